@@ -0,0 +1,164 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+const (
+	// AIPParamPageSize is the page size param of the AIP-158, zero means the
+	// default size of the policy
+	AIPParamPageSize = "page_size"
+	// AIPParamPageToken is the param carrying the next_page_token of the
+	// previous page on the AIP-158
+	AIPParamPageToken = "page_token"
+)
+
+// aipCheckParam is the param kept inside the tokens with the fingerprint of
+// the request that issued them
+const aipCheckParam = "check"
+
+// errNegativePageSize is the error returned for a negative page size, which
+// the AIP-158 refuses as an invalid argument
+var errNegativePageSize = errors.New("pagination: the page_size can't be negative")
+
+// AIPParams type encapsulates the params found on a request following the
+// AIP-158 (https://google.aip.dev/158), the page is located by the opaque
+// page token answered back as the next_page_token of the previous page
+type AIPParams struct {
+	Params
+
+	// cursors is the codec signing the tokens, when there is none they are
+	// just encoded with base64
+	cursors *TokenCodec
+	// fingerprint identifies the request without the page params, the tokens
+	// are only accepted by the same request
+	fingerprint string
+}
+
+// AIPPage type encapsulates a paginated response following the AIP-158, the
+// items are answered back under the given Key, like books
+type AIPPage struct {
+	Key           string
+	Items         []interface{}
+	NextPageToken string
+}
+
+// FindAIPParams will find the params on the request following the AIP-158
+// rules, applying the rules of the given policy:
+//
+//   - a page_size of zero, or missing, uses the DefaultLimit of the policy
+//   - a page_size bigger than the MaxLimit of the policy is coerced to it
+//   - a negative page_size is refused
+//   - a page_token is only accepted when the rest of the request, like the
+//     filters or the sort, is the same as the one that issued it, otherwise a
+//     *TokenError matching ErrTokenMismatch is returned
+//
+// When the policy has Tokens the page tokens are signed with them
+func FindAIPParams(req *http.Request, policy Policy) (AIPParams, error) {
+	aip := AIPParams{cursors: policy.Tokens, fingerprint: aipFingerprint(req.URL.RawQuery)}
+	rawQuery, err := policy.preset().applyDuplicateParams(req.URL.RawQuery, AIPParamPageSize, AIPParamPageToken)
+	if err == nil {
+		rawQuery, err = aip.rawQuery(req, rawQuery, policy)
+	}
+	if err == nil {
+		policy = policy.preset()
+		err = policy.findParamsInto(req, rawQuery, &aip.Params)
+	}
+	if aip.cursors != nil && aip.cursors.CorrelationIDs {
+		aip.correlationID = CorrelationID(req.Context())
+	}
+	if aip.cursors != nil {
+		aip.scope = TokenScope(req.Context())
+	}
+	return aip, policy.found(req, &aip.Params, err)
+}
+
+// rawQuery method will translate the AIP-158 params of the given raw query of
+// the request into the pagination params. The pagination params sent by the
+// client are dropped, the page is only located by the page_token
+func (a AIPParams) rawQuery(req *http.Request, rawQuery string, policy Policy) (string, error) {
+	var prefix string
+	if size := lookupParam(rawQuery, AIPParamPageSize); size != "" {
+		pageSize, err := strconv.ParseInt(size, 10, 32)
+		if err != nil {
+			return "", err
+		}
+		if pageSize < 0 {
+			return "", errNegativePageSize
+		}
+		if pageSize > 0 {
+			prefix = ParamPageLimit + "=" + strconv.FormatInt(pageSize, 10) + "&"
+		}
+	}
+	if token := lookupParam(rawQuery, AIPParamPageToken); token != "" {
+		query, err := policy.decodeCursor(req, token)
+		if err != nil {
+			return "", err
+		}
+		if lookupParam(query, aipCheckParam) != a.fingerprint {
+			return "", &TokenError{Failure: TokenRequestMismatch, Reason: "the request changed since the token was issued"}
+		}
+		prefix += ParamPageOffset + "=" + url.QueryEscape(lookupParam(query, ParamPageOffset)) + "&"
+	}
+	return prefix + withoutPageParams(rawQuery), nil
+}
+
+// Paginate method will build the AIP-158 page for the given data, the items
+// are answered back under the given key and the next page token is empty on
+// the last page
+func (a AIPParams) Paginate(key string, data []interface{}) AIPPage {
+	a.pageServed(len(data))
+	page := AIPPage{Key: key, Items: buildData(data, a.Params)}
+	if page.Items == nil {
+		page.Items = []interface{}{}
+	}
+	if uint(len(data)) > a.Limit {
+		query := ParamPageOffset + "=" + strconv.FormatUint(uint64(a.Offset+a.Limit), 10) + "&" + aipCheckParam + "=" + a.fingerprint
+		page.NextPageToken = encodeCursor(a.cursors, query, a.correlationID, a.scope)
+	}
+	a.paginated(context.Background(), Response{Data: page.Items, Links: Links{Next: page.NextPageToken}})
+	return page
+}
+
+// MarshalJSON method will encode the page with the items under its key
+func (p AIPPage) MarshalJSON() ([]byte, error) {
+	key, err := json.Marshal(p.Key)
+	if err != nil {
+		return nil, err
+	}
+	items, err := json.Marshal(p.Items)
+	if err != nil {
+		return nil, err
+	}
+	token, err := json.Marshal(p.NextPageToken)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	b.Write(key)
+	b.WriteByte(':')
+	b.Write(items)
+	b.WriteString(`,"next_page_token":`)
+	b.Write(token)
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// aipFingerprint function will identify the given raw query without the page
+// params, the order of the params doesn't matter
+func aipFingerprint(rawQuery string) string {
+	values, _ := url.ParseQuery(withoutPageParams(rawQuery))
+	values.Del(AIPParamPageSize)
+	values.Del(AIPParamPageToken)
+	sum := sha256.Sum256([]byte(values.Encode()))
+	return hex.EncodeToString(sum[:8])
+}
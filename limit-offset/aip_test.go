@@ -0,0 +1,203 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+var aipPolicy = pagination.Policy{DefaultLimit: 2, MaxLimit: 3}
+
+// aipHandler will serve the given books following the AIP-158 with the policy
+func aipHandler(books []interface{}, policy pagination.Policy) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		aip, err := pagination.FindAIPParams(req, policy)
+		if err != nil {
+			wr.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		end := aip.Offset + aip.Limit + 1
+		if end > uint(len(books)) {
+			end = uint(len(books))
+		}
+		start := aip.Offset
+		if start > end {
+			start = end
+		}
+		json.NewEncoder(wr).Encode(aip.Paginate("books", books[start:end]))
+	})
+}
+
+func TestFindAIPParams(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantLimit uint
+		wantErr   error
+	}{
+		{
+			name:      "Missing page size",
+			url:       "app.quicka.co/api/books",
+			wantLimit: 2,
+		},
+		{
+			name:      "Zero page size",
+			url:       "app.quicka.co/api/books?page_size=0",
+			wantLimit: 2,
+		},
+		{
+			name:      "Page size coerced to the maximum",
+			url:       "app.quicka.co/api/books?page_size=50",
+			wantLimit: 3,
+		},
+		{
+			name:    "Negative page size",
+			url:     "app.quicka.co/api/books?page_size=-1",
+			wantErr: errors.New("pagination: the page_size can't be negative"),
+		},
+		{
+			name:    "Malformed page token",
+			url:     "app.quicka.co/api/books?page_token=not*base64",
+			wantErr: pagination.ErrTokenMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			aip, err := pagination.FindAIPParams(req, aipPolicy)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					assert.Equal(t, tt.wantErr.Error(), err.Error())
+				}
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, aip.Limit)
+		})
+	}
+}
+
+func TestAIPPageTokens(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/books?page_size=1&filter[author]=tolkien", nil)
+	assert.Nil(t, err)
+	aip, err := pagination.FindAIPParams(req, aipPolicy)
+	assert.Nil(t, err)
+	page := aip.Paginate("books", []interface{}{"a", "b"})
+	assert.NotEmpty(t, page.NextPageToken)
+
+	next := "app.quicka.co/api/books?filter[author]=tolkien&page_token=" + url.QueryEscape(page.NextPageToken)
+	req, err = http.NewRequest(http.MethodGet, next, nil)
+	assert.Nil(t, err)
+	aip, err = pagination.FindAIPParams(req, aipPolicy)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), aip.Offset)
+	assert.Equal(t, uint(2), aip.Limit)
+
+	changed := "app.quicka.co/api/books?filter[author]=martin&page_token=" + url.QueryEscape(page.NextPageToken)
+	req, err = http.NewRequest(http.MethodGet, changed, nil)
+	assert.Nil(t, err)
+	_, err = pagination.FindAIPParams(req, aipPolicy)
+	assert.True(t, errors.Is(err, pagination.ErrTokenMismatch))
+
+	body, err := json.Marshal(pagination.AIPPage{Key: "books", Items: []interface{}{"a"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"books": ["a"], "next_page_token": ""}`, string(body))
+}
+
+func TestAIPIgnoresPageParams(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/books?page_size=1&filter[author]=tolkien", nil)
+	assert.Nil(t, err)
+	aip, err := pagination.FindAIPParams(req, aipPolicy)
+	assert.Nil(t, err)
+	page := aip.Paginate("books", []interface{}{"a", "b"})
+
+	tests := map[string]struct {
+		query      string
+		wantOffset uint
+		wantLimit  uint
+	}{
+		"offset without page token": {
+			query:      "filter[author]=tolkien&page[offset]=5000",
+			wantOffset: 0,
+			wantLimit:  2,
+		},
+		"escaped offset without page token": {
+			query:      "filter[author]=tolkien&page%5Boffset%5D=5000&page[number]=40",
+			wantOffset: 0,
+			wantLimit:  2,
+		},
+		"offset with page token": {
+			query:      "filter[author]=tolkien&page[offset]=5000&page_token=" + url.QueryEscape(page.NextPageToken),
+			wantOffset: 1,
+			wantLimit:  2,
+		},
+		"limit with page size": {
+			query:      "filter[author]=tolkien&page_size=1&page[limit]=100",
+			wantOffset: 0,
+			wantLimit:  1,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/books?"+tt.query, nil)
+			assert.Nil(t, err)
+			aip, err := pagination.FindAIPParams(req, aipPolicy)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantOffset, aip.Offset)
+			assert.Equal(t, tt.wantLimit, aip.Limit)
+		})
+	}
+}
+
+func TestAIPConformance(t *testing.T) {
+	books := []interface{}{"a", "b", "c", "d", "e"}
+
+	tests := []struct {
+		name    string
+		handler http.Handler
+		wantErr bool
+	}{
+		{
+			name:    "Compliant endpoint",
+			handler: aipHandler(books, aipPolicy),
+		},
+		{
+			name:    "Page size not coerced",
+			handler: aipHandler(books, pagination.Policy{DefaultLimit: 2}),
+			wantErr: true,
+		},
+		{
+			name: "Everything refused",
+			handler: http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+				wr.WriteHeader(http.StatusBadRequest)
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := pagination.AIPConformance{
+				Handler:         tt.handler,
+				Target:          "/v1/books",
+				Key:             "books",
+				DefaultPageSize: 2,
+				MaxPageSize:     3,
+				ChangedQuery:    "filter[author]=tolkien",
+			}.Check()
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// aipMaxPages is the maximum number of pages walked by the conformance checks
+const aipMaxPages = 1000
+
+// AIPConformance type encapsulates the checks of the AIP-158 rules against a
+// list endpoint, so the services can certify their compliance on their tests
+//
+//	err := pagination.AIPConformance{
+//	  Handler:         router,
+//	  Target:          "/v1/books",
+//	  Key:             "books",
+//	  DefaultPageSize: 10,
+//	  MaxPageSize:     100,
+//	  ChangedQuery:    "filter[author]=someone",
+//	}.Check()
+//
+// The endpoint needs at least two items for checking the page tokens
+type AIPConformance struct {
+	// Handler is the handler serving the list endpoint
+	Handler http.Handler
+	// Target is the URL of the list endpoint, like /v1/books
+	Target string
+	// Key is the key of the items on the response, like books
+	Key string
+	// DefaultPageSize is the page size expected when the page_size is zero
+	// or missing
+	DefaultPageSize uint
+	// MaxPageSize is the maximum page size, the bigger ones must be coerced
+	// to it. Zero skips the check
+	MaxPageSize uint
+	// ChangedQuery is a query changing the request, like a filter, the page
+	// tokens must be refused when it is added. Empty skips the check
+	ChangedQuery string
+}
+
+// aipResponse type encapsulates the response of the list endpoint read by the
+// conformance checks
+type aipResponse struct {
+	status        int
+	items         int
+	nextPageToken string
+}
+
+// Check method will run all the checks against the endpoint, the error joins
+// the failure of every check that didn't pass
+func (c AIPConformance) Check() error {
+	return errors.Join(
+		c.checkDefaultPageSize(),
+		c.checkMaxPageSize(),
+		c.checkNegativePageSize(),
+		c.checkMalformedToken(),
+		c.checkPageTokens(),
+		c.checkChangedRequest(),
+	)
+}
+
+// checkDefaultPageSize method will check a zero page size works like a missing
+// one, using the default page size
+func (c AIPConformance) checkDefaultPageSize() error {
+	for _, query := range []string{"", AIPParamPageSize + "=0"} {
+		res, err := c.get(query)
+		if err != nil {
+			return err
+		}
+		if res.status != http.StatusOK {
+			return fmt.Errorf("aip-158: %q answered back the status %d instead of 200", c.url(query), res.status)
+		}
+		if uint(res.items) > c.DefaultPageSize {
+			return fmt.Errorf("aip-158: %q answered back %d items, more than the default page size %d", c.url(query), res.items, c.DefaultPageSize)
+		}
+	}
+	return nil
+}
+
+// checkMaxPageSize method will check the page sizes bigger than the maximum
+// are coerced to it instead of refused
+func (c AIPConformance) checkMaxPageSize() error {
+	if c.MaxPageSize == 0 {
+		return nil
+	}
+	query := AIPParamPageSize + "=" + strconv.FormatUint(uint64(c.MaxPageSize)+1, 10)
+	res, err := c.get(query)
+	if err != nil {
+		return err
+	}
+	if res.status != http.StatusOK {
+		return fmt.Errorf("aip-158: %q answered back the status %d instead of coercing the page size", c.url(query), res.status)
+	}
+	if uint(res.items) > c.MaxPageSize {
+		return fmt.Errorf("aip-158: %q answered back %d items, more than the maximum page size %d", c.url(query), res.items, c.MaxPageSize)
+	}
+	return nil
+}
+
+// checkNegativePageSize method will check the negative page sizes are refused
+func (c AIPConformance) checkNegativePageSize() error {
+	return c.expectRefused(AIPParamPageSize + "=-1")
+}
+
+// checkMalformedToken method will check the malformed page tokens are refused
+func (c AIPConformance) checkMalformedToken() error {
+	return c.expectRefused(AIPParamPageToken + "=" + url.QueryEscape("not a token!"))
+}
+
+// checkPageTokens method will walk all the pages one item at a time, checking
+// the last page has an empty next page token
+func (c AIPConformance) checkPageTokens() error {
+	query := AIPParamPageSize + "=1"
+	for page := 0; page < aipMaxPages; page++ {
+		res, err := c.get(query)
+		if err != nil {
+			return err
+		}
+		if res.status != http.StatusOK {
+			return fmt.Errorf("aip-158: %q answered back the status %d instead of 200", c.url(query), res.status)
+		}
+		if res.nextPageToken == "" {
+			if page == 0 {
+				return fmt.Errorf("aip-158: %q didn't answer back a next page token, the endpoint needs at least two items", c.url(query))
+			}
+			return nil
+		}
+		if res.items == 0 {
+			return fmt.Errorf("aip-158: %q answered back a next page token on an empty page", c.url(query))
+		}
+		query = AIPParamPageSize + "=1&" + AIPParamPageToken + "=" + url.QueryEscape(res.nextPageToken)
+	}
+	return fmt.Errorf("aip-158: %q still had a next page token after %d pages", c.url(AIPParamPageSize+"=1"), aipMaxPages)
+}
+
+// checkChangedRequest method will check a page token is refused once the rest
+// of the request changed
+func (c AIPConformance) checkChangedRequest() error {
+	if c.ChangedQuery == "" {
+		return nil
+	}
+	res, err := c.get(AIPParamPageSize + "=1")
+	if err != nil {
+		return err
+	}
+	if res.nextPageToken == "" {
+		return nil
+	}
+	return c.expectRefused(c.ChangedQuery + "&" + AIPParamPageSize + "=1&" + AIPParamPageToken + "=" + url.QueryEscape(res.nextPageToken))
+}
+
+// expectRefused method will check the request with the given query is refused
+// with a 400 status
+func (c AIPConformance) expectRefused(query string) error {
+	res, err := c.get(query)
+	if err != nil {
+		return err
+	}
+	if res.status != http.StatusBadRequest {
+		return fmt.Errorf("aip-158: %q answered back the status %d instead of 400", c.url(query), res.status)
+	}
+	return nil
+}
+
+// get method will call the endpoint with the given query, the items and next
+// page token are only read on the successful responses
+func (c AIPConformance) get(query string) (aipResponse, error) {
+	rec := httptest.NewRecorder()
+	c.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, c.url(query), nil))
+	res := aipResponse{status: rec.Code}
+	if rec.Code != http.StatusOK {
+		return res, nil
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		return res, fmt.Errorf("aip-158: %q answered back an invalid body: %w", c.url(query), err)
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(body[c.Key], &items); err != nil {
+		return res, fmt.Errorf("aip-158: %q answered back invalid %s: %w", c.url(query), c.Key, err)
+	}
+	res.items = len(items)
+	if token, ok := body["next_page_token"]; ok {
+		if err := json.Unmarshal(token, &res.nextPageToken); err != nil {
+			return res, fmt.Errorf("aip-158: %q answered back an invalid next_page_token: %w", c.url(query), err)
+		}
+	}
+	return res, nil
+}
+
+// url method will build the URL of the endpoint with the given query
+func (c AIPConformance) url(query string) string {
+	if query == "" {
+		return c.Target
+	}
+	if strings.Contains(c.Target, "?") {
+		return c.Target + "&" + query
+	}
+	return c.Target + "?" + query
+}
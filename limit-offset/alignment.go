@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrUnalignedOffset is the error returned by the policies refusing the
+// offsets that aren't a multiple of the limit, see OffsetAlignmentReject
+var ErrUnalignedOffset = errors.New("pagination: unaligned offset")
+
+// OffsetAlignment type defines how the offsets that aren't a multiple of the
+// limit are handled, like offset=7 with limit=5, the pages of those requests
+// don't match the pages of the rest of clients
+type OffsetAlignment int
+
+const (
+	// OffsetAlignmentAny accepts the offsets as they are
+	OffsetAlignmentAny OffsetAlignment = iota
+	// OffsetAlignmentSnap moves the offsets to the start of the page
+	// containing them, like offset=5 for offset=7 with limit=5
+	OffsetAlignmentSnap
+	// OffsetAlignmentReject refuses the unaligned offsets with
+	// ErrUnalignedOffset
+	OffsetAlignmentReject
+)
+
+// applyOffsetAlignment method will handle the unaligned offset of the given
+// params following the OffsetAlignment of the policy
+func (p Policy) applyOffsetAlignment(ctx context.Context, params *Params) error {
+	if p.OffsetAlignment == OffsetAlignmentAny || params.Limit == 0 || params.Offset%params.Limit == 0 {
+		return nil
+	}
+	if p.OffsetAlignment == OffsetAlignmentReject {
+		return unalignedOffset(*params)
+	}
+	offset := params.Offset / params.Limit * params.Limit
+	p.log(ctx, "pagination: offset snapped", "raw_offset", params.Offset, "offset", offset)
+	params.Offset = offset
+	return nil
+}
+
+// unalignedOffset function will build the error for the unaligned offset of
+// the given params
+func unalignedOffset(params Params) error {
+	return fmt.Errorf("%w: the offset %d is not a multiple of the limit %d", ErrUnalignedOffset, params.Offset, params.Limit)
+}
+
+// prevOffset function will compute the offset of the previous page, which
+// starts on the first item when the offset is smaller than the limit, so the
+// unaligned offsets don't go before the first item
+func prevOffset(params Params) uint {
+	if params.Offset < params.Limit {
+		return 0
+	}
+	return params.Offset - params.Limit
+}
@@ -0,0 +1,90 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyOffsetAlignment(t *testing.T) {
+	tests := []struct {
+		name       string
+		alignment  pagination.OffsetAlignment
+		url        string
+		wantOffset uint
+		wantErr    error
+	}{
+		{
+			name:       "Unaligned offset accepted",
+			alignment:  pagination.OffsetAlignmentAny,
+			url:        "/users?page[limit]=5&page[offset]=7",
+			wantOffset: 7,
+		},
+		{
+			name:       "Unaligned offset snapped",
+			alignment:  pagination.OffsetAlignmentSnap,
+			url:        "/users?page[limit]=5&page[offset]=7",
+			wantOffset: 5,
+		},
+		{
+			name:      "Unaligned offset rejected",
+			alignment: pagination.OffsetAlignmentReject,
+			url:       "/users?page[limit]=5&page[offset]=7",
+			wantErr:   pagination.ErrUnalignedOffset,
+		},
+		{
+			name:       "Aligned offset",
+			alignment:  pagination.OffsetAlignmentReject,
+			url:        "/users?page[limit]=5&page[offset]=10",
+			wantOffset: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			params, err := pagination.Policy{OffsetAlignment: tt.alignment}.FindParams(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOffset, params.Offset)
+		})
+	}
+}
+
+func TestOffsetAlignmentValidate(t *testing.T) {
+	policy := pagination.Policy{OffsetAlignment: pagination.OffsetAlignmentReject}
+	assert.ErrorIs(t, pagination.Params{Limit: 5, Offset: 7}.Validate(policy), pagination.ErrUnalignedOffset)
+	assert.NoError(t, pagination.Params{Limit: 5, Offset: 5}.Validate(policy))
+}
+
+func TestUnalignedPrevLink(t *testing.T) {
+	tests := []struct {
+		name   string
+		params pagination.Params
+		want   string
+	}{
+		{
+			name:   "Offset smaller than the limit",
+			params: pagination.Params{Limit: 5, Offset: 3},
+			want:   "/users?page[limit]=5&page[offset]=0",
+		},
+		{
+			name:   "Offset bigger than the limit",
+			params: pagination.Params{Limit: 5, Offset: 7},
+			want:   "/users?page[limit]=5&page[offset]=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.Paginate(nil, "/users", tt.params).Links.Prev)
+			template := pagination.NewLinkTemplate("/users", tt.params)
+			assert.Equal(t, tt.want, template.Links(tt.params, 0).Prev)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// ErrInvalidBaseURL is the error returned by SanitizeBaseURL for the base URLs
+// that can't be used on the links
+var ErrInvalidBaseURL = errors.New("pagination: invalid base URL")
+
+// SanitizeBaseURL will validate the given base URL before building the links
+// with it, as the links of the responses are followed by the clients. A
+// rooted path, like /users, is expected, the absolute URLs are only accepted
+// for the given hosts and with the http or https schemes. The base URLs with
+// credentials, a query, a fragment, backslashes or control characters are
+// refused, which includes the protocol relative ones, like //evil.com, as
+// they override the host. The path is cleaned, so /users//../admin is
+// answered back as /admin
+func SanitizeBaseURL(baseURL string, hosts ...string) (string, error) {
+	if baseURL == "" {
+		return "", nil
+	}
+	if strings.ContainsFunc(baseURL, func(r rune) bool { return r < 0x20 || r == 0x7f || r == '\\' }) {
+		return "", ErrInvalidBaseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.User != nil || u.Opaque != "" || u.RawQuery != "" || u.ForceQuery || u.Fragment != "" {
+		return "", ErrInvalidBaseURL
+	}
+	switch {
+	case u.Scheme == "" && u.Host == "":
+		if !strings.HasPrefix(u.Path, "/") {
+			return "", ErrInvalidBaseURL
+		}
+	case u.Scheme != "http" && u.Scheme != "https":
+		return "", ErrInvalidBaseURL
+	case !allowedHost(u.Host, hosts):
+		return "", ErrInvalidBaseURL
+	}
+	if u.Path != "" {
+		cleaned := path.Clean(u.Path)
+		if strings.HasSuffix(u.Path, "/") && cleaned != "/" {
+			cleaned += "/"
+		}
+		u.Path, u.RawPath = cleaned, ""
+	}
+	return u.String(), nil
+}
+
+// allowedHost function will check if the given host is one of the hosts
+func allowedHost(host string, hosts []string) bool {
+	for _, allowed := range hosts {
+		if strings.EqualFold(host, allowed) {
+			return true
+		}
+	}
+	return false
+}
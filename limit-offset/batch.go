@@ -0,0 +1,58 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// resourceParamNames function will build the param names used for paginating
+// the given resource inside a batch request, for example page[users][limit],
+// page[users][offset] and sort[users]
+func resourceParamNames(resource string) paramNames {
+	return paramNames{
+		limit:   "page[" + resource + "][limit]",
+		offset:  "page[" + resource + "][offset]",
+		sort:    ParamSortBy + "[" + resource + "]",
+		orderBy: ParamOrderBy + "[" + resource + "]",
+		seed:    ParamSeed + "[" + resource + "]",
+		number:  "page[" + resource + "][number]",
+		size:    "page[" + resource + "][size]",
+	}
+}
+
+// FindBatchParams will find the pagination params of each one of the given
+// resources on a request that asks for several collections at once, each one
+// with its own params, for example
+//
+//	/dashboard?page[users][limit]=5&page[orders][limit]=10&sort[orders]=created_at.desc
+//
+// The resources without params on the request will get the given defaults
+func FindBatchParams(req *http.Request, defaultOffset, defaultLimit uint, resources ...string) (map[string]Params, error) {
+	batch := make(map[string]Params, len(resources))
+	for _, resource := range resources {
+		params := Params{
+			Limit:  defaultLimit,
+			Offset: defaultOffset,
+		}
+		if err := findParams(req.URL.RawQuery, resourceParamNames(resource), SortFormatAuto, &params); err != nil {
+			return nil, fmt.Errorf("%s: %w", resource, err)
+		}
+		batch[resource] = params
+	}
+	return batch, nil
+}
+
+// PaginateBatch will build a paginated response for each one of the resources
+// found on the given data, using the params of the same resource. The links of
+// each response only move through the pages of its own resource
+func PaginateBatch(data map[string][]interface{}, baseURL string, params map[string]Params) map[string]Response {
+	batch := make(map[string]Response, len(data))
+	for resource, resourceData := range data {
+		resourceParams := params[resource]
+		batch[resource] = Response{
+			Data:  buildData(resourceData, resourceParams),
+			Links: buildLinksWithNames(baseURL, resourceParamNames(resource), resourceParams, len(resourceData)),
+		}
+	}
+	return batch
+}
@@ -0,0 +1,81 @@
+package pagination_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindBatchParams(t *testing.T) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/dashboard?page[users][limit]=5&page[users][offset]=10&page[orders][limit]=2&sort[orders]=created_at.desc&page[limit]=50",
+		nil,
+	)
+	assert.Nil(t, err)
+
+	batch, err := pagination.FindBatchParams(req, 0, 20, "users", "orders", "invoices")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(batch))
+
+	assert.Equal(t, uint(5), batch["users"].Limit)
+	assert.Equal(t, uint(10), batch["users"].Offset)
+	assert.Equal(t, 0, len(batch["users"].Sort))
+
+	assert.Equal(t, uint(2), batch["orders"].Limit)
+	assert.Equal(t, uint(0), batch["orders"].Offset)
+	assert.Equal(t, []pagination.Sort{{Field: "created_at", Order: "desc"}}, batch["orders"].Sort)
+
+	assert.Equal(t, uint(20), batch["invoices"].Limit)
+	assert.Equal(t, uint(0), batch["invoices"].Offset)
+
+	wrongReq, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/dashboard?page[orders][limit]=wrong", nil)
+	assert.Nil(t, err)
+	_, err = pagination.FindBatchParams(wrongReq, 0, 20, "users", "orders")
+	assert.NotNil(t, err)
+}
+
+func TestPaginateBatch(t *testing.T) {
+	data := map[string][]interface{}{
+		"users":  {"user", "user2", "user3"},
+		"orders": {"order"},
+	}
+	params := map[string]pagination.Params{
+		"users": {Limit: 2, Offset: 2},
+		"orders": {
+			Limit: 2,
+			Sort:  []pagination.Sort{{Field: "created_at", Order: "desc"}},
+		},
+	}
+
+	batch := pagination.PaginateBatch(data, "/dashboard", params)
+	assert.Equal(t, 2, len(batch))
+
+	assert.Equal(t, []interface{}{"user", "user2"}, batch["users"].Data)
+	assert.Equal(t, pagination.Links{
+		First: "/dashboard?page[users][limit]=2&page[users][offset]=0",
+		Prev:  "/dashboard?page[users][limit]=2&page[users][offset]=0",
+		Next:  "/dashboard?page[users][limit]=2&page[users][offset]=4",
+	}, batch["users"].Links)
+
+	assert.Equal(t, []interface{}{"order"}, batch["orders"].Data)
+	assert.Equal(t, pagination.Links{
+		First: "/dashboard?page[orders][limit]=2&page[orders][offset]=0&sort[orders]=created_at.desc",
+	}, batch["orders"].Links)
+}
+
+func TestBatchSeedInjection(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/dashboard?sort[users]=random&seed[users]=1%26page[users][offset]%3D99999", nil)
+	assert.Nil(t, err)
+	_, err = pagination.FindBatchParams(req, 0, 20, "users")
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	params := map[string]pagination.Params{
+		"users": {Limit: 2, Sort: []pagination.Sort{{Field: "random", Order: "asc"}}, Seed: "1&page[users][offset]=99999"},
+	}
+	links := pagination.PaginateBatch(map[string][]interface{}{"users": {"user"}}, "/dashboard", params)["users"].Links
+	assert.Equal(t, "/dashboard?page[users][limit]=2&page[users][offset]=0&sort[users]=random.asc&seed[users]=1%26page%5Busers%5D%5Boffset%5D%3D99999", links.First)
+}
@@ -0,0 +1,100 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// HeaderCacheControl is the header name for the cache directives
+	HeaderCacheControl = "Cache-Control"
+	// HeaderSurrogateKey is the header name used by CDNs for tagging cached
+	// responses so they can be purged later
+	HeaderSurrogateKey = "Surrogate-Key"
+)
+
+// CachePolicy type encapsulates how the pages of a resource should be cached
+// by clients and CDNs
+type CachePolicy struct {
+	// MaxAge is how long the clients can cache a page
+	MaxAge time.Duration
+	// SharedMaxAge is how long the CDNs can cache a page, when zero the MaxAge
+	// is used also for them
+	SharedMaxAge time.Duration
+	// StaleWhileRevalidate is how long a stale page can be served while it is
+	// revalidated in background
+	StaleWhileRevalidate time.Duration
+	// Private means the page is only cacheable by the client, for example
+	// pages that depend on the authenticated user
+	Private bool
+}
+
+// CacheControl will build the Cache-Control header value for the policy
+func (c CachePolicy) CacheControl() string {
+	if c.MaxAge <= 0 && c.SharedMaxAge <= 0 {
+		return "no-cache"
+	}
+	directives := []string{"public"}
+	if c.Private {
+		directives[0] = "private"
+	}
+	directives = append(directives, "max-age="+seconds(c.MaxAge))
+	if c.SharedMaxAge > 0 && !c.Private {
+		directives = append(directives, "s-maxage="+seconds(c.SharedMaxAge))
+	}
+	if c.StaleWhileRevalidate > 0 {
+		directives = append(directives, "stale-while-revalidate="+seconds(c.StaleWhileRevalidate))
+	}
+	return strings.Join(directives, ", ")
+}
+
+// SetCacheHeaders will set the Cache-Control header using the given policy and
+// the surrogate keys for the page of the resource the params are pointing to
+func SetCacheHeaders(wr http.ResponseWriter, resource string, params Params, policy CachePolicy) {
+	wr.Header().Set(HeaderCacheControl, policy.CacheControl())
+	if !policy.Private {
+		wr.Header().Set(HeaderSurrogateKey, strings.Join(SurrogateKeys(resource, params), " "))
+	}
+}
+
+// SurrogateKeys will build the surrogate keys for the page of the resource the
+// params are pointing to, the first one is the key of the whole resource and
+// the second one the key of the page, for example users and users:page:3
+func SurrogateKeys(resource string, params Params) []string {
+	return []string{resource, PageKey(resource, pageNumber(params))}
+}
+
+// PurgeKeys will build the keys to purge from the CDN when the resource
+// changes, without pages the key of the whole resource is returned
+func PurgeKeys(resource string, pages ...uint) []string {
+	if len(pages) == 0 {
+		return []string{resource}
+	}
+	keys := make([]string, 0, len(pages))
+	for _, page := range pages {
+		keys = append(keys, PageKey(resource, page))
+	}
+	return keys
+}
+
+// PageKey will build the surrogate key of a single page of the resource, pages
+// start on 1
+func PageKey(resource string, page uint) string {
+	return resource + ":page:" + strconv.FormatUint(uint64(page), 10)
+}
+
+// pageNumber function will find the page number, starting on 1, the params are
+// pointing to
+func pageNumber(params Params) uint {
+	if params.Limit == 0 {
+		return 1
+	}
+	return params.Offset/params.Limit + 1
+}
+
+// seconds function will format the given duration as seconds
+func seconds(d time.Duration) string {
+	return strconv.FormatInt(int64(d/time.Second), 10)
+}
@@ -0,0 +1,100 @@
+package pagination_test
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheControl(t *testing.T) {
+	tests := []struct {
+		name string
+		args pagination.CachePolicy
+		want string
+	}{
+		{
+			name: "Empty policy",
+			args: pagination.CachePolicy{},
+			want: "no-cache",
+		},
+		{
+			name: "Public policy",
+			args: pagination.CachePolicy{MaxAge: time.Minute},
+			want: "public, max-age=60",
+		},
+		{
+			name: "Public policy for CDNs",
+			args: pagination.CachePolicy{
+				MaxAge:               time.Minute,
+				SharedMaxAge:         time.Hour,
+				StaleWhileRevalidate: 30 * time.Second,
+			},
+			want: "public, max-age=60, s-maxage=3600, stale-while-revalidate=30",
+		},
+		{
+			name: "Private policy",
+			args: pagination.CachePolicy{
+				MaxAge:       time.Minute,
+				SharedMaxAge: time.Hour,
+				Private:      true,
+			},
+			want: "private, max-age=60",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.CacheControl())
+		})
+	}
+}
+
+func TestSurrogateKeys(t *testing.T) {
+	tests := []struct {
+		name string
+		args pagination.Params
+		want []string
+	}{
+		{
+			name: "Default params",
+			args: pagination.Params{},
+			want: []string{"users", "users:page:1"},
+		},
+		{
+			name: "First page",
+			args: pagination.Params{Limit: 10},
+			want: []string{"users", "users:page:1"},
+		},
+		{
+			name: "Third page",
+			args: pagination.Params{Limit: 10, Offset: 20},
+			want: []string{"users", "users:page:3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.SurrogateKeys("users", tt.args))
+		})
+	}
+}
+
+func TestPurgeKeys(t *testing.T) {
+	assert.Equal(t, []string{"users"}, pagination.PurgeKeys("users"))
+	assert.Equal(t, []string{"users:page:1", "users:page:2"}, pagination.PurgeKeys("users", 1, 2))
+}
+
+func TestSetCacheHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	pagination.SetCacheHeaders(rec, "users", pagination.Params{Limit: 10, Offset: 20}, pagination.CachePolicy{MaxAge: time.Minute})
+	assert.Equal(t, "public, max-age=60", rec.Header().Get(pagination.HeaderCacheControl))
+	assert.Equal(t, "users users:page:3", rec.Header().Get(pagination.HeaderSurrogateKey))
+
+	rec = httptest.NewRecorder()
+	pagination.SetCacheHeaders(rec, "users", pagination.Params{Limit: 10}, pagination.CachePolicy{MaxAge: time.Minute, Private: true})
+	assert.Equal(t, "private, max-age=60", rec.Header().Get(pagination.HeaderCacheControl))
+	assert.Equal(t, "", rec.Header().Get(pagination.HeaderSurrogateKey))
+}
@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"sort"
+	"strings"
+)
+
+// CanonicalQuery will reorder the params of the given raw query following the
+// order of the generated links, see Links, so the URLs selecting the same page
+// are byte-identical no matter the order the client used, which is handy for
+// the cache keys and the response diffing. The params aren't unescaped
+func CanonicalQuery(rawQuery string) string {
+	return canonicalQuery(rawQuery, defaultParamNames)
+}
+
+// CanonicalQuery method will reorder the params of the given raw query like
+// the CanonicalQuery function does using the param names of the policy
+func (p Policy) CanonicalQuery(rawQuery string) string {
+	return canonicalQuery(rawQuery, p.names.orDefault())
+}
+
+// queryPair type keeps a param of the raw query with the values used for
+// ordering it
+type queryPair struct {
+	raw    string
+	name   string
+	rank   int
+	filter Filter
+}
+
+// canonicalQuery function will reorder the params of the given raw query using
+// the given param names. The params with the same position keep the order of
+// the raw query, except the filters, which are sorted like Filters.Encode. As
+// the signature of the links depends on the order, the incoming URLs have to
+// be verified before they are reordered
+func canonicalQuery(rawQuery string, names paramNames) string {
+	var pairs []queryPair
+	for rawQuery != "" {
+		var raw string
+		raw, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if raw == "" {
+			continue
+		}
+		key, value, _ := strings.Cut(raw, "=")
+		name, ok := unescapeQuery(key)
+		if !ok {
+			name = key
+		}
+		pair := queryPair{raw: raw, name: name, rank: names.rank(name)}
+		if field, op, ok := filterKey(name); ok && pair.rank == rankFilter {
+			value, _ = unescapeQuery(value)
+			pair.filter = newFilter(field, op, value)
+		}
+		pairs = append(pairs, pair)
+	}
+	sort.SliceStable(pairs, func(i, j int) bool {
+		a, b := pairs[i], pairs[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		switch a.rank {
+		case rankFilter:
+			if a.filter.Field != b.filter.Field {
+				return a.filter.Field < b.filter.Field
+			}
+			if a.filter.Operator != b.filter.Operator {
+				return a.filter.Operator < b.filter.Operator
+			}
+			return a.filter.Value < b.filter.Value
+		case rankOther:
+			return a.name < b.name
+		}
+		return false
+	})
+	var b strings.Builder
+	for i, pair := range pairs {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(pair.raw)
+	}
+	return b.String()
+}
+
+const (
+	// rankFilter is the position of the filters on the links
+	rankFilter = iota + 7
+	// rankOther is the position of the rest of params, sorted by name
+	rankOther
+	// rankSignature is the position of the signature, always the last one
+	rankSignature
+)
+
+// rank method will answer back the position of the given param on the links
+func (n paramNames) rank(name string) int {
+	switch name {
+	case n.limit:
+		return 0
+	case n.offset:
+		return 1
+	case n.number:
+		return 2
+	case n.size:
+		return 3
+	case n.sort:
+		return 4
+	case n.orderBy:
+		return 5
+	case n.seed:
+		return 6
+	case ParamSignature:
+		return rankSignature
+	}
+	if _, _, ok := filterKey(name); ok {
+		return rankFilter
+	}
+	return rankOther
+}
@@ -0,0 +1,66 @@
+package pagination_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		rawQuery string
+		want     string
+	}{
+		{
+			name:     "Already canonical",
+			rawQuery: "page[limit]=10&page[offset]=20&sort=name.asc",
+			want:     "page[limit]=10&page[offset]=20&sort=name.asc",
+		},
+		{
+			name:     "Pagination params first",
+			rawQuery: "sort=name.asc&page[offset]=20&page[limit]=10",
+			want:     "page[limit]=10&page[offset]=20&sort=name.asc",
+		},
+		{
+			name:     "Filters sorted by field, operator and value",
+			rawQuery: "filter[status]=active&filter[age][gt]=30&page[limit]=10&filter[age]=gte:18&filter[a-b]=1&filter[a]=2",
+			want:     "page[limit]=10&filter[a]=2&filter[a-b]=1&filter[age][gt]=30&filter[age]=gte:18&filter[status]=active",
+		},
+		{
+			name:     "Rest of params sorted by name before the signature",
+			rawQuery: "page%5Bsig%5D=abc&q=john&page[limit]=10&embed=posts",
+			want:     "page[limit]=10&embed=posts&q=john&page%5Bsig%5D=abc",
+		},
+		{
+			name:     "Repeated params keep their order",
+			rawQuery: "tag=b&&tag=a&page[limit]=10",
+			want:     "page[limit]=10&tag=b&tag=a",
+		},
+		{
+			name: "Empty query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.CanonicalQuery(tt.rawQuery))
+		})
+	}
+}
+
+func TestCanonicalQueryOfTheLinks(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 2, LinkSigner: pagination.NewLinkSigner(tokenKey)}
+	req, _ := http.NewRequest(http.MethodGet, "/users?filter[status]=active&sort=name.asc&filter[age][gt]=30&seed=7", nil)
+	params, err := policy.FindParams(req)
+	assert.NoError(t, err)
+
+	links := pagination.Paginate([]interface{}{1, 2, 3}, "/users", params).Links
+	for _, link := range []string{links.First, links.Next} {
+		_, query, _ := strings.Cut(link, "?")
+		assert.Equal(t, query, policy.CanonicalQuery(query))
+	}
+}
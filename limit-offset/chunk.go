@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"context"
+	"iter"
+	"slices"
+)
+
+// Chunks will split the given items into consecutive chunks of the given
+// size, the last one can be smaller, so the background jobs can process them
+// in batches. It panics when the size is lower than 1
+func Chunks[T any](items []T, size int) iter.Seq[[]T] {
+	return slices.Chunk(items, size)
+}
+
+// Plan will answer back the params of every page needed for going through the
+// given total of items with the given limit, the same pages the API answers
+// back, so the background jobs can split their work by page. It is empty when
+// there are no items or the limit is zero
+func Plan(total, limit uint) []Params {
+	pages := Params{Limit: limit}.TotalPages(total)
+	plan := make([]Params, 0, pages)
+	for page := uint(0); page < pages; page++ {
+		plan = append(plan, Params{Limit: limit, Offset: page * limit})
+	}
+	return plan
+}
+
+// Walk will walk the pages of the given fetch function from the offset of the
+// given params, yielding their items one by one until a page comes with less
+// items than its limit, or after the first page when the limit is zero. Only a
+// page is kept in memory and the next one is fetched once its items are
+// consumed. An error of the fetch function or the context is yielded with the
+// zero item and ends the walk
+func Walk[T any](ctx context.Context, params Params, fetch func(ctx context.Context, params Params) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		for {
+			if err := ctx.Err(); err != nil {
+				yield(zero, err)
+				return
+			}
+			items, err := fetch(ctx, params)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if params.Limit == 0 || uint(len(items)) < params.Limit {
+				return
+			}
+			params.Offset += params.Limit
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunks(t *testing.T) {
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(pagination.Chunks([]int{1, 2, 3, 4, 5}, 2)))
+	assert.Empty(t, slices.Collect(pagination.Chunks([]int{}, 2)))
+	assert.Panics(t, func() { pagination.Chunks([]int{1}, 0) })
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name  string
+		total uint
+		limit uint
+		want  []pagination.Params
+	}{
+		{
+			name:  "Partial last page",
+			total: 25,
+			limit: 10,
+			want:  []pagination.Params{{Limit: 10}, {Limit: 10, Offset: 10}, {Limit: 10, Offset: 20}},
+		},
+		{
+			name:  "Exact pages",
+			total: 20,
+			limit: 10,
+			want:  []pagination.Params{{Limit: 10}, {Limit: 10, Offset: 10}},
+		},
+		{
+			name:  "No items",
+			limit: 10,
+			want:  []pagination.Params{},
+		},
+		{
+			name:  "Zero limit",
+			total: 10,
+			want:  []pagination.Params{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.Plan(tt.total, tt.limit))
+		})
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var offsets []uint
+	fetch := func(ctx context.Context, params pagination.Params) ([]uint, error) {
+		offsets = append(offsets, params.Offset)
+		var items []uint
+		for i := params.Offset; i < 5 && i < params.Offset+params.Limit; i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+
+	var items []uint
+	for item, err := range pagination.Walk(context.Background(), pagination.Params{Limit: 2}, fetch) {
+		assert.NoError(t, err)
+		items = append(items, item)
+	}
+	assert.Equal(t, []uint{0, 1, 2, 3, 4}, items)
+	assert.Equal(t, []uint{0, 2, 4}, offsets)
+
+	offsets = nil
+	for item := range pagination.Walk(context.Background(), pagination.Params{Limit: 2}, fetch) {
+		if item == 2 {
+			break
+		}
+	}
+	assert.Equal(t, []uint{0, 2}, offsets)
+}
+
+func TestWalkErrors(t *testing.T) {
+	failure := errors.New("database down")
+	fetch := func(ctx context.Context, params pagination.Params) ([]int, error) {
+		return nil, failure
+	}
+	for _, err := range pagination.Walk(context.Background(), pagination.Params{Limit: 2}, fetch) {
+		assert.ErrorIs(t, err, failure)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range pagination.Walk(ctx, pagination.Params{Limit: 2}, fetch) {
+		assert.ErrorIs(t, err, context.Canceled)
+	}
+}
@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+)
+
+// OrderByClause method will build the ORDER BY clause of the params, like
+// ORDER BY name asc,id asc, without any surrounding whitespace. It is empty
+// when there is nothing to sort by
+func (p Params) OrderByClause() string {
+	orderBy := p.OrderBy()
+	if orderBy == "" {
+		return ""
+	}
+	return "ORDER BY " + orderBy
+}
+
+// LimitOffsetClause method will build the LIMIT and OFFSET clause of the
+// params, like LIMIT 11 OFFSET 20, without any surrounding whitespace. The
+// limit is the EffectiveLimit, the clause is empty when the params are
+// Unlimited
+func (p Params) LimitOffsetClause() string {
+	if p.unlimited {
+		return ""
+	}
+	b := make([]byte, 0, 32)
+	b = append(b, "LIMIT "...)
+	b = strconv.AppendUint(b, uint64(p.EffectiveLimit()), 10)
+	b = append(b, " OFFSET "...)
+	b = strconv.AppendUint(b, uint64(p.Offset), 10)
+	return string(b)
+}
+
+// Compose method will append the clauses of the params to the given base
+// query, the ORDER BY followed by the LIMIT and OFFSET, separated by a single
+// space. The whitespace and the semicolon at the end of the base query are
+// dropped, so the composed query is valid SQL
+//
+//	where, args := filters.Where(columns, pagination.DialectPostgres, 1)
+//	db.Select(&data, params.Compose("SELECT * FROM users"+where), args...)
+func (p Params) Compose(base string) string {
+	base = strings.TrimRight(base, " \t\r\n;")
+	clauses := []string{p.OrderByClause(), p.LimitOffsetClause()}
+	var b strings.Builder
+	b.WriteString(base)
+	for _, clause := range clauses {
+		if clause == "" {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(clause)
+	}
+	return b.String()
+}
@@ -0,0 +1,56 @@
+package pagination_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClauses(t *testing.T) {
+	tests := []struct {
+		name            string
+		params          pagination.Params
+		base            string
+		wantOrderBy     string
+		wantLimitOffset string
+		wantComposed    string
+	}{
+		{
+			name:            "Without sort",
+			params:          pagination.Params{Limit: 10, Offset: 20},
+			base:            "SELECT * FROM users",
+			wantLimitOffset: "LIMIT 11 OFFSET 20",
+			wantComposed:    "SELECT * FROM users LIMIT 11 OFFSET 20",
+		},
+		{
+			name:            "With sort and tie breaker",
+			params:          pagination.Params{Limit: 10, Sort: []pagination.Sort{{Field: "name", Order: "asc"}}, TieBreaker: "id"},
+			base:            "SELECT * FROM users WHERE age > $1 ",
+			wantOrderBy:     "ORDER BY name asc,id asc",
+			wantLimitOffset: "LIMIT 11 OFFSET 0",
+			wantComposed:    "SELECT * FROM users WHERE age > $1 ORDER BY name asc,id asc LIMIT 11 OFFSET 0",
+		},
+		{
+			name:            "Trailing semicolon",
+			params:          pagination.Params{Limit: 5, Offset: 5, DisableOverFetch: true},
+			base:            "SELECT * FROM users;\n",
+			wantLimitOffset: "LIMIT 5 OFFSET 5",
+			wantComposed:    "SELECT * FROM users LIMIT 5 OFFSET 5",
+		},
+		{
+			name:            "Empty base",
+			params:          pagination.Params{Limit: 5},
+			wantLimitOffset: "LIMIT 6 OFFSET 0",
+			wantComposed:    "LIMIT 6 OFFSET 0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantOrderBy, tt.params.OrderByClause())
+			assert.Equal(t, tt.wantLimitOffset, tt.params.LimitOffsetClause())
+			assert.Equal(t, tt.wantComposed, tt.params.Compose(tt.base))
+		})
+	}
+}
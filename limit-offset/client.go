@@ -0,0 +1,69 @@
+package pagination
+
+import "context"
+
+// ClientLimits type encapsulates the limits of a kind of caller, like the
+// partners paging deeper than the anonymous traffic. The zero values keep the
+// ones of the policy
+type ClientLimits struct {
+	// MaxLimit replaces the MaxLimit of the policy
+	MaxLimit uint
+	// MaxOffsetDepth replaces the MaxOffsetDepth of the policy
+	MaxOffsetDepth uint
+}
+
+// ForContext will answer back the policy with the limits of the caller of the
+// given context applied, see Policy.Clients. The policy is applied this way
+// when finding the params on a request, the rest of callers, like the
+// connection fields of relay, can use it before applying the policy
+func (p Policy) ForContext(ctx context.Context) Policy {
+	if p.Clients == nil {
+		return p
+	}
+	limits, ok := p.Clients(ctx)
+	if !ok {
+		return p
+	}
+	if limits.MaxLimit > 0 {
+		p.MaxLimit = limits.MaxLimit
+	}
+	if limits.MaxOffsetDepth > 0 {
+		p.MaxOffsetDepth = limits.MaxOffsetDepth
+	}
+	return p
+}
+
+// clientTierKey is the context key of the client tier
+type clientTierKey struct{}
+
+// WithClientTier will answer back a context carrying the given tier of the
+// caller, like partner or internal, usually set by the authentication
+// middleware from the API key
+func WithClientTier(ctx context.Context, tier string) context.Context {
+	return context.WithValue(ctx, clientTierKey{}, tier)
+}
+
+// ClientTier will answer back the client tier of the given context, empty
+// when there is none
+func ClientTier(ctx context.Context) string {
+	tier, _ := ctx.Value(clientTierKey{}).(string)
+	return tier
+}
+
+// TierLimits will build the Clients hook of a policy finding the limits by
+// the client tier of the context, see WithClientTier. The callers without a
+// known tier get the limits of the policy
+//
+//	policy := pagination.Policy{
+//	  MaxLimit:       50,
+//	  MaxOffsetDepth: 1000,
+//	  Clients: pagination.TierLimits(map[string]pagination.ClientLimits{
+//	    "partner": {MaxLimit: 500, MaxOffsetDepth: 100000},
+//	  }),
+//	}
+func TierLimits(tiers map[string]ClientLimits) func(ctx context.Context) (ClientLimits, bool) {
+	return func(ctx context.Context) (ClientLimits, bool) {
+		limits, ok := tiers[ClientTier(ctx)]
+		return limits, ok
+	}
+}
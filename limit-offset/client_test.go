@@ -0,0 +1,61 @@
+package pagination_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientLimits(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit:   10,
+		MaxLimit:       50,
+		MaxOffsetDepth: 1000,
+		Clients: pagination.TierLimits(map[string]pagination.ClientLimits{
+			"partner":  {MaxLimit: 500, MaxOffsetDepth: 100000},
+			"internal": {MaxLimit: 1000},
+		}),
+	}
+
+	tests := []struct {
+		name           string
+		tier           string
+		wantLimit      uint
+		wantDeepOffset bool
+	}{
+		{name: "Anonymous", wantLimit: 50, wantDeepOffset: true},
+		{name: "Unknown tier", tier: "trial", wantLimit: 50, wantDeepOffset: true},
+		{name: "Partner", tier: "partner", wantLimit: 200},
+		{name: "Keeps the depth of the policy", tier: "internal", wantLimit: 200, wantDeepOffset: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/users?page[limit]=200&page[offset]=5000", nil)
+			if tt.tier != "" {
+				req = req.WithContext(pagination.WithClientTier(req.Context(), tt.tier))
+			}
+			params, err := policy.FindParams(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+			assert.Equal(t, tt.wantDeepOffset, params.DeepOffset())
+		})
+	}
+}
+
+func TestPolicyForContext(t *testing.T) {
+	policy := pagination.Policy{MaxLimit: 50}
+	assert.Equal(t, uint(50), policy.ForContext(context.Background()).MaxLimit)
+
+	policy.Clients = func(ctx context.Context) (pagination.ClientLimits, bool) {
+		return pagination.ClientLimits{MaxLimit: 70}, pagination.ClientTier(ctx) == "partner"
+	}
+	ctx := pagination.WithClientTier(context.Background(), "partner")
+	assert.Equal(t, "partner", pagination.ClientTier(ctx))
+	assert.Equal(t, uint(70), policy.ForContext(ctx).MaxLimit)
+	assert.Equal(t, uint(50), policy.ForContext(context.Background()).MaxLimit)
+	assert.Equal(t, uint(50), policy.MaxLimit)
+}
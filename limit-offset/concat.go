@@ -0,0 +1,60 @@
+package pagination
+
+import "context"
+
+// ConcatSource type is one of the backends of a Concat, like the hot store or
+// the archive
+type ConcatSource[T any] struct {
+	// Count answers back the number of items of the source matching the
+	// filters of the params, it is only called when the offset could skip the
+	// whole source so it can be nil on the last one
+	Count func(ctx context.Context, params Params) (uint, error)
+	// Fetch answers back up to the limit of the params from their offset,
+	// sorted with their sort and matching their filters
+	Fetch func(ctx context.Context, params Params) ([]T, error)
+}
+
+// Concat type paginates a single sequence made of several sources one after
+// the other, like a hot store followed by its archive. The offsets are global
+// to the sequence, so a page can take the last items of a source and the
+// first ones of the next and the clients never see the seam between them
+type Concat[T any] struct {
+	Sources []ConcatSource[T]
+}
+
+// Page method will fetch the page the given params are pointing to, plus the
+// next item so we know if there are more pages, and build the paginated
+// response like Paginate does. The sources fully skipped by the offset are
+// only counted and the ones after the page are never called
+func (c Concat[T]) Page(ctx context.Context, baseURL string, params Params) (Response, error) {
+	want := params.Limit + 1
+	offset := params.Offset
+	data := make([]interface{}, 0, want)
+	for _, source := range c.Sources {
+		if offset > 0 && source.Count != nil {
+			count, err := source.Count(ctx, params)
+			if err != nil {
+				return Response{}, err
+			}
+			if offset >= count {
+				offset -= count
+				continue
+			}
+		}
+		p := params
+		p.Offset = offset
+		p.Limit = want - uint(len(data))
+		items, err := source.Fetch(ctx, p)
+		if err != nil {
+			return Response{}, err
+		}
+		for _, item := range items {
+			data = append(data, item)
+		}
+		if uint(len(data)) >= want {
+			break
+		}
+		offset = 0
+	}
+	return Paginate(data, baseURL, params), nil
+}
@@ -0,0 +1,88 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+// store answers back a concat source over the given items, counting the calls
+func store(calls *int, items ...string) pagination.ConcatSource[string] {
+	return pagination.ConcatSource[string]{
+		Count: func(ctx context.Context, params pagination.Params) (uint, error) {
+			*calls++
+			return uint(len(items)), nil
+		},
+		Fetch: func(ctx context.Context, params pagination.Params) ([]string, error) {
+			*calls++
+			if params.Offset >= uint(len(items)) {
+				return nil, nil
+			}
+			return items[params.Offset:min(params.Offset+params.Limit, uint(len(items)))], nil
+		},
+	}
+}
+
+func TestConcat(t *testing.T) {
+	tests := []struct {
+		name             string
+		params           pagination.Params
+		wantData         []interface{}
+		wantNext         bool
+		wantHotCalls     int
+		wantArchiveCalls int
+	}{
+		{
+			name:         "Page on the hot store",
+			params:       pagination.Params{Limit: 2},
+			wantData:     []interface{}{"h1", "h2"},
+			wantNext:     true,
+			wantHotCalls: 1,
+		},
+		{
+			name:             "Page crossing the seam",
+			params:           pagination.Params{Limit: 3, Offset: 3},
+			wantData:         []interface{}{"h4", "a1", "a2"},
+			wantNext:         true,
+			wantHotCalls:     2,
+			wantArchiveCalls: 1,
+		},
+		{
+			name:             "Page on the archive",
+			params:           pagination.Params{Limit: 2, Offset: 6},
+			wantData:         []interface{}{"a3"},
+			wantHotCalls:     1,
+			wantArchiveCalls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hotCalls, archiveCalls int
+			concat := pagination.Concat[string]{Sources: []pagination.ConcatSource[string]{
+				store(&hotCalls, "h1", "h2", "h3", "h4"),
+				store(&archiveCalls, "a1", "a2", "a3"),
+			}}
+			response, err := concat.Page(context.Background(), "/orders", tt.params)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantNext, response.Links.Next != "")
+			assert.Equal(t, tt.wantHotCalls, hotCalls)
+			assert.Equal(t, tt.wantArchiveCalls, archiveCalls)
+		})
+	}
+}
+
+func TestConcatErrors(t *testing.T) {
+	failure := errors.New("archive unavailable")
+	calls := 0
+	concat := pagination.Concat[string]{Sources: []pagination.ConcatSource[string]{
+		store(&calls, "h1"),
+		{Fetch: func(ctx context.Context, params pagination.Params) ([]string, error) { return nil, failure }},
+	}}
+	_, err := concat.Page(context.Background(), "/orders", pagination.Params{Limit: 2})
+	assert.ErrorIs(t, err, failure)
+}
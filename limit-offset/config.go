@@ -0,0 +1,180 @@
+package pagination
+
+import "net/http"
+
+// Config type encapsulates the configuration of a Paginator, the names of the
+// params found on the request and used on the links and the policy applied
+// when finding them. Build it with the options given to New
+type Config struct {
+	// Policy holds the rules applied when finding the params, like the
+	// defaults, the maximum limit or the tokens
+	Policy Policy
+	// LimitParam, OffsetParam, SortParam, OrderByParam, SeedParam, NumberParam
+	// and SizeParam are the names of the params, by default the ones of the
+	// Param constants, like page[limit]
+	LimitParam   string
+	OffsetParam  string
+	SortParam    string
+	OrderByParam string
+	SeedParam    string
+	NumberParam  string
+	SizeParam    string
+}
+
+// Option type defines the options used by New for building the Config
+type Option func(*Config)
+
+// WithBasePolicy option will use the given policy as the rules of the
+// paginator, the options given after it can still change some of them
+func WithBasePolicy(policy Policy) Option {
+	return func(c *Config) {
+		c.Policy = policy
+	}
+}
+
+// WithLimitParams option will use the given names for the limit and offset
+// params, like limit and offset
+func WithLimitParams(limit, offset string) Option {
+	return func(c *Config) {
+		c.LimitParam, c.OffsetParam = limit, offset
+	}
+}
+
+// WithSortParams option will use the given names for the sort and order by
+// params
+func WithSortParams(sort, orderBy string) Option {
+	return func(c *Config) {
+		c.SortParam, c.OrderByParam = sort, orderBy
+	}
+}
+
+// WithPageNumberParams option will use the given names for the page number
+// and size params, like page and per_page
+func WithPageNumberParams(number, size string) Option {
+	return func(c *Config) {
+		c.NumberParam, c.SizeParam = number, size
+	}
+}
+
+// WithDefaults option will use the given default limit and offset when the
+// request doesn't have them
+func WithDefaults(limit, offset uint) Option {
+	return func(c *Config) {
+		c.Policy.DefaultLimit, c.Policy.DefaultOffset = limit, offset
+	}
+}
+
+// WithOmitDefaultParams option will drop from the links the limit and the
+// offset equal to the defaults, see Policy.OmitDefaultParams
+func WithOmitDefaultParams() Option {
+	return func(c *Config) {
+		c.Policy.OmitDefaultParams = true
+	}
+}
+
+// WithStableJSON option will always encode the data and the four links of the
+// responses, see Policy.StableJSON
+func WithStableJSON() Option {
+	return func(c *Config) {
+		c.Policy.StableJSON = true
+	}
+}
+
+// WithMaxLimit option will clamp the limits bigger than the given one
+func WithMaxLimit(limit uint) Option {
+	return func(c *Config) {
+		c.Policy.MaxLimit = limit
+	}
+}
+
+// WithSortFormat option will accept only the given sort format and use the
+// given link sort format on the links, see SortFormat
+func WithSortFormat(format, linkFormat SortFormat) Option {
+	return func(c *Config) {
+		c.Policy.SortFormat, c.Policy.LinkSortFormat = format, linkFormat
+	}
+}
+
+// WithTokens option will encode the links as opaque page tokens signed with
+// the given codec
+func WithTokens(tokens *TokenCodec) Option {
+	return func(c *Config) {
+		c.Policy.Tokens = tokens
+	}
+}
+
+// Paginator type encapsulates a configured pagination, so many differently
+// configured paginators can coexist in one binary, for example a public API
+// using limit and offset params next to an internal one using the defaults.
+// It is safe for concurrent use
+type Paginator struct {
+	policy Policy
+}
+
+// New will build a new paginator with the given options, without options it
+// works like the free functions with a default limit of 10
+//
+//	var users = pagination.New(
+//	  pagination.WithLimitParams("limit", "offset"),
+//	  pagination.WithDefaults(20, 0),
+//	  pagination.WithMaxLimit(100),
+//	)
+func New(opts ...Option) *Paginator {
+	config := Config{Policy: Policy{DefaultLimit: 10}}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	return NewFromConfig(config)
+}
+
+// NewFromConfig will build a new paginator with the given config, the empty
+// param names use the default ones
+func NewFromConfig(config Config) *Paginator {
+	names := defaultParamNames
+	for _, name := range []struct {
+		dst *string
+		src string
+	}{
+		{&names.limit, config.LimitParam},
+		{&names.offset, config.OffsetParam},
+		{&names.sort, config.SortParam},
+		{&names.orderBy, config.OrderByParam},
+		{&names.seed, config.SeedParam},
+		{&names.number, config.NumberParam},
+		{&names.size, config.SizeParam},
+	} {
+		if name.src != "" {
+			*name.dst = name.src
+		}
+	}
+	policy := config.Policy
+	policy.names = names
+	return &Paginator{policy: policy}
+}
+
+// Policy method will answer back the policy of the paginator, it keeps the
+// param names so it can be used everywhere a policy is expected, like
+// FindListParams or Handler
+func (p *Paginator) Policy() Policy {
+	return p.policy
+}
+
+// FindParams method will find the pagination params on the request using the
+// param names and rules of the paginator, the links built for them with
+// Paginate keep the same names
+func (p *Paginator) FindParams(req *http.Request) (Params, error) {
+	return p.policy.FindParams(req)
+}
+
+// FindParamsInto method works like FindParams but fills the given params
+func (p *Paginator) FindParamsInto(req *http.Request, params *Params) error {
+	return p.policy.FindParamsInto(req, params)
+}
+
+// Paginate method will build a new paginated response for the given params,
+// like the Paginate function does, the links use the param names of the
+// paginator even when the params were built by hand
+func (p *Paginator) Paginate(data []interface{}, baseURL string, params Params) Response {
+	params.names = p.policy.names
+	return Paginate(data, baseURL, params)
+}
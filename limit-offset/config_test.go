@@ -0,0 +1,96 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	public := pagination.New(
+		pagination.WithLimitParams("limit", "offset"),
+		pagination.WithSortParams("sort_by", "order"),
+		pagination.WithDefaults(20, 0),
+		pagination.WithMaxLimit(50),
+	)
+	internal := pagination.New()
+
+	tests := []struct {
+		name       string
+		paginator  *pagination.Paginator
+		url        string
+		wantLimit  uint
+		wantOffset uint
+		wantLinks  pagination.Links
+	}{
+		{
+			name:       "Custom param names",
+			paginator:  public,
+			url:        "app.quicka.co/api/sample?limit=2&offset=2&sort_by=name.asc",
+			wantLimit:  2,
+			wantOffset: 2,
+			wantLinks: pagination.Links{
+				First: "/users?limit=2&offset=0&sort_by=name.asc",
+				Prev:  "/users?limit=2&offset=0&sort_by=name.asc",
+				Next:  "/users?limit=2&offset=4&sort_by=name.asc",
+			},
+		},
+		{
+			name:      "Custom names ignore the default ones",
+			paginator: public,
+			url:       "app.quicka.co/api/sample?page[limit]=2",
+			wantLimit: 20,
+			wantLinks: pagination.Links{
+				First: "/users?limit=20&offset=0",
+				Next:  "/users?limit=20&offset=20",
+			},
+		},
+		{
+			name:      "Max limit",
+			paginator: public,
+			url:       "app.quicka.co/api/sample?limit=500",
+			wantLimit: 50,
+			wantLinks: pagination.Links{
+				First: "/users?limit=50&offset=0",
+				Next:  "/users?limit=50&offset=50",
+			},
+		},
+		{
+			name:      "Default names",
+			paginator: internal,
+			url:       "app.quicka.co/api/sample?page[limit]=2",
+			wantLimit: 2,
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+				Next:  "/users?page[limit]=2&page[offset]=2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			params, err := tt.paginator.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+			assert.Equal(t, tt.wantOffset, params.Offset)
+
+			data := make([]interface{}, params.Limit+1)
+			assert.Equal(t, tt.wantLinks, tt.paginator.Paginate(data, "/users", params).Links)
+		})
+	}
+}
+
+func TestPaginatorParamsBuiltByHand(t *testing.T) {
+	paginator := pagination.NewFromConfig(pagination.Config{LimitParam: "limit", OffsetParam: "offset"})
+	response := paginator.Paginate([]interface{}{1, 2}, "/users", pagination.Params{Limit: 1})
+	assert.Equal(t, "/users?limit=1&offset=1", response.Links.Next)
+
+	parameters := paginator.Policy().OpenAPIParameters()
+	assert.Equal(t, "limit", parameters[0].Name)
+	assert.Equal(t, "offset", parameters[1].Name)
+}
@@ -0,0 +1,81 @@
+// Package connectpagination provides the helpers for serving the paginated
+// collections on Connect services
+package connectpagination
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// Interceptor type applies a policy to the page_size and page_token fields of
+// every request, see paginationv1.NormalizeRequest, so the methods can't
+// forget the checks. The requests with a negative page_size or a page_token
+// that can't be accepted are refused with CodeInvalidArgument. The limits of
+// the caller are applied, see pagination.Policy.ForContext
+type Interceptor struct {
+	policy pagination.Policy
+}
+
+var _ connect.Interceptor = (*Interceptor)(nil)
+
+// NewInterceptor will build a new interceptor applying the given policy, set
+// it with connect.WithInterceptors on the handlers
+func NewInterceptor(policy pagination.Policy) *Interceptor {
+	return &Interceptor{policy: policy}
+}
+
+// WrapUnary method will apply the policy to the requests of the unary
+// methods, the client calls are left as they are
+func (i *Interceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if !req.Spec().IsClient {
+			if err := normalize(req.Any(), i.policy.ForContext(ctx)); err != nil {
+				return nil, err
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+// WrapStreamingClient method will leave the client streams as they are
+func (i *Interceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler method will apply the policy to every message received
+// by the streaming methods
+func (i *Interceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		return next(ctx, &normalizingConn{StreamingHandlerConn: conn, policy: i.policy.ForContext(ctx)})
+	}
+}
+
+// normalize function will apply the policy to the given message
+func normalize(m any, policy pagination.Policy) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := paginationv1.NormalizeRequest(msg, policy); err != nil {
+		return connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return nil
+}
+
+// normalizingConn type applies the policy to the messages received
+type normalizingConn struct {
+	connect.StreamingHandlerConn
+	policy pagination.Policy
+}
+
+// Receive method will receive the next message applying the policy to it
+func (c *normalizingConn) Receive(m any) error {
+	if err := c.StreamingHandlerConn.Receive(m); err != nil {
+		return err
+	}
+	return normalize(m, c.policy)
+}
@@ -0,0 +1,31 @@
+package connectpagination_test
+
+import (
+	"context"
+	"testing"
+
+	"connectrpc.com/connect"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/connectpagination"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+var policy = pagination.Policy{DefaultLimit: 20, MaxLimit: 100}
+
+func TestInterceptorWrapUnary(t *testing.T) {
+	var received *paginationv1.PageRequest
+	unary := connectpagination.NewInterceptor(policy).WrapUnary(func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		received = req.Any().(*paginationv1.PageRequest)
+		return connect.NewResponse(&paginationv1.PageResponse{}), nil
+	})
+
+	_, err := unary(context.Background(), connect.NewRequest(&paginationv1.PageRequest{}))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(20), received.GetPageSize())
+
+	received = nil
+	_, err = unary(context.Background(), connect.NewRequest(&paginationv1.PageRequest{PageSize: 10, PageToken: "forged"}))
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+	assert.Nil(t, received)
+}
@@ -0,0 +1,64 @@
+package connectpagination
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultStreamPageSize is the size of the pages fetched by Stream when the
+// params have no limit
+const DefaultStreamPageSize = 100
+
+// Params will find the params of the given list request, its page_size and
+// page_token fields, after applying the given policy like the Interceptor
+// does, see paginationv1.RequestParams. The errors are answered back with
+// CodeInvalidArgument
+func Params[T any](req *connect.Request[T], policy pagination.Policy) (pagination.Params, error) {
+	msg, ok := any(req.Msg).(proto.Message)
+	if !ok {
+		return pagination.Params{Limit: policy.DefaultLimit}, nil
+	}
+	params, err := paginationv1.RequestParams(msg, policy)
+	if err != nil {
+		return params, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	return params, nil
+}
+
+// Response will build the response of a list method for the given params and
+// the size of the data fetched, the page plus the next item like Paginate
+// expects. When there are more pages the next_page_token of the message is
+// set to the cursor of the next offset, signed with the Tokens of the given
+// policy, and the Link header carries the links of the page on the given base
+// url for the browser clients using Connect over HTTP
+func Response[T any](msg *T, baseURL string, params pagination.Params, dataSize int, policy pagination.Policy) *connect.Response[T] {
+	res := connect.NewResponse(msg)
+	if m, ok := any(msg).(proto.Message); ok && uint(dataSize) > params.Limit {
+		paginationv1.SetNextPageToken(m, pagination.OffsetCursor(policy.Tokens, params.Offset+params.Limit))
+	}
+	links := pagination.NewLinkTemplate(baseURL, params).Links(params, dataSize)
+	res.Header().Set(pagination.HeaderLink, links.Header())
+	return res
+}
+
+// Stream will implement a server-streaming list method on top of the given
+// paginated fetch function, walking its pages with pagination.Walk from the
+// offset of the given params and sending the items one by one
+func Stream[T any](ctx context.Context, stream *connect.ServerStream[T], params pagination.Params, fetch func(ctx context.Context, params pagination.Params) ([]*T, error)) error {
+	if params.Limit == 0 {
+		params.Limit = DefaultStreamPageSize
+	}
+	for item, err := range pagination.Walk(ctx, params, fetch) {
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package connectpagination_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"connectrpc.com/connect"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/connectpagination"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParams(t *testing.T) {
+	params, err := connectpagination.Params(connect.NewRequest(&paginationv1.PageRequest{PageSize: 10, PageToken: pagination.OffsetCursor(nil, 30)}), policy)
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.Params{Limit: 10, Offset: 30}, params)
+
+	_, err = connectpagination.Params(connect.NewRequest(&paginationv1.PageRequest{PageToken: "forged"}), policy)
+	assert.Equal(t, connect.CodeInvalidArgument, connect.CodeOf(err))
+}
+
+func TestResponse(t *testing.T) {
+	params := pagination.Params{Limit: 10, Offset: 10}
+	res := connectpagination.Response(&paginationv1.PageResponse{}, "/users", params, 11, policy)
+	assert.Equal(t, pagination.OffsetCursor(nil, 20), res.Msg.GetNextPageToken())
+	assert.Equal(t, `</users?page[limit]=10&page[offset]=0>; rel="prev", </users?page[limit]=10&page[offset]=20>; rel="next", </users?page[limit]=10&page[offset]=0>; rel="first"`, res.Header().Get(pagination.HeaderLink))
+
+	res = connectpagination.Response(&paginationv1.PageResponse{}, "/users", params, 5, policy)
+	assert.Empty(t, res.Msg.GetNextPageToken())
+}
+
+func TestStream(t *testing.T) {
+	const procedure = "/ramonmacias.pagination.v1.Test/StreamSorts"
+	var fetched []uint
+	fetch := func(ctx context.Context, params pagination.Params) ([]*paginationv1.Sort, error) {
+		fetched = append(fetched, params.Offset)
+		var sorts []*paginationv1.Sort
+		for i := params.Offset; i < 5 && i < params.Offset+params.Limit; i++ {
+			sorts = append(sorts, &paginationv1.Sort{Field: "field" + strconv.FormatUint(uint64(i), 10)})
+		}
+		return sorts, nil
+	}
+	mux := http.NewServeMux()
+	mux.Handle(procedure, connect.NewServerStreamHandler(procedure, func(ctx context.Context, req *connect.Request[paginationv1.PageRequest], stream *connect.ServerStream[paginationv1.Sort]) error {
+		return connectpagination.Stream(ctx, stream, pagination.Params{Limit: uint(req.Msg.GetPageSize())}, fetch)
+	}))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := connect.NewClient[paginationv1.PageRequest, paginationv1.Sort](server.Client(), server.URL+procedure)
+	stream, err := client.CallServerStream(context.Background(), connect.NewRequest(&paginationv1.PageRequest{PageSize: 2}))
+	assert.NoError(t, err)
+	var fields []string
+	for stream.Receive() {
+		fields = append(fields, stream.Msg().GetField())
+	}
+	assert.NoError(t, stream.Err())
+	assert.Equal(t, []string{"field0", "field1", "field2", "field3", "field4"}, fields)
+	assert.Equal(t, []uint{0, 2, 4}, fetched)
+}
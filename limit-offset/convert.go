@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"strconv"
+)
+
+// OffsetToPage will convert the given offset and limit into the page number,
+// starting on 1, and the page size. The offsets that aren't a multiple of the
+// limit are rounded down into the page containing them
+func OffsetToPage(offset, limit uint) (number, size uint) {
+	return pageNumber(Params{Limit: limit, Offset: offset}), limit
+}
+
+// PageToOffset will convert the given page number, starting on 1, and size
+// into the offset and limit, the page 0 is treated as the first one
+func PageToOffset(number, size uint) (offset, limit uint) {
+	if number == 0 {
+		return 0, size
+	}
+	return (number - 1) * size, size
+}
+
+// OffsetCursor will build a synthetic cursor for the given offset, so the
+// clients migrating to cursor pagination can start using cursors while the
+// server still paginates with offsets. The cursor is signed when a codec is
+// given and just encoded with base64 otherwise, see CursorOffset
+func OffsetCursor(tokens *TokenCodec, offset uint) string {
+	return encodeCursor(tokens, ParamPageOffset+"="+strconv.FormatUint(uint64(offset), 10), "", "")
+}
+
+// CursorOffset will answer back the offset of a synthetic cursor built with
+// OffsetCursor, the same codec must be given. A *TokenError is returned for
+// the cursors that can't be accepted
+func CursorOffset(tokens *TokenCodec, cursor string) (uint, error) {
+	query, err := cursorQuery(tokens, cursor)
+	if err != nil {
+		return 0, err
+	}
+	offset, err := strconv.ParseUint(lookupParam(query, ParamPageOffset), 10, 32)
+	if err != nil {
+		return 0, &TokenError{Failure: TokenMalformed, Reason: "the cursor has no offset"}
+	}
+	return uint(offset), nil
+}
+
+// cursorQuery function will answer back the query kept inside a cursor built
+// with encodeCursor
+func cursorQuery(tokens *TokenCodec, cursor string) (string, error) {
+	if tokens != nil {
+		token, err := tokens.Decode(cursor)
+		if err != nil {
+			return "", err
+		}
+		return token.Query, nil
+	}
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", &TokenError{Failure: TokenMalformed, Reason: "the cursor is malformed"}
+	}
+	return string(b), nil
+}
+
+// ApproximatePage will estimate the page number, starting on 1, of a cursor
+// page when the totals are known, that is the total of items and the ones
+// remaining from the cursor onwards, both usually found with count queries.
+// It is approximated because the items can change between the queries
+func ApproximatePage(total, remaining, limit uint) uint {
+	if remaining > total {
+		remaining = total
+	}
+	return pageNumber(Params{Limit: limit, Offset: total - remaining})
+}
@@ -0,0 +1,75 @@
+package pagination_test
+
+import (
+	"errors"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffsetToPage(t *testing.T) {
+	tests := []struct {
+		name       string
+		offset     uint
+		limit      uint
+		wantNumber uint
+	}{
+		{name: "First page", offset: 0, limit: 10, wantNumber: 1},
+		{name: "Third page", offset: 20, limit: 10, wantNumber: 3},
+		{name: "Offset not aligned", offset: 25, limit: 10, wantNumber: 3},
+		{name: "Zero limit", offset: 25, limit: 0, wantNumber: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			number, size := pagination.OffsetToPage(tt.offset, tt.limit)
+			assert.Equal(t, tt.wantNumber, number)
+			assert.Equal(t, tt.limit, size)
+		})
+	}
+}
+
+func TestPageToOffset(t *testing.T) {
+	offset, limit := pagination.PageToOffset(3, 10)
+	assert.Equal(t, uint(20), offset)
+	assert.Equal(t, uint(10), limit)
+
+	offset, _ = pagination.PageToOffset(0, 10)
+	assert.Equal(t, uint(0), offset)
+}
+
+func TestOffsetCursor(t *testing.T) {
+	codec := pagination.NewTokenCodec([]byte("0123456789abcdef0123456789abcdef"))
+	for _, tokens := range []*pagination.TokenCodec{nil, codec} {
+		offset, err := pagination.CursorOffset(tokens, pagination.OffsetCursor(tokens, 40))
+		assert.Nil(t, err)
+		assert.Equal(t, uint(40), offset)
+	}
+
+	_, err := pagination.CursorOffset(nil, "not*base64")
+	assert.True(t, errors.Is(err, pagination.ErrTokenMalformed))
+	_, err = pagination.CursorOffset(codec, pagination.OffsetCursor(nil, 40))
+	assert.True(t, errors.Is(err, pagination.ErrInvalidToken))
+}
+
+func TestApproximatePage(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     uint
+		remaining uint
+		limit     uint
+		want      uint
+	}{
+		{name: "First page", total: 100, remaining: 100, limit: 10, want: 1},
+		{name: "Middle page", total: 100, remaining: 55, limit: 10, want: 5},
+		{name: "Last page", total: 100, remaining: 5, limit: 10, want: 10},
+		{name: "Remaining bigger than total", total: 10, remaining: 12, limit: 10, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.ApproximatePage(tt.total, tt.remaining, tt.limit))
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CountCache interface defines how the exact totals of a paginated query are
+// cached, so the count query is executed at most once per TTL instead of once
+// per request
+type CountCache interface {
+	// Get will return the cached total for the given key, found will be false
+	// when the key is not cached or it already expired
+	Get(ctx context.Context, key string) (total uint, found bool, err error)
+	// Set will cache the total for the given key during the given ttl
+	Set(ctx context.Context, key string, total uint, ttl time.Duration) error
+}
+
+// QueryFingerprint will build a cache key for the given count query and its
+// arguments. Use the query without the pagination part, the total doesn't
+// depend on the page we are requesting
+func QueryFingerprint(query string, args ...interface{}) string {
+	h := sha256.New()
+	h.Write([]byte(query))
+	for _, arg := range args {
+		fmt.Fprintf(h, "\x00%T:%v", arg, arg)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// CachedCount will return the total cached for the given key, when there is no
+// value cached the count function is called and its result stored on the cache
+// during the given ttl. The cache is optional, with a nil cache we always call
+// the count function
+func CachedCount(ctx context.Context, cache CountCache, key string, ttl time.Duration, count func(ctx context.Context) (uint, error)) (uint, error) {
+	if cache == nil {
+		return count(ctx)
+	}
+	total, found, err := cache.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if found {
+		return total, nil
+	}
+	total, err = count(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return total, cache.Set(ctx, key, total, ttl)
+}
+
+// MemoryCountCache type is an in-memory implementation of the CountCache, it is
+// safe for concurrent use
+type MemoryCountCache struct {
+	mu      sync.Mutex
+	entries map[string]countEntry
+	// sweptAt is the last time the expired entries were removed
+	sweptAt time.Time
+}
+
+// countSweepInterval is how often Set removes the expired entries, so the
+// sweep over every entry isn't paid on each call
+const countSweepInterval = time.Minute
+
+// countEntry type keeps a cached total together with its expiration time
+type countEntry struct {
+	total     uint
+	expiresAt time.Time
+}
+
+// NewMemoryCountCache will build a new empty in-memory count cache
+func NewMemoryCountCache() *MemoryCountCache {
+	return &MemoryCountCache{
+		entries: make(map[string]countEntry),
+	}
+}
+
+// Get will return the cached total for the given key, expired entries are
+// removed when found
+func (c *MemoryCountCache) Get(ctx context.Context, key string) (uint, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, found := c.entries[key]
+	if !found {
+		return 0, false, nil
+	}
+	if !time.Now().Before(entry.expiresAt) {
+		delete(c.entries, key)
+		return 0, false, nil
+	}
+	return entry.total, true, nil
+}
+
+// Set will cache the total for the given key during the given ttl, once in a
+// while it also takes the chance for removing the expired entries
+func (c *MemoryCountCache) Set(ctx context.Context, key string, total uint, ttl time.Duration) error {
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if now.Sub(c.sweptAt) >= countSweepInterval {
+		for k, entry := range c.entries {
+			if !now.Before(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+		c.sweptAt = now
+	}
+	c.entries[key] = countEntry{
+		total:     total,
+		expiresAt: now.Add(ttl),
+	}
+	return nil
+}
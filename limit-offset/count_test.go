@@ -0,0 +1,87 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryFingerprint(t *testing.T) {
+	base := pagination.QueryFingerprint("SELECT count(*) FROM users WHERE status = $1", "active")
+
+	assert.Equal(t, base, pagination.QueryFingerprint("SELECT count(*) FROM users WHERE status = $1", "active"))
+	assert.NotEqual(t, base, pagination.QueryFingerprint("SELECT count(*) FROM users WHERE status = $1", "pending"))
+	assert.NotEqual(t, base, pagination.QueryFingerprint("SELECT count(*) FROM orders WHERE status = $1", "active"))
+	assert.NotEqual(t,
+		pagination.QueryFingerprint("SELECT count(*) FROM users WHERE id = $1", 1),
+		pagination.QueryFingerprint("SELECT count(*) FROM users WHERE id = $1", "1"),
+	)
+}
+
+func TestCachedCount(t *testing.T) {
+	ctx := context.Background()
+	cache := pagination.NewMemoryCountCache()
+	calls := 0
+	count := func(ctx context.Context) (uint, error) {
+		calls++
+		return 213, nil
+	}
+
+	total, err := pagination.CachedCount(ctx, cache, "users", time.Minute, count)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(213), total)
+
+	total, err = pagination.CachedCount(ctx, cache, "users", time.Minute, count)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(213), total)
+	assert.Equal(t, 1, calls)
+
+	total, err = pagination.CachedCount(ctx, nil, "users", time.Minute, count)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(213), total)
+	assert.Equal(t, 2, calls)
+
+	expectedErr := errors.New("count failed")
+	_, err = pagination.CachedCount(ctx, cache, "orders", time.Minute, func(ctx context.Context) (uint, error) {
+		return 0, expectedErr
+	})
+	assert.Equal(t, expectedErr, err)
+	_, found, err := cache.Get(ctx, "orders")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestMemoryCountCacheExpiration(t *testing.T) {
+	ctx := context.Background()
+	cache := pagination.NewMemoryCountCache()
+
+	assert.Nil(t, cache.Set(ctx, "users", 10, 10*time.Millisecond))
+	total, found, err := cache.Get(ctx, "users")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint(10), total)
+
+	time.Sleep(20 * time.Millisecond)
+	_, found, err = cache.Get(ctx, "users")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func BenchmarkMemoryCountCacheSet(b *testing.B) {
+	ctx := context.Background()
+	cache := pagination.NewMemoryCountCache()
+	for i := 0; i < 10000; i++ {
+		cache.Set(ctx, strconv.Itoa(i), uint(i), time.Hour)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		cache.Set(ctx, "users", uint(i), time.Hour)
+	}
+}
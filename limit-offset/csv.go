@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+)
+
+// CSVOptions type encapsulates how PaginateCSV reads the file
+type CSVOptions struct {
+	// Header tells the first record of the file is the header, so the rows are
+	// answered back as map[string]string keyed by its columns instead of
+	// []string
+	Header bool
+	// Total tells to keep reading the file after the page counting the rows,
+	// the total is added to the meta of the response as "total". It reads the
+	// whole file, so it is meant for being done once, like on the first page
+	Total bool
+}
+
+// PaginateCSV will skip the offset rows of the given CSV reader, read the
+// page plus the next row, so we know if there are more pages, and build the
+// paginated response like Paginate does. The rest of the file is only read
+// when the options ask for the total
+func PaginateCSV(r *csv.Reader, baseURL string, params Params, options CSVOptions) (Response, error) {
+	var header []string
+	if options.Header {
+		record, err := r.Read()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Response{}, err
+		}
+		header = slices.Clone(record)
+	}
+	var (
+		data []interface{}
+		rows uint
+	)
+	for options.Total || uint(len(data)) <= params.Limit {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return Response{}, err
+		}
+		rows++
+		if rows > params.Offset && uint(len(data)) <= params.Limit {
+			data = append(data, csvRow(header, record))
+		}
+	}
+	response := Paginate(data, baseURL, params)
+	if options.Total {
+		if response.Meta == nil {
+			response.Meta = make(map[string]interface{}, 1)
+		}
+		response.Meta["total"] = rows
+	}
+	return response, nil
+}
+
+// csvRow function will build the row of the response for the given record,
+// keyed by the columns of the header when there is one
+func csvRow(header, record []string) interface{} {
+	if header == nil {
+		return slices.Clone(record)
+	}
+	row := make(map[string]string, len(header))
+	for i, column := range header {
+		if i < len(record) {
+			row[column] = record[i]
+		}
+	}
+	return row
+}
@@ -0,0 +1,87 @@
+package pagination_test
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+const upload = `name,email
+ada,ada@quicka.co
+grace,grace@quicka.co
+linus,linus@quicka.co
+`
+
+func TestPaginateCSV(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    pagination.Params
+		options   pagination.CSVOptions
+		wantData  []interface{}
+		wantLinks pagination.Links
+		wantMeta  map[string]interface{}
+	}{
+		{
+			name:   "Records without header",
+			params: pagination.Params{Limit: 2},
+			wantData: []interface{}{
+				[]string{"name", "email"},
+				[]string{"ada", "ada@quicka.co"},
+			},
+			wantLinks: pagination.Links{
+				First: "/uploads/1?page[limit]=2&page[offset]=0",
+				Next:  "/uploads/1?page[limit]=2&page[offset]=2",
+			},
+		},
+		{
+			name:    "Rows keyed by the header",
+			params:  pagination.Params{Limit: 1, Offset: 1},
+			options: pagination.CSVOptions{Header: true},
+			wantData: []interface{}{
+				map[string]string{"name": "grace", "email": "grace@quicka.co"},
+			},
+			wantLinks: pagination.Links{
+				First: "/uploads/1?page[limit]=1&page[offset]=0",
+				Prev:  "/uploads/1?page[limit]=1&page[offset]=0",
+				Next:  "/uploads/1?page[limit]=1&page[offset]=2",
+			},
+		},
+		{
+			name:    "Total of rows",
+			params:  pagination.Params{Limit: 1},
+			options: pagination.CSVOptions{Header: true, Total: true},
+			wantData: []interface{}{
+				map[string]string{"name": "ada", "email": "ada@quicka.co"},
+			},
+			wantLinks: pagination.Links{
+				First: "/uploads/1?page[limit]=1&page[offset]=0",
+				Next:  "/uploads/1?page[limit]=1&page[offset]=1",
+			},
+			wantMeta: map[string]interface{}{"total": uint(3)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := pagination.PaginateCSV(csv.NewReader(strings.NewReader(upload)), "/uploads/1", tt.params, tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantLinks, response.Links)
+			assert.Equal(t, tt.wantMeta, response.Meta)
+		})
+	}
+}
+
+func TestPaginateCSVStopsAfterThePage(t *testing.T) {
+	r := csv.NewReader(strings.NewReader("a\nb\nc\nd,broken\n"))
+	response, err := pagination.PaginateCSV(r, "/uploads/1", pagination.Params{Limit: 2}, pagination.CSVOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, response.Data, 2)
+
+	r = csv.NewReader(strings.NewReader("a\nb\nc\nd,broken\n"))
+	_, err = pagination.PaginateCSV(r, "/uploads/1", pagination.Params{Limit: 2}, pagination.CSVOptions{Total: true})
+	assert.ErrorIs(t, err, csv.ErrFieldCount)
+}
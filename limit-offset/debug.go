@@ -0,0 +1,66 @@
+package pagination
+
+// Debug type encapsulates what the pagination layer produced for a request,
+// it is added to the meta of the responses when the Debug of the Policy is
+// enabled. Never enable it on production, it exposes the queries
+type Debug struct {
+	Limit   uint   `json:"limit"`
+	Offset  uint   `json:"offset"`
+	Sort    string `json:"sort,omitempty"`
+	Filters string `json:"filters,omitempty"`
+	Seed    string `json:"seed,omitempty"`
+	// Query is the SQL generated by Params.Query
+	Query string `json:"query"`
+	// Clauses are the extra clauses added with Response.AddDebugClause, like
+	// the WHERE of the filters or the Mongo filter document
+	Clauses map[string]interface{} `json:"clauses,omitempty"`
+}
+
+// AddDebugClause will add the given clause into the debug meta of the
+// response, it does nothing when the debug is disabled so it is safe to be
+// called always. The clauses are added as they are, the Redactions of the
+// policy aren't applied to them
+//
+//	where, args := params.Filters.Where(columns, params.Dialect, 1)
+//	response.AddDebugClause("where", where)
+func (r *Response) AddDebugClause(name string, clause interface{}) {
+	debug, ok := r.Meta["debug"].(*Debug)
+	if !ok {
+		return
+	}
+	if debug.Clauses == nil {
+		debug.Clauses = make(map[string]interface{})
+	}
+	debug.Clauses[name] = clause
+}
+
+// debugInfo method will build the debug meta for the params, the values of
+// the filters are redacted with the Redactions of the policy
+func (p Params) debugInfo() *Debug {
+	redacted := p
+	redacted.Filters = redactFilters(p.redactions, p.Filters)
+	return &Debug{
+		Limit:   p.Limit,
+		Offset:  p.Offset,
+		Sort:    p.SortValue(),
+		Filters: redacted.filterQuery(),
+		Seed:    p.Seed,
+		Query:   p.Query(),
+	}
+}
+
+// meta method will build the meta of the response for the params, nil when
+// there is nothing to add
+func (p Params) meta() map[string]interface{} {
+	if !p.debug && !(p.deepOffset && p.deepOffsetHint) {
+		return nil
+	}
+	meta := make(map[string]interface{}, 2)
+	if p.deepOffset && p.deepOffsetHint {
+		meta["hint"] = DeepOffsetHint
+	}
+	if p.debug {
+		meta["debug"] = p.debugInfo()
+	}
+	return meta
+}
@@ -0,0 +1,40 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyDebug(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[limit]=5&sort=name.asc&filter[status]=active", nil)
+	assert.Nil(t, err)
+
+	params, err := pagination.Policy{DefaultLimit: 10}.FindParams(req)
+	assert.Nil(t, err)
+	response := pagination.Paginate(nil, "/sample", params)
+	response.AddDebugClause("where", " WHERE status = ?")
+	assert.Nil(t, response.Meta)
+
+	params, err = pagination.Policy{DefaultLimit: 10, Debug: true}.FindParams(req)
+	assert.Nil(t, err)
+	response = pagination.Paginate(nil, "/sample", params)
+	where, _ := params.Filters.Where(map[string]string{"status": "status"}, params.Dialect, 1)
+	response.AddDebugClause("where", where)
+
+	body, err := json.Marshal(response.Meta)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"debug": {
+			"limit": 5,
+			"offset": 0,
+			"sort": "name.asc",
+			"filters": "filter[status]=active",
+			"query": " LIMIT 6 OFFSET 0 ORDER BY name asc",
+			"clauses": {"where": " WHERE status = ?"}
+		}
+	}`, string(body))
+}
@@ -0,0 +1,44 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// HeaderDeprecation is the header telling the clients they are using a
+// deprecated feature, see RFC 9745
+const HeaderDeprecation = "Deprecation"
+
+// DeepOffsetHint is the hint added to the meta of the responses with a deep
+// offset, see Policy.DeepOffsetHint
+const DeepOffsetHint = "deep offsets are slow, please move to cursor pagination"
+
+// DeepOffset will check if the params have an offset beyond the deep offset of
+// the policy that found them
+func (p Params) DeepOffset() bool {
+	return p.deepOffset
+}
+
+// SetDeepOffsetHeaders will add the Deprecation header to the response when the
+// given params have a deep offset and the policy has a DeepOffsetDeprecation
+// date, so the clients can be driven to cursor pagination
+func (p Policy) SetDeepOffsetHeaders(wr http.ResponseWriter, params Params) {
+	if !params.deepOffset || p.DeepOffsetDeprecation.IsZero() {
+		return
+	}
+	wr.Header().Set(HeaderDeprecation, "@"+strconv.FormatInt(p.DeepOffsetDeprecation.Unix(), 10))
+}
+
+// applyDeepOffset method will flag the params with a deep offset and call the
+// OnDeepOffset callback for them
+func (p Policy) applyDeepOffset(req *http.Request, params *Params) {
+	params.deepOffset = p.MaxOffsetDepth > 0 && params.Offset > p.MaxOffsetDepth
+	if !params.deepOffset {
+		return
+	}
+	params.deepOffsetHint = p.DeepOffsetHint
+	p.log(req.Context(), "pagination: deep offset", "offset", params.Offset, "max_offset_depth", p.MaxOffsetDepth)
+	if p.OnDeepOffset != nil {
+		p.OnDeepOffset(req, *params)
+	}
+}
@@ -0,0 +1,75 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyDeepOffset(t *testing.T) {
+	var calls []uint
+	policy := pagination.Policy{
+		DefaultLimit:          10,
+		MaxOffsetDepth:        100,
+		DeepOffsetHint:        true,
+		DeepOffsetDeprecation: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		OnDeepOffset: func(req *http.Request, params pagination.Params) {
+			calls = append(calls, params.Offset)
+		},
+	}
+
+	tests := []struct {
+		name           string
+		url            string
+		wantDeep       bool
+		wantMeta       map[string]interface{}
+		wantDeprecated string
+	}{
+		{
+			name: "Shallow offset",
+			url:  "app.quicka.co/api/sample?page[offset]=100",
+		},
+		{
+			name:           "Deep offset",
+			url:            "app.quicka.co/api/sample?page[offset]=110",
+			wantDeep:       true,
+			wantMeta:       map[string]interface{}{"hint": pagination.DeepOffsetHint},
+			wantDeprecated: "@1767225600",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantDeep, params.DeepOffset())
+			assert.Equal(t, tt.wantMeta, pagination.Paginate(nil, "/sample", params).Meta)
+
+			wr := httptest.NewRecorder()
+			policy.SetDeepOffsetHeaders(wr, params)
+			assert.Equal(t, tt.wantDeprecated, wr.Header().Get(pagination.HeaderDeprecation))
+		})
+	}
+	assert.Equal(t, []uint{110}, calls)
+}
+
+func TestPolicyDeepOffsetWithoutHint(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, MaxOffsetDepth: 100}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[offset]=110", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.True(t, params.DeepOffset())
+	assert.Nil(t, pagination.Paginate(nil, "/sample", params).Meta)
+
+	wr := httptest.NewRecorder()
+	policy.SetDeepOffsetHeaders(wr, params)
+	assert.Equal(t, "", wr.Header().Get(pagination.HeaderDeprecation))
+}
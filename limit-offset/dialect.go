@@ -0,0 +1,66 @@
+package pagination
+
+import "strings"
+
+// Dialect type defines the SQL dialect used when building the query, the parts
+// of the query that aren't standard SQL are rendered following it
+type Dialect int
+
+const (
+	// DialectDefault renders everything as plain as possible, the collation
+	// names are written without quoting
+	DialectDefault Dialect = iota
+	// DialectPostgres renders the query for PostgreSQL
+	DialectPostgres
+	// DialectMySQL renders the query for MySQL and MariaDB
+	DialectMySQL
+	// DialectSQLite renders the query for SQLite
+	DialectSQLite
+)
+
+// collate method will render the COLLATE clause for the given collation, in
+// PostgreSQL the collations are identifiers that need quoting (for example
+// "und-x-icu") while MySQL and SQLite use plain names (utf8mb4_unicode_ci)
+func (d Dialect) collate(collation string) string {
+	if d == DialectPostgres {
+		return ` COLLATE "` + strings.ReplaceAll(collation, `"`, `""`) + `"`
+	}
+	return " COLLATE " + collation
+}
+
+// jsonPath method will render the expression for reading a nested field of a
+// JSON column, the first part of the field is the column and the rest the
+// path, for example metadata.priority is rendered as metadata->>'priority' on
+// PostgreSQL and JSON_EXTRACT(metadata, '$.priority') on MySQL. The default
+// dialect has no JSON support so the field is returned as it is
+func (d Dialect) jsonPath(field string) string {
+	column, path, ok := strings.Cut(field, ".")
+	if !ok {
+		return field
+	}
+	keys := strings.Split(path, ".")
+	switch d {
+	case DialectPostgres:
+		var b strings.Builder
+		b.WriteString(column)
+		for i, key := range keys {
+			if i == len(keys)-1 {
+				b.WriteString("->>")
+			} else {
+				b.WriteString("->")
+			}
+			b.WriteString(quoteString(key))
+		}
+		return b.String()
+	case DialectMySQL:
+		return "JSON_EXTRACT(" + column + ", " + quoteString("$."+path) + ")"
+	case DialectSQLite:
+		return "json_extract(" + column + ", " + quoteString("$."+path) + ")"
+	}
+	return field
+}
+
+// quoteString function will render the given value as a SQL string literal
+func quoteString(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,95 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryCollation(t *testing.T) {
+	sort := []pagination.Sort{
+		{
+			Field:     "name",
+			Order:     "asc",
+			Collation: "und-x-icu",
+		},
+		{
+			Field: "created_at",
+			Order: "desc",
+		},
+	}
+	tests := []struct {
+		name    string
+		dialect pagination.Dialect
+		sort    []pagination.Sort
+		want    string
+	}{
+		{
+			name:    "Default dialect",
+			dialect: pagination.DialectDefault,
+			sort:    sort,
+			want:    " LIMIT 11 OFFSET 0 ORDER BY name COLLATE und-x-icu asc,created_at desc",
+		},
+		{
+			name:    "Postgres dialect",
+			dialect: pagination.DialectPostgres,
+			sort:    sort,
+			want:    ` LIMIT 11 OFFSET 0 ORDER BY name COLLATE "und-x-icu" asc,created_at desc`,
+		},
+		{
+			name:    "MySQL dialect",
+			dialect: pagination.DialectMySQL,
+			sort: []pagination.Sort{
+				{
+					Field:     "name",
+					Order:     "asc",
+					Collation: "utf8mb4_unicode_ci",
+					Columns:   []string{"last_name", "first_name"},
+				},
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY last_name COLLATE utf8mb4_unicode_ci asc,first_name COLLATE utf8mb4_unicode_ci asc",
+		},
+		{
+			name:    "Postgres dialect quoting",
+			dialect: pagination.DialectPostgres,
+			sort: []pagination.Sort{
+				{
+					Field:     "name",
+					Order:     "asc",
+					Collation: `weird"name`,
+				},
+			},
+			want: ` LIMIT 11 OFFSET 0 ORDER BY name COLLATE "weird""name" asc`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := pagination.Params{
+				Limit:   10,
+				Sort:    tt.sort,
+				Dialect: tt.dialect,
+			}
+			assert.Equal(t, tt.want, params.Query())
+		})
+	}
+}
+
+func TestPolicyCollations(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		Dialect:      pagination.DialectPostgres,
+		Collations: map[string]string{
+			"name": "und-x-icu",
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=name.asc,id.asc", nil)
+	assert.Nil(t, err)
+
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, ` LIMIT 11 OFFSET 0 ORDER BY name COLLATE "und-x-icu" asc,id asc`, params.Query())
+	assert.Equal(t, "/sample?page[limit]=10&page[offset]=0&sort=name.asc,id.asc", pagination.Paginate(nil, "/sample", params).Links.First)
+}
@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrParameterPollution is the error returned by the policies refusing the
+// duplicated params, see DuplicateParamsReject
+var ErrParameterPollution = errors.New("pagination: duplicated param")
+
+// DuplicateParams type defines how the pagination params sent more than once
+// on a request are handled, like page[limit]=10&page[limit]=10000. The proxies
+// and the services must agree on it, otherwise a proxy checking the first
+// value could let through the last one
+type DuplicateParams int
+
+const (
+	// DuplicateParamsFirst takes the first value of the duplicated params
+	DuplicateParamsFirst DuplicateParams = iota
+	// DuplicateParamsLast takes the last value of the duplicated params
+	DuplicateParamsLast
+	// DuplicateParamsReject refuses the requests with duplicated params with
+	// ErrParameterPollution
+	DuplicateParamsReject
+)
+
+// applyDuplicateParams method will handle the duplicated pagination params of
+// the given raw query following the DuplicateParams of the policy. The last
+// values are placed before the raw query so they are the ones found. The
+// presets give the names of their own params as aliases, so they are handled
+// too
+func (p Policy) applyDuplicateParams(rawQuery string, aliases ...string) (string, error) {
+	if p.DuplicateParams == DuplicateParamsFirst {
+		return rawQuery, nil
+	}
+	names := p.names.orDefault()
+	tracked := append([]string{names.limit, names.offset, names.sort, names.orderBy, names.seed, names.number, names.size, ParamPageToken}, aliases...)
+	var (
+		seen   = make(map[string]bool, len(tracked))
+		last   = make(map[string]string)
+		prefix strings.Builder
+	)
+	for query := rawQuery; query != ""; {
+		var pair string
+		pair, query, _ = strings.Cut(query, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+		key, _, _ := strings.Cut(pair, "=")
+		key, ok := unescapeQuery(key)
+		if !ok || !slices.Contains(tracked, key) {
+			continue
+		}
+		if seen[key] {
+			if p.DuplicateParams == DuplicateParamsReject {
+				return "", fmt.Errorf("%w %s", ErrParameterPollution, key)
+			}
+			last[key] = pair
+		}
+		seen[key] = true
+	}
+	for _, name := range tracked {
+		if pair, ok := last[name]; ok {
+			prefix.WriteString(pair)
+			prefix.WriteByte('&')
+		}
+	}
+	return prefix.String() + rawQuery, nil
+}
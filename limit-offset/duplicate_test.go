@@ -0,0 +1,151 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDuplicateParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		duplicates pagination.DuplicateParams
+		query      string
+		wantLimit  uint
+		wantSort   string
+		wantErr    bool
+	}{
+		{
+			name:      "First by default",
+			query:     "page[limit]=10&page[limit]=10000&sort=name.asc&sort=id.desc",
+			wantLimit: 10,
+			wantSort:  "name",
+		},
+		{
+			name:       "Last",
+			duplicates: pagination.DuplicateParamsLast,
+			query:      "page[limit]=10&page[limit]=15&page%5Blimit%5D=30&sort=name.asc&sort=id.desc",
+			wantLimit:  30,
+			wantSort:   "id",
+		},
+		{
+			name:       "Last without duplicates",
+			duplicates: pagination.DuplicateParamsLast,
+			query:      "page[limit]=15&sort=name.asc",
+			wantLimit:  15,
+			wantSort:   "name",
+		},
+		{
+			name:       "Reject",
+			duplicates: pagination.DuplicateParamsReject,
+			query:      "page[limit]=10&page%5Blimit%5D=10000",
+			wantErr:    true,
+		},
+		{
+			name:       "Reject keeps the repeated filters",
+			duplicates: pagination.DuplicateParamsReject,
+			query:      "page[limit]=10&filter[tag]=a&filter[tag]=b&sort=name.asc",
+			wantLimit:  10,
+			wantSort:   "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := pagination.Policy{DefaultLimit: 5, MaxLimit: 100, DuplicateParams: tt.duplicates}
+			params, err := policy.FindParams(httptest.NewRequest("GET", "/users?"+tt.query, nil))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, pagination.ErrParameterPollution)
+				assert.EqualError(t, err, "pagination: duplicated param page[limit]")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+			assert.Equal(t, tt.wantSort, params.Sort[0].Field)
+		})
+	}
+}
+
+func TestDuplicatePageTokens(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	policy := pagination.Policy{DefaultLimit: 5, Tokens: codec, DuplicateParams: pagination.DuplicateParamsLast}
+	first := codec.Encode(pagination.PageToken{Query: "page[offset]=5"})
+	last := codec.Encode(pagination.PageToken{Query: "page[offset]=50"})
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?page[token]="+first+"&page[token]="+last, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(50), params.Offset)
+}
+
+func TestDuplicateParamsOnPresets(t *testing.T) {
+	finds := []struct {
+		name  string
+		query string
+		find  func(req *http.Request, policy pagination.Policy) (pagination.Params, error)
+	}{
+		{
+			name:  "GitHub",
+			query: "per_page=10&per_page=30",
+			find:  pagination.FindGitHubParams,
+		},
+		{
+			name:  "Stripe",
+			query: "limit=10&limit=30",
+			find: func(req *http.Request, policy pagination.Policy) (pagination.Params, error) {
+				stripe, err := pagination.FindStripeParams(req, policy)
+				return stripe.Params, err
+			},
+		},
+		{
+			name:  "OData",
+			query: "$top=10&%24top=30",
+			find: func(req *http.Request, policy pagination.Policy) (pagination.Params, error) {
+				odata, err := pagination.FindODataParams(req, policy)
+				return odata.Params, err
+			},
+		},
+		{
+			name:  "Slack",
+			query: "limit=10&limit=30",
+			find: func(req *http.Request, policy pagination.Policy) (pagination.Params, error) {
+				slack, err := pagination.FindSlackParams(req, policy)
+				return slack.Params, err
+			},
+		},
+		{
+			name:  "AIP",
+			query: "page_size=10&page_size=30",
+			find: func(req *http.Request, policy pagination.Policy) (pagination.Params, error) {
+				aip, err := pagination.FindAIPParams(req, policy)
+				return aip.Params, err
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		duplicates pagination.DuplicateParams
+		wantLimit  uint
+		wantErr    bool
+	}{
+		{name: "First", duplicates: pagination.DuplicateParamsFirst, wantLimit: 10},
+		{name: "Last", duplicates: pagination.DuplicateParamsLast, wantLimit: 30},
+		{name: "Reject", duplicates: pagination.DuplicateParamsReject, wantErr: true},
+	}
+
+	for _, find := range finds {
+		for _, tt := range tests {
+			t.Run(find.name+" "+tt.name, func(t *testing.T) {
+				policy := pagination.Policy{DefaultLimit: 5, MaxLimit: 100, DuplicateParams: tt.duplicates}
+				params, err := find.find(httptest.NewRequest("GET", "/users?"+find.query, nil), policy)
+				if tt.wantErr {
+					assert.ErrorIs(t, err, pagination.ErrParameterPollution)
+					return
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantLimit, params.Limit)
+			})
+		}
+	}
+}
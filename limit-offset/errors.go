@@ -0,0 +1,267 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ErrUnstableSort is the error returned when the sort can't guarantee a stable
+// order and the requested offset goes beyond the allowed depth, it can be
+// checked with errors.Is, the error returned is an *UnstableSortError
+var ErrUnstableSort = errors.New("pagination: unstable sort")
+
+// UnstableSortError type encapsulates the information about a request that was
+// refused because its sort is not stable
+type UnstableSortError struct {
+	// Offset is the offset requested
+	Offset uint
+	// MaxOffset is the deepest offset allowed with an unstable sort
+	MaxOffset uint
+}
+
+// Error will describe the error suggesting cursor pagination
+func (e *UnstableSortError) Error() string {
+	return fmt.Sprintf(
+		"pagination: the sort is not stable beyond offset %d (requested %d), sort by a unique field or use cursor pagination",
+		e.MaxOffset,
+		e.Offset,
+	)
+}
+
+// Is will make errors.Is match the ErrUnstableSort
+func (e *UnstableSortError) Is(target error) bool {
+	return target == ErrUnstableSort
+}
+
+// ErrResultWindowExceeded is the error returned when the page requested goes
+// beyond the MaxResultWindow of the policy, it can be checked with errors.Is,
+// the error returned is a *ResultWindowError
+var ErrResultWindowExceeded = errors.New("pagination: result window exceeded")
+
+// ResultWindowError type encapsulates the information about a request that
+// was refused because its page goes beyond the result window
+type ResultWindowError struct {
+	// Offset and Limit are the ones requested
+	Offset uint
+	Limit  uint
+	// MaxResultWindow is the deepest item that can be paginated
+	MaxResultWindow uint
+	// ExportURL is where the whole collection can be exported, when there is
+	// such API
+	ExportURL string
+}
+
+// Error will describe the error suggesting the export API
+func (e *ResultWindowError) Error() string {
+	message := fmt.Sprintf(
+		"pagination: the page goes beyond the first %d items (requested offset %d and limit %d)",
+		e.MaxResultWindow,
+		e.Offset,
+		e.Limit,
+	)
+	if e.ExportURL != "" {
+		return message + ", use " + e.ExportURL + " for exporting the whole collection"
+	}
+	return message
+}
+
+// Is will make errors.Is match the ErrResultWindowExceeded
+func (e *ResultWindowError) Is(target error) bool {
+	return target == ErrResultWindowExceeded
+}
+
+var (
+	// ErrNegativeValue is the error matched by the numeric params with a
+	// negative value, like page[limit]=-5, the error returned is a
+	// *NumberError
+	ErrNegativeValue = errors.New("pagination: negative value")
+	// ErrValueTooLarge is the error matched by the numeric params with a
+	// value that doesn't fit on 32 bits, like page[offset]=99999999999999, the
+	// error returned is a *NumberError
+	ErrValueTooLarge = errors.New("pagination: value too large")
+)
+
+// NumberError type encapsulates the information about a numeric param of the
+// request that can't be accepted, so the handlers can tell the client which
+// one is wrong
+type NumberError struct {
+	// Param is the name of the param and Value the raw value sent
+	Param string
+	Value string
+	// Err is the kind of problem, ErrNegativeValue or ErrValueTooLarge
+	Err error
+}
+
+// Error will describe the wrong param, including the biggest value accepted
+// when it is too large
+func (e *NumberError) Error() string {
+	if errors.Is(e.Err, ErrValueTooLarge) {
+		return fmt.Sprintf("pagination: invalid %s=%q: the value is bigger than %d", e.Param, e.Value, uint32(math.MaxUint32))
+	}
+	return fmt.Sprintf("pagination: invalid %s=%q: the value can't be negative", e.Param, e.Value)
+}
+
+// Unwrap will make errors.Is match the kind of problem
+func (e *NumberError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidSortField is the error returned by the policies with
+// StrictSortFields for the sort fields that aren't safe identifiers
+var ErrInvalidSortField = errors.New("pagination: invalid sort field")
+
+// ErrInvalidSortOrder is the error returned for the sort orders that aren't
+// asc or desc, it can be checked with errors.Is, the error returned is a
+// *SortOrderError
+var ErrInvalidSortOrder = errors.New("pagination: invalid sort order")
+
+// SortOrderError type encapsulates the information about a sort field of the
+// request with an order that isn't asc or desc
+type SortOrderError struct {
+	Field string
+	Order string
+}
+
+// Error will describe the wrong order
+func (e *SortOrderError) Error() string {
+	return fmt.Sprintf("pagination: invalid sort order %q for the field %q, only asc and desc are allowed", e.Order, e.Field)
+}
+
+// Is will make errors.Is match the ErrInvalidSortOrder
+func (e *SortOrderError) Is(target error) bool {
+	return target == ErrInvalidSortOrder
+}
+
+// ErrInvalidFilter is the error returned when a filter of the request doesn't
+// follow its schema, it can be checked with errors.Is, the error returned is a
+// *FilterError
+var ErrInvalidFilter = errors.New("pagination: invalid filter")
+
+// FilterError type encapsulates the information about a filter of the request
+// that doesn't follow its schema
+type FilterError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+// Error will describe the wrong filter
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("pagination: invalid filter %s=%q: %s", e.Field, e.Value, e.Reason)
+}
+
+// Is will make errors.Is match the ErrInvalidFilter
+func (e *FilterError) Is(target error) bool {
+	return target == ErrInvalidFilter
+}
+
+// ErrInvalidToken is the error returned when a page token can't be accepted,
+// it can be checked with errors.Is, the error returned is a *TokenError which
+// also matches the error of its kind, like ErrTokenExpired
+var ErrInvalidToken = errors.New("pagination: invalid page token")
+
+var (
+	// ErrTokenMalformed is the error matched by the tokens that can't be read,
+	// usually client bugs like truncated tokens
+	ErrTokenMalformed = errors.New("pagination: malformed page token")
+	// ErrTokenTampered is the error matched by the tokens with a wrong
+	// signature, that is tokens modified or built by the client
+	ErrTokenTampered = errors.New("pagination: tampered page token")
+	// ErrTokenVersion is the error matched by the tokens issued with a format
+	// version not supported anymore
+	ErrTokenVersion = errors.New("pagination: page token version not supported")
+	// ErrTokenExpired is the error matched by the tokens older than the TTL
+	ErrTokenExpired = errors.New("pagination: expired page token")
+	// ErrTokenMismatch is the error matched by the tokens used with a request
+	// different from the one that issued them, like a different filter
+	ErrTokenMismatch = errors.New("pagination: page token issued for another request")
+	// ErrTokenScopeMismatch is the error matched by the tokens used by a
+	// principal different from the one they were issued to, like another
+	// tenant, see WithTokenScope
+	ErrTokenScopeMismatch = errors.New("pagination: page token issued for another scope")
+)
+
+// TokenFailure type classifies why a page token was refused, so attacks can
+// be told apart from client bugs
+type TokenFailure string
+
+const (
+	// TokenMalformed is the failure of the tokens that can't be read
+	TokenMalformed TokenFailure = "malformed"
+	// TokenTampered is the failure of the tokens with a wrong signature
+	TokenTampered TokenFailure = "tampered"
+	// TokenVersionMismatch is the failure of the tokens with an old version
+	TokenVersionMismatch TokenFailure = "version_mismatch"
+	// TokenExpired is the failure of the tokens older than the TTL
+	TokenExpired TokenFailure = "expired"
+	// TokenRequestMismatch is the failure of the tokens used with a request
+	// different from the one that issued them
+	TokenRequestMismatch TokenFailure = "request_mismatch"
+	// TokenScopeMismatch is the failure of the tokens used by a principal
+	// different from the one they were issued to
+	TokenScopeMismatch TokenFailure = "scope_mismatch"
+)
+
+// tokenFailureErrors maps the failures into the errors they match
+var tokenFailureErrors = map[TokenFailure]error{
+	TokenMalformed:       ErrTokenMalformed,
+	TokenTampered:        ErrTokenTampered,
+	TokenVersionMismatch: ErrTokenVersion,
+	TokenExpired:         ErrTokenExpired,
+	TokenRequestMismatch: ErrTokenMismatch,
+	TokenScopeMismatch:   ErrTokenScopeMismatch,
+}
+
+// TokenError type encapsulates the information about a page token that can't
+// be accepted
+type TokenError struct {
+	Failure TokenFailure
+	Reason  string
+	// CorrelationID is the ID of the request that issued the token, when it
+	// could be read from it. It comes from the client, so it is only useful
+	// for finding the logs
+	CorrelationID string
+	// IssuedAt is when the token claims it was issued, when it could be read
+	// from it. It isn't verified for the tampered tokens
+	IssuedAt time.Time
+}
+
+// Error will describe why the token was refused
+func (e *TokenError) Error() string {
+	return "pagination: invalid page token: " + e.Reason
+}
+
+// Is will make errors.Is match the ErrInvalidToken and the error of the
+// failure of the token
+func (e *TokenError) Is(target error) bool {
+	return target == ErrInvalidToken || target == tokenFailureErrors[e.Failure]
+}
+
+// ErrPolicyViolation is the error matched by the problems found by
+// Params.Validate, like a limit bigger than the MaxLimit of the policy
+var ErrPolicyViolation = errors.New("pagination: policy violation")
+
+// ParamsError type encapsulates all the problems found on the params of a
+// request by FindParamsStrict, or by Params.Validate, errors.Is and errors.As
+// match any of them
+type ParamsError struct {
+	Problems []error
+}
+
+// Error will describe all the problems found, one per line
+func (e *ParamsError) Error() string {
+	messages := make([]string, 0, len(e.Problems))
+	for _, problem := range e.Problems {
+		messages = append(messages, problem.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Unwrap will answer back the problems found, so errors.Is and errors.As can
+// match them
+func (e *ParamsError) Unwrap() []error {
+	return e.Problems
+}
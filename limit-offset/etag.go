@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// ETag will compute a stable ETag from the given encoded response. The same
+// page contents always produce the same ETag
+func ETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// VersionETag will compute an ETag from a version provided by the caller (for
+// example the last updated_at of the collection) and the page params, so we
+// can answer back a 304 before even querying the data. Everything selecting
+// the page is covered, like the filters, the sort and the seed
+func VersionETag(version string, params Params) string {
+	return versionETag(version, params, params.filterQuery())
+}
+
+// VersionETag method will compute the ETag like the VersionETag function does,
+// covering the search term too
+func (l ListParams) VersionETag(version string) string {
+	return versionETag(version, l.Params, l.query())
+}
+
+// versionETag function will hash the version with the canonical query of the
+// link to the page of the params, carrying the given encoded query
+func versionETag(version string, params Params, query string) string {
+	h := sha256.New()
+	h.Write([]byte(version))
+	h.Write([]byte{0})
+	h.Write([]byte(params.selfQuery(query)))
+	if params.unlimited {
+		h.Write([]byte{0})
+		h.Write([]byte(LimitAll))
+	}
+	sum := h.Sum(nil)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified will set the ETag header and check it against the If-None-Match
+// header of the request, when they match a 304 is written and true is returned
+// so the handler can stop there
+func NotModified(wr http.ResponseWriter, req *http.Request, etag string) bool {
+	wr.Header().Set("ETag", etag)
+	if !etagMatches(req.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	wr.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// WriteJSON will encode the given response, set its ETag and write it unless
+// the client already has it, in that case a 304 is written without body
+func WriteJSON(wr http.ResponseWriter, req *http.Request, resp Response) error {
+	body, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if NotModified(wr, req, ETag(body)) {
+		return nil
+	}
+	wr.Header().Set("Content-Type", "application/json")
+	_, err = wr.Write(body)
+	return err
+}
+
+// etagMatches function will check if the etag is one of the values of the
+// If-None-Match header, using the weak comparison as the RFC 7232 defines
+func etagMatches(ifNoneMatch, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for ifNoneMatch != "" {
+		var candidate string
+		candidate, ifNoneMatch, _ = strings.Cut(ifNoneMatch, ",")
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	etag := pagination.ETag([]byte(`{"data":["sample"]}`))
+
+	assert.Equal(t, etag, pagination.ETag([]byte(`{"data":["sample"]}`)))
+	assert.NotEqual(t, etag, pagination.ETag([]byte(`{"data":["sample2"]}`)))
+	assert.Equal(t, byte('"'), etag[0])
+	assert.Equal(t, byte('"'), etag[len(etag)-1])
+}
+
+func TestVersionETag(t *testing.T) {
+	params := pagination.Params{Limit: 10, Offset: 20}
+	etag := pagination.VersionETag("v1", params)
+
+	assert.Equal(t, etag, pagination.VersionETag("v1", params))
+	assert.NotEqual(t, etag, pagination.VersionETag("v2", params))
+	assert.NotEqual(t, etag, pagination.VersionETag("v1", pagination.Params{Limit: 10, Offset: 30}))
+	assert.NotEqual(t, etag, pagination.VersionETag("v1", pagination.Params{
+		Limit:  10,
+		Offset: 20,
+		Sort:   []pagination.Sort{{Field: "name", Order: "asc"}},
+	}))
+}
+
+func TestVersionETagCoversTheQuery(t *testing.T) {
+	find := func(target string) pagination.ListParams {
+		list, err := pagination.FindListParams(httptest.NewRequest(http.MethodGet, target, nil), pagination.Policy{RandomSort: true})
+		assert.NoError(t, err)
+		return list
+	}
+	active := find("/users?filter[status]=active&filter[role]=admin")
+	etag := active.VersionETag("v1")
+
+	assert.Equal(t, etag, find("/users?filter[role]=admin&filter[status]=active").VersionETag("v1"))
+	assert.Equal(t, pagination.VersionETag("v1", active.Params), pagination.VersionETag("v1", find("/users?filter[role]=admin&filter[status]=active").Params))
+	assert.NotEqual(t, pagination.VersionETag("v1", active.Params), pagination.VersionETag("v1", find("/users?filter[status]=pending&filter[role]=admin").Params))
+	assert.NotEqual(t, etag, find("/users?filter[status]=active").VersionETag("v1"))
+	assert.NotEqual(t, etag, find("/users?filter[status]=active&filter[role]=admin&q=jo").VersionETag("v1"))
+	assert.NotEqual(t, find("/users?sort=random&seed=1").VersionETag("v1"), find("/users?sort=random&seed=2").VersionETag("v1"))
+}
+
+func TestNotModified(t *testing.T) {
+	etag := `"abc"`
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{
+			name: "Without If-None-Match",
+			want: false,
+		},
+		{
+			name:        "Same etag",
+			ifNoneMatch: `"abc"`,
+			want:        true,
+		},
+		{
+			name:        "Weak etag",
+			ifNoneMatch: `W/"abc"`,
+			want:        true,
+		},
+		{
+			name:        "One of the list",
+			ifNoneMatch: `"xyz", "abc"`,
+			want:        true,
+		},
+		{
+			name:        "Any etag",
+			ifNoneMatch: `*`,
+			want:        true,
+		},
+		{
+			name:        "Different etag",
+			ifNoneMatch: `"xyz"`,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/sample", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			assert.Equal(t, tt.want, pagination.NotModified(rec, req, etag))
+			assert.Equal(t, etag, rec.Header().Get("ETag"))
+			if tt.want {
+				assert.Equal(t, http.StatusNotModified, rec.Code)
+			}
+		})
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	resp := pagination.Paginate([]interface{}{"sample"}, "/sample", pagination.Params{Limit: 5})
+
+	req := httptest.NewRequest(http.MethodGet, "/sample", nil)
+	rec := httptest.NewRecorder()
+	assert.Nil(t, pagination.WriteJSON(rec, req, resp))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"data":["sample"],"links":{"first":"/sample?page[limit]=5&page[offset]=0"}}`, rec.Body.String())
+	etag := rec.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	req = httptest.NewRequest(http.MethodGet, "/sample", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	assert.Nil(t, pagination.WriteJSON(rec, req, resp))
+	assert.Equal(t, http.StatusNotModified, rec.Code)
+	assert.Equal(t, 0, rec.Body.Len())
+}
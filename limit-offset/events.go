@@ -0,0 +1,44 @@
+package pagination
+
+import "context"
+
+// PaginateInfo type encapsulates the information about a paginated response
+// passed to the OnPaginate callback of the Policy
+type PaginateInfo struct {
+	// Resource is the Resource of the policy, like users
+	Resource string
+	Params   Params
+	// Count is the number of items returned on the page
+	Count int
+	// HasNext is true when the Next link was emitted
+	HasNext bool
+}
+
+// PaginateContext works like Paginate passing the given context to the
+// OnPaginate callback of the policy that found the params
+func PaginateContext(ctx context.Context, data []interface{}, baseURL string, params Params) Response {
+	response := Paginate(data, baseURL, params.withoutOnPaginate())
+	params.paginated(ctx, response)
+	return response
+}
+
+// paginated method will call the OnPaginate callback, when there is one, for
+// the given response
+func (p Params) paginated(ctx context.Context, response Response) {
+	if p.onPaginate == nil {
+		return
+	}
+	p.onPaginate(ctx, PaginateInfo{
+		Resource: p.resource,
+		Params:   p,
+		Count:    len(response.Data),
+		HasNext:  response.Links.Next != "",
+	})
+}
+
+// withoutOnPaginate method will answer back a copy of the params without the
+// OnPaginate callback, so it isn't called twice
+func (p Params) withoutOnPaginate() Params {
+	p.onPaginate = nil
+	return p
+}
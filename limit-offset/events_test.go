@@ -0,0 +1,46 @@
+package pagination_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+type ctxKey struct{}
+
+func TestPolicyOnPaginate(t *testing.T) {
+	var infos []pagination.PaginateInfo
+	var values []interface{}
+	policy := pagination.Policy{
+		DefaultLimit: 2,
+		Resource:     "users",
+		OnPaginate: func(ctx context.Context, info pagination.PaginateInfo) {
+			infos = append(infos, info)
+			values = append(values, ctx.Value(ctxKey{}))
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[offset]=2", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+
+	pagination.Paginate([]interface{}{"a", "b", "c"}, "/users", params)
+	ctx := context.WithValue(context.Background(), ctxKey{}, "request")
+	pagination.PaginateContext(ctx, []interface{}{"a"}, "/users", params)
+	pagination.NewLinkTemplate("/users", params).Paginate([]interface{}{"a", "b"}, params)
+
+	assert.Equal(t, 3, len(infos))
+	assert.Equal(t, "users", infos[0].Resource)
+	assert.Equal(t, uint(2), infos[0].Params.Offset)
+	assert.Equal(t, 2, infos[0].Count)
+	assert.True(t, infos[0].HasNext)
+	assert.Equal(t, 1, infos[1].Count)
+	assert.False(t, infos[1].HasNext)
+	assert.Equal(t, 2, infos[2].Count)
+	assert.False(t, infos[2].HasNext)
+	assert.Equal(t, []interface{}{nil, "request", nil}, values)
+}
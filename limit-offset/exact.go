@@ -0,0 +1,52 @@
+package pagination
+
+import "context"
+
+// PaginateTotal will build a new paginated response like Paginate does but
+// using the given total of items instead of the extra item for finding the
+// last page, so the query can fetch the real limit, see Params.ExactCount.
+// The Next link is there when there are items beyond the page, the Last link
+// points to the last page and the total is added to the meta. The params
+// without limit (see Unlimited) keep all the data and have no links
+func PaginateTotal(data []interface{}, baseURL string, params Params, total uint) Response {
+	if !params.unlimited && uint(len(data)) > params.Limit {
+		data = data[:params.Limit]
+	}
+	dataSize := len(data)
+	if !params.unlimited && params.Offset+params.Limit < total {
+		dataSize = int(params.Limit) + 1
+	}
+	params.pageServed(dataSize)
+	links := buildLinks(baseURL, params, dataSize)
+	if !params.unlimited && total > 0 && params.Limit > 0 {
+		buf := acquireLinkBuffer()
+		links.Last = params.linker(buf, baseURL, params.paramNames(), params.filterQuery())((total - 1) / params.Limit * params.Limit)
+		releaseLinkBuffer(buf)
+	}
+	meta := params.meta()
+	if meta == nil {
+		meta = make(map[string]interface{}, 1)
+	}
+	meta["total"] = total
+	response := Response{
+		Data:   data,
+		Links:  links,
+		Meta:   meta,
+		stable: params.StableJSON,
+	}
+	params.paginated(context.Background(), response)
+	return response
+}
+
+// PaginateCount will build a new paginated response like PaginateTotal does
+// with the total answered back by the given count function, which can be
+// wrapped with CachedCount. The error of the count is answered back
+func PaginateCount(ctx context.Context, data []interface{}, baseURL string, params Params, count func(ctx context.Context) (uint, error)) (Response, error) {
+	total, err := count(ctx)
+	if err != nil {
+		return Response{}, err
+	}
+	response := PaginateTotal(data, baseURL, params.withoutOnPaginate(), total)
+	params.paginated(ctx, response)
+	return response, nil
+}
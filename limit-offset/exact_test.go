@@ -0,0 +1,138 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginateTotal(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      []interface{}
+		params    pagination.Params
+		total     uint
+		wantData  []interface{}
+		wantLinks pagination.Links
+	}{
+		{
+			name:     "First page",
+			data:     []interface{}{1, 2},
+			params:   pagination.Params{Limit: 2, Offset: 0},
+			total:    5,
+			wantData: []interface{}{1, 2},
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+				Next:  "/users?page[limit]=2&page[offset]=2",
+				Last:  "/users?page[limit]=2&page[offset]=4",
+			},
+		},
+		{
+			name:     "Last partial page",
+			data:     []interface{}{5},
+			params:   pagination.Params{Limit: 2, Offset: 4},
+			total:    5,
+			wantData: []interface{}{5},
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+				Prev:  "/users?page[limit]=2&page[offset]=2",
+				Last:  "/users?page[limit]=2&page[offset]=4",
+			},
+		},
+		{
+			name:     "Last full page",
+			data:     []interface{}{3, 4},
+			params:   pagination.Params{Limit: 2, Offset: 2},
+			total:    4,
+			wantData: []interface{}{3, 4},
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+				Prev:  "/users?page[limit]=2&page[offset]=0",
+				Last:  "/users?page[limit]=2&page[offset]=2",
+			},
+		},
+		{
+			name:     "Extra item is dropped",
+			data:     []interface{}{1, 2, 3},
+			params:   pagination.Params{Limit: 2, Offset: 0},
+			total:    2,
+			wantData: []interface{}{1, 2},
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+				Last:  "/users?page[limit]=2&page[offset]=0",
+			},
+		},
+		{
+			name:   "Empty collection",
+			params: pagination.Params{Limit: 2, Offset: 0},
+			wantLinks: pagination.Links{
+				First: "/users?page[limit]=2&page[offset]=0",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := pagination.PaginateTotal(tt.data, "/users", tt.params, tt.total)
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantLinks, response.Links)
+			assert.Equal(t, tt.total, response.Meta["total"])
+		})
+	}
+}
+
+func TestPaginateCount(t *testing.T) {
+	params := pagination.Params{Limit: 2, Offset: 0}
+	response, err := pagination.PaginateCount(context.Background(), []interface{}{1, 2}, "/users", params, func(ctx context.Context) (uint, error) {
+		return 3, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/users?page[limit]=2&page[offset]=2", response.Links.Next)
+	assert.Equal(t, uint(3), response.Meta["total"])
+
+	errCount := errors.New("count failed")
+	_, err = pagination.PaginateCount(context.Background(), nil, "/users", params, func(ctx context.Context) (uint, error) {
+		return 0, errCount
+	})
+	assert.ErrorIs(t, err, errCount)
+}
+
+func TestPolicyExactCount(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/users?page[limit]=10&page[offset]=20", nil)
+	params, err := pagination.Policy{ExactCount: true}.FindParams(req)
+	assert.NoError(t, err)
+	assert.True(t, params.ExactCount)
+	assert.Equal(t, " LIMIT 10 OFFSET 20 ", params.Query())
+
+	params.ExactCount = false
+	assert.Equal(t, " LIMIT 11 OFFSET 20 ", params.Query())
+}
+
+func TestPaginateTotalUnlimited(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 2, ExactCount: true, AllowUnlimited: func(context.Context) bool { return true }}
+	req, err := http.NewRequest(http.MethodGet, "/users?page[limit]=all", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.True(t, params.Unlimited())
+
+	response := pagination.PaginateTotal([]interface{}{"a", "b", "c"}, "/users", params, 3)
+	assert.Equal(t, []interface{}{"a", "b", "c"}, response.Data)
+	assert.Equal(t, pagination.Links{}, response.Links)
+	assert.Equal(t, uint(3), response.Meta["total"])
+
+	users := []string{"a", "b", "c"}
+	handler := pagination.Handler(func(ctx context.Context, params pagination.Params) ([]string, error) {
+		return users, nil
+	}, pagination.WithPolicy(policy), pagination.WithCount(func(ctx context.Context, params pagination.Params) (uint, error) {
+		return uint(len(users)), nil
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users?page[limit]=all", nil))
+	assert.JSONEq(t, `{"data": ["a", "b", "c"], "links": {}, "meta": {"total": 3}}`, rec.Body.String())
+}
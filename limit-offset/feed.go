@@ -0,0 +1,36 @@
+package pagination
+
+import "encoding/xml"
+
+// FeedLink type encapsulates a paged feed link of the RFC 5005, it is encoded
+// as an Atom link element so it can be used on Atom feeds and, thanks to the
+// namespace, on RSS channels too
+type FeedLink struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom link"`
+	Rel     string   `xml:"rel,attr"`
+	Href    string   `xml:"href,attr"`
+}
+
+// FeedLinks will build the RFC 5005 paged feed links for navigate through the
+// pages of a feed, the same links Paginate builds for the given data size
+//
+//	feed.Links = append(feed.Links, pagination.FeedLinks("/audit.atom", params, len(entries))...)
+func FeedLinks(baseURL string, params Params, dataSize int) []FeedLink {
+	return buildLinks(baseURL, params, dataSize).Feed()
+}
+
+// Feed method will answer back the links as RFC 5005 paged feed links, the
+// empty links are skipped and the prev link uses the previous relation
+func (l Links) Feed() []FeedLink {
+	links := make([]FeedLink, 0, 4)
+	add := func(href, rel string) {
+		if href != "" {
+			links = append(links, FeedLink{Rel: rel, Href: href})
+		}
+	}
+	add(l.First, "first")
+	add(l.Prev, "previous")
+	add(l.Next, "next")
+	add(l.Last, "last")
+	return links
+}
@@ -0,0 +1,51 @@
+package pagination_test
+
+import (
+	"encoding/xml"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFeedLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		params   pagination.Params
+		dataSize int
+		want     []pagination.FeedLink
+	}{
+		{
+			name:     "First page with more pages",
+			params:   pagination.Params{Limit: 2},
+			dataSize: 3,
+			want: []pagination.FeedLink{
+				{Rel: "first", Href: "/audit.atom?page[limit]=2&page[offset]=0"},
+				{Rel: "next", Href: "/audit.atom?page[limit]=2&page[offset]=2"},
+			},
+		},
+		{
+			name:     "Last page",
+			params:   pagination.Params{Limit: 2, Offset: 4},
+			dataSize: 1,
+			want: []pagination.FeedLink{
+				{Rel: "first", Href: "/audit.atom?page[limit]=2&page[offset]=0"},
+				{Rel: "previous", Href: "/audit.atom?page[limit]=2&page[offset]=2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.FeedLinks("/audit.atom", tt.params, tt.dataSize))
+		})
+	}
+}
+
+func TestFeedLinkXML(t *testing.T) {
+	links := pagination.Links{Next: "/audit.atom?page[limit]=2&page[offset]=2", Last: "/audit.atom?page[limit]=2&page[offset]=8"}.Feed()
+	body, err := xml.Marshal(links)
+	assert.Nil(t, err)
+	assert.Equal(t, `<link xmlns="http://www.w3.org/2005/Atom" rel="next" href="/audit.atom?page[limit]=2&amp;page[offset]=2"></link>`+
+		`<link xmlns="http://www.w3.org/2005/Atom" rel="last" href="/audit.atom?page[limit]=2&amp;page[offset]=8"></link>`, string(body))
+}
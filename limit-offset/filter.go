@@ -0,0 +1,205 @@
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// ParamFilter is the value for the filter parameters on http request, each
+// filter uses the field name between brackets, for example filter[status]
+const ParamFilter = "filter"
+
+// Operator type defines how the value of a filter is compared with the field
+type Operator string
+
+const (
+	// OpEq matches the fields equal to the value, it is the default operator
+	OpEq Operator = "eq"
+	// OpNe matches the fields not equal to the value
+	OpNe Operator = "ne"
+	// OpGt matches the fields greater than the value
+	OpGt Operator = "gt"
+	// OpGte matches the fields greater than or equal to the value
+	OpGte Operator = "gte"
+	// OpLt matches the fields lower than the value
+	OpLt Operator = "lt"
+	// OpLte matches the fields lower than or equal to the value
+	OpLte Operator = "lte"
+	// OpIn matches the fields equal to one of the comma separated values
+	OpIn Operator = "in"
+	// OpLike matches the fields following the pattern of the value
+	OpLike Operator = "like"
+)
+
+// operators are all the operators accepted on the filters
+var operators = []Operator{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn, OpLike}
+
+// parseOperator function will check if the given value is a known operator
+func parseOperator(value string) (Operator, bool) {
+	for _, op := range operators {
+		if string(op) == value {
+			return op, true
+		}
+	}
+	return "", false
+}
+
+// Filter type encapsulates a single filter found on the http request, for
+// example filter[status]=active. The operator can be given as a suffix on
+// the param name, filter[created_at][gte]=2024-01-01, or as a prefix on the
+// value, filter[status]=in:active,pending
+type Filter struct {
+	Field    string
+	Operator Operator
+	Value    string
+	// Values keeps each one of the values of the in operator, for the rest of
+	// the operators it is empty
+	Values []string
+	// Parsed keeps the values coerced into the type declared on the schema,
+	// one for each value, see FilterSchema. When set they are used as the args
+	// of the query
+	Parsed []interface{}
+}
+
+// Filters type encapsulates all the filters found on the http request, in the
+// same order they were found
+type Filters []Filter
+
+// Get will return the value of the first filter for the given field
+func (f Filters) Get(field string) (string, bool) {
+	for _, filter := range f {
+		if filter.Field == field {
+			return filter.Value, true
+		}
+	}
+	return "", false
+}
+
+// Values will return the values of all the filters for the given field
+func (f Filters) Values(field string) []string {
+	var values []string
+	for _, filter := range f {
+		if filter.Field == field {
+			values = append(values, filter.Value)
+		}
+	}
+	return values
+}
+
+// Encode will serialize the filters into the query of the links, the filters
+// are sorted by field, operator and value so the same filters always produce
+// the same links no matter the order the client used, which keeps the links
+// deterministic and cacheable
+func (f Filters) Encode() string {
+	if len(f) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	for i, filter := range f.sorted() {
+		if i > 0 {
+			b.WriteByte('&')
+		}
+		// The field is escaped too, so a field sent by the client can't smuggle
+		// other params into the links
+		b.WriteString(ParamFilter + "[" + url.QueryEscape(filter.Field) + "]")
+		// The eq operator is the default one, we only write it when the value
+		// could be confused with an operator prefix
+		if filter.Operator != OpEq || strings.Contains(filter.Value, ":") {
+			b.WriteString("[" + string(filter.Operator) + "]")
+		}
+		b.WriteByte('=')
+		b.WriteString(strings.ReplaceAll(url.QueryEscape(filter.Value), "%2C", ","))
+	}
+	return b.String()
+}
+
+// sorted method will answer back a copy of the filters sorted by field,
+// operator and value
+func (f Filters) sorted() Filters {
+	sorted := make(Filters, len(f))
+	copy(sorted, f)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Field != sorted[j].Field {
+			return sorted[i].Field < sorted[j].Field
+		}
+		if sorted[i].Operator != sorted[j].Operator {
+			return sorted[i].Operator < sorted[j].Operator
+		}
+		return sorted[i].Value < sorted[j].Value
+	})
+	return sorted
+}
+
+// FindFilters will find the filters on the request, for example
+// filter[status]=active&filter[owner_id]=42. Malformed filter params and
+// unknown operators are ignored like url.ParseQuery does
+func FindFilters(req *http.Request) Filters {
+	return findFilters(req.URL.RawQuery)
+}
+
+// findFilters function will scan the raw query looking for the filter params
+func findFilters(rawQuery string) (filters Filters) {
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key, ok := unescapeQuery(key)
+		if !ok {
+			continue
+		}
+		field, op, ok := filterKey(key)
+		if !ok {
+			continue
+		}
+		if value, ok = unescapeQuery(value); ok {
+			filters = append(filters, newFilter(field, op, value))
+		}
+	}
+	return filters
+}
+
+// newFilter function will build a filter, when the operator isn't found on the
+// param name we look for it on the value prefix
+func newFilter(field string, op Operator, value string) Filter {
+	if op == "" {
+		op = OpEq
+		if prefix, rest, ok := strings.Cut(value, ":"); ok {
+			if prefixOp, ok := parseOperator(prefix); ok {
+				op, value = prefixOp, rest
+			}
+		}
+	}
+	filter := Filter{
+		Field:    field,
+		Operator: op,
+		Value:    value,
+	}
+	if op == OpIn {
+		filter.Values = strings.Split(value, ",")
+	}
+	return filter
+}
+
+// filterKey function will return the field and the operator, when it is
+// present, of the given filter param name, for example status from
+// filter[status] or created_at and gte from filter[created_at][gte]
+func filterKey(key string) (string, Operator, bool) {
+	if !strings.HasPrefix(key, ParamFilter+"[") || !strings.HasSuffix(key, "]") {
+		return "", "", false
+	}
+	inner := key[len(ParamFilter)+1 : len(key)-1]
+	field, rawOp, hasOp := strings.Cut(inner, "][")
+	if field == "" || strings.ContainsAny(field, "[]") {
+		return "", "", false
+	}
+	if !hasOp {
+		return field, "", true
+	}
+	op, ok := parseOperator(rawOp)
+	return field, op, ok
+}
@@ -0,0 +1,218 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindFilters(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want pagination.Filters
+	}{
+		{
+			name: "Should return an empty filters",
+			url:  "app.quicka.co/api/sample?page[limit]=5",
+			want: nil,
+		},
+		{
+			name: "Should return the filters in order",
+			url:  "app.quicka.co/api/sample?filter[status]=active&page[limit]=5&filter[owner_id]=42",
+			want: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+				{Field: "owner_id", Operator: pagination.OpEq, Value: "42"},
+			},
+		},
+		{
+			name: "Should read escaped filters",
+			url:  "app.quicka.co/api/sample?filter%5Bname%5D=John+Doe",
+			want: pagination.Filters{
+				{Field: "name", Operator: pagination.OpEq, Value: "John Doe"},
+			},
+		},
+		{
+			name: "Should keep repeated filters",
+			url:  "app.quicka.co/api/sample?filter[status]=active&filter[status]=pending",
+			want: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+				{Field: "status", Operator: pagination.OpEq, Value: "pending"},
+			},
+		},
+		{
+			name: "Should ignore mallformed filters",
+			url:  "app.quicka.co/api/sample?filter=active&filter[]=1&filter[status=2&filter[a]]=3&filter[name]=%zz&filter[id]=4",
+			want: pagination.Filters{
+				{Field: "id", Operator: pagination.OpEq, Value: "4"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, pagination.FindFilters(req))
+		})
+	}
+}
+
+func TestFindFilterOperators(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want pagination.Filters
+	}{
+		{
+			name: "Should read the operator from the param name",
+			url:  "app.quicka.co/api/sample?filter[created_at][gte]=2024-01-01&filter[created_at][lt]=2024-02-01",
+			want: pagination.Filters{
+				{Field: "created_at", Operator: pagination.OpGte, Value: "2024-01-01"},
+				{Field: "created_at", Operator: pagination.OpLt, Value: "2024-02-01"},
+			},
+		},
+		{
+			name: "Should read the operator from the value",
+			url:  "app.quicka.co/api/sample?filter[status]=in:active,pending&filter[name]=like:John%25&filter[age]=gt:30",
+			want: pagination.Filters{
+				{Field: "status", Operator: pagination.OpIn, Value: "active,pending", Values: []string{"active", "pending"}},
+				{Field: "name", Operator: pagination.OpLike, Value: "John%"},
+				{Field: "age", Operator: pagination.OpGt, Value: "30"},
+			},
+		},
+		{
+			name: "Should keep values with unknown prefixes",
+			url:  "app.quicka.co/api/sample?filter[url]=https://quicka.co&filter[id][ne]=in:1",
+			want: pagination.Filters{
+				{Field: "url", Operator: pagination.OpEq, Value: "https://quicka.co"},
+				{Field: "id", Operator: pagination.OpNe, Value: "in:1"},
+			},
+		},
+		{
+			name: "Should ignore unknown operators",
+			url:  "app.quicka.co/api/sample?filter[created_at][after]=2024-01-01&filter[id][in]=1,2",
+			want: pagination.Filters{
+				{Field: "id", Operator: pagination.OpIn, Value: "1,2", Values: []string{"1", "2"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, pagination.FindFilters(req))
+		})
+	}
+}
+
+func TestFiltersGetAndValues(t *testing.T) {
+	filters := pagination.Filters{
+		{Field: "status", Operator: pagination.OpEq, Value: "active"},
+		{Field: "owner_id", Operator: pagination.OpEq, Value: "42"},
+		{Field: "status", Operator: pagination.OpEq, Value: "pending"},
+	}
+
+	value, ok := filters.Get("status")
+	assert.True(t, ok)
+	assert.Equal(t, "active", value)
+
+	_, ok = filters.Get("name")
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"active", "pending"}, filters.Values("status"))
+	assert.Nil(t, filters.Values("name"))
+}
+
+func TestFiltersEncode(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters pagination.Filters
+		want    string
+	}{
+		{
+			name: "Without filters",
+			want: "",
+		},
+		{
+			name: "Sorted by field, operator and value",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "pending"},
+				{Field: "created_at", Operator: pagination.OpLte, Value: "2024-12-31"},
+				{Field: "created_at", Operator: pagination.OpGte, Value: "2024-01-01"},
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+			},
+			want: "filter[created_at][gte]=2024-01-01&filter[created_at][lte]=2024-12-31&filter[status]=active&filter[status]=pending",
+		},
+		{
+			name: "Escaped values",
+			filters: pagination.Filters{
+				{Field: "name", Operator: pagination.OpLike, Value: "jo%&co"},
+				{Field: "status", Operator: pagination.OpIn, Value: "active,pending", Values: []string{"active", "pending"}},
+			},
+			want: "filter[name][like]=jo%25%26co&filter[status][in]=active,pending",
+		},
+		{
+			name: "Equal value that looks like an operator prefix",
+			filters: pagination.Filters{
+				{Field: "label", Operator: pagination.OpEq, Value: "in:progress"},
+			},
+			want: "filter[label][eq]=in%3Aprogress",
+		},
+		{
+			name: "Escaped fields",
+			filters: pagination.Filters{
+				{Field: "x&sort=password.desc", Operator: pagination.OpEq, Value: "1"},
+			},
+			want: "filter[x%26sort%3Dpassword.desc]=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.filters.Encode())
+		})
+	}
+}
+
+func TestFiltersCarriedOnLinks(t *testing.T) {
+	requests := []string{
+		"app.quicka.co/api/sample?filter[status]=in:active,pending&page[limit]=2&filter[name][like]=jo%25",
+		"app.quicka.co/api/sample?filter[name][like]=jo%25&page[limit]=2&filter[status][in]=active,pending",
+	}
+	for _, url := range requests {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		params, err := pagination.Policy{}.FindParams(req)
+		assert.Nil(t, err)
+
+		response := pagination.Paginate(make([]interface{}, 3), "/sample", params)
+		assert.Equal(t, pagination.Links{
+			First: "/sample?page[limit]=2&page[offset]=0&filter[name][like]=jo%25&filter[status][in]=active,pending",
+			Next:  "/sample?page[limit]=2&page[offset]=2&filter[name][like]=jo%25&filter[status][in]=active,pending",
+		}, response.Links)
+
+		// The links can be followed keeping the same filters
+		next, err := http.NewRequest(http.MethodGet, response.Links.Next, nil)
+		assert.Nil(t, err)
+		assert.Equal(t, params.Filters.Encode(), pagination.FindFilters(next).Encode())
+	}
+}
+
+func TestFiltersCantSmuggleParamsIntoLinks(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/u?filter%5Bx%26sort%3Dpassword.desc%5D=1&page[limit]=2", nil)
+	params, err := pagination.Policy{}.FindParams(req)
+	assert.NoError(t, err)
+
+	response := pagination.Paginate(make([]interface{}, 3), "/u", params)
+	next, err := url.Parse(response.Links.Next)
+	if assert.NoError(t, err) {
+		assert.Empty(t, next.Query().Get("sort"))
+		assert.Equal(t, "1", next.Query().Get("filter[x&sort=password.desc]"))
+	}
+}
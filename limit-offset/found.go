@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+)
+
+// foundParamsKey is the context key of the params found by a middleware
+type foundParamsKey struct{}
+
+// foundParams type keeps the params found for a request, the error finding
+// them and the policy they were found with
+type foundParams struct {
+	policy Policy
+	params Params
+	err    error
+}
+
+// WithParams method will answer back a context carrying the params found for
+// the request with the policy and the error finding them, so a middleware
+// finds them once for the handlers after it, see FoundParams
+func (p Policy) WithParams(ctx context.Context, params Params, err error) context.Context {
+	return context.WithValue(ctx, foundParamsKey{}, foundParams{policy: p, params: params, err: err})
+}
+
+// FoundParams method will answer back the params a middleware kept on the
+// context of the request with WithParams, when they were found with the same
+// policy, otherwise they are found like FindParams does. This way the logs,
+// the metrics and the callbacks of the policy fire once per request, and the
+// rules of a stricter policy of the route are still applied
+func (p Policy) FoundParams(req *http.Request) (Params, error) {
+	if found, ok := req.Context().Value(foundParamsKey{}).(foundParams); ok && samePolicy(found.policy, p) {
+		return found.params, found.err
+	}
+	return p.FindParams(req)
+}
+
+// samePolicy function will check if both policies have the same rules, the
+// callbacks are the same when they are the same function. The policies that
+// can't be compared, like the ones with callbacks inside the FilterSchema, are
+// considered different
+func samePolicy(a, b Policy) bool {
+	if a.names != b.names {
+		return false
+	}
+	a.names, b.names = paramNames{}, paramNames{}
+	va, vb := reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem()
+	for i := 0; i < va.NumField(); i++ {
+		fa, fb := va.Field(i), vb.Field(i)
+		if !fa.CanSet() || !sameFuncs(fa, fb) {
+			continue
+		}
+		fa.SetZero()
+		fb.SetZero()
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// sameFuncs function will check if the given values are the same functions,
+// or slices of the same functions like the Redactions. False is answered back
+// for any other kind of value
+func sameFuncs(a, b reflect.Value) bool {
+	switch {
+	case a.Kind() == reflect.Func:
+		return a.Pointer() == b.Pointer()
+	case a.Kind() == reflect.Slice && a.Type().Elem().Kind() == reflect.Func:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if a.Index(i).Pointer() != b.Index(i).Pointer() {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
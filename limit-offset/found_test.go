@@ -0,0 +1,91 @@
+package pagination_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyFoundParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10}
+	req := httptest.NewRequest(http.MethodGet, "/users?page[offset]=20", nil)
+
+	params, err := policy.FoundParams(req)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(20), params.Offset)
+
+	found := pagination.Params{Limit: 5, Offset: 40}
+	params, err = policy.FoundParams(req.WithContext(policy.WithParams(req.Context(), found, nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, found, params)
+
+	_, err = policy.FoundParams(req.WithContext(policy.WithParams(req.Context(), pagination.Params{}, pagination.ErrInvalidToken)))
+	assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+}
+
+func TestPolicyFoundParamsWithAnotherPolicy(t *testing.T) {
+	onPaginate := func(ctx context.Context, info pagination.PaginateInfo) {}
+	middleware := pagination.Policy{DefaultLimit: 10, OnPaginate: onPaginate}
+	req := httptest.NewRequest(http.MethodGet, "/users?page[limit]=100000", nil)
+	found, err := middleware.FindParams(req)
+	assert.NoError(t, err)
+	req = req.WithContext(middleware.WithParams(req.Context(), found, nil))
+
+	tests := []struct {
+		name      string
+		policy    pagination.Policy
+		wantLimit uint
+	}{
+		{
+			name:      "Same policy",
+			policy:    pagination.Policy{DefaultLimit: 10, OnPaginate: onPaginate},
+			wantLimit: 100000,
+		},
+		{
+			name:      "Stricter policy",
+			policy:    pagination.Policy{DefaultLimit: 10, MaxLimit: 50, OnPaginate: onPaginate},
+			wantLimit: 50,
+		},
+		{
+			name:      "Another callback",
+			policy:    pagination.Policy{DefaultLimit: 10, MaxLimit: 50, OnPaginate: func(ctx context.Context, info pagination.PaginateInfo) {}},
+			wantLimit: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.policy.FoundParams(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+		})
+	}
+}
+
+func TestHandlerBehindAMiddlewareWithAnotherPolicy(t *testing.T) {
+	middleware := pagination.Policy{DefaultLimit: 10}
+	route := pagination.Policy{DefaultLimit: 10, MaxLimit: 50, MaxResultWindow: 1000}
+	var limits []uint
+	handler := pagination.Handler(func(ctx context.Context, params pagination.Params) ([]string, error) {
+		limits = append(limits, params.Limit)
+		return nil, nil
+	}, pagination.WithPolicy(route))
+
+	for target, wantStatus := range map[string]int{
+		"/users?page[limit]=100000": http.StatusOK,
+		"/users?page[offset]=5000":  http.StatusBadRequest,
+	} {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		found, err := middleware.FindParams(req)
+		assert.NoError(t, err)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req.WithContext(middleware.WithParams(req.Context(), found, err)))
+		assert.Equal(t, wantStatus, rec.Code, target)
+	}
+	// Only the first request reaches the fetch, with the limit of the route
+	assert.Equal(t, []uint{50}, limits)
+}
@@ -0,0 +1,141 @@
+// Package gatewaypagination translates the pagination params of the REST
+// callers on the grpc-gateway routes into the page_size and page_token fields
+// of the proto requests, and builds the links of the responses back from
+// their next_page_token, so the REST callers keep paginating like on the rest
+// of the endpoints
+package gatewaypagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+const (
+	// ParamPageSize is the query param of the page_size field of the proto
+	// requests on the gateway routes
+	ParamPageSize = "page_size"
+	// ParamPageToken is the query param of the page_token field of the proto
+	// requests on the gateway routes
+	ParamPageToken = "page_token"
+)
+
+// restParams are the params of the REST callers replaced by the proto fields
+var restParams = []string{pagination.ParamPageLimit, pagination.ParamPageOffset, pagination.ParamPageNumber, pagination.ParamPageSize}
+
+// Middleware will wrap the given gateway handler, usually the runtime.ServeMux
+// of the list routes, translating the page[limit] and page[offset] (or
+// page[number] and page[size]) params of the request, found with the given
+// policy, into the page_size and page_token params the gateway maps into the
+// proto request. The page token is the synthetic cursor of the offset, see
+// pagination.CursorOffset for reading it on the service. The requests already
+// carrying a page_size or page_token are served as they are.
+//
+// The JSON responses carrying a next_page_token (nextPageToken with the
+// default marshaler) get the links of the page, like the rest of the
+// endpoints, on a links field and the Link header, the request is refused
+// with a 400 when its params can't be found or its path can't be used on
+// the links, see pagination.SanitizeBaseURL
+func Middleware(policy pagination.Policy, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		if query.Has(ParamPageSize) || query.Has(ParamPageToken) {
+			next.ServeHTTP(wr, req)
+			return
+		}
+		params, err := policy.FindParams(req)
+		if err != nil {
+			http.Error(wr, err.Error(), http.StatusBadRequest)
+			return
+		}
+		baseURL, err := pagination.SanitizeBaseURL(req.URL.Path)
+		if err != nil {
+			http.Error(wr, err.Error(), http.StatusBadRequest)
+			return
+		}
+		for _, name := range restParams {
+			query.Del(name)
+		}
+		query.Set(ParamPageSize, strconv.FormatUint(uint64(params.Limit), 10))
+		if params.Offset > 0 {
+			query.Set(ParamPageToken, pagination.OffsetCursor(policy.Tokens, params.Offset))
+		}
+		proxied := req.Clone(req.Context())
+		proxied.URL.RawQuery = query.Encode()
+		proxied.RequestURI = proxied.URL.RequestURI()
+
+		rec := &recorder{header: make(http.Header), status: http.StatusOK}
+		next.ServeHTTP(rec, proxied)
+		rec.flush(wr, links(rec, baseURL, params))
+	})
+}
+
+// links function will build the links of the recorded response, it answers
+// back nil when it is not a JSON object of a successful response
+func links(rec *recorder, baseURL string, params pagination.Params) map[string]json.RawMessage {
+	if rec.status != http.StatusOK {
+		return nil
+	}
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(rec.body.Bytes(), &body); err != nil {
+		return nil
+	}
+	var nextPageToken string
+	for _, name := range []string{"nextPageToken", "next_page_token"} {
+		if raw, ok := body[name]; ok {
+			json.Unmarshal(raw, &nextPageToken)
+			break
+		}
+	}
+	dataSize := 0
+	if nextPageToken != "" {
+		dataSize = int(params.Limit) + 1
+	}
+	links := pagination.NewLinkTemplate(baseURL, params).Links(params, dataSize)
+	rec.header.Set(pagination.HeaderLink, links.Header())
+	body["links"], _ = json.Marshal(links)
+	return body
+}
+
+// recorder type records the response of the gateway so the links can be
+// added to it
+type recorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+// Header method will answer back the headers of the recorded response
+func (r *recorder) Header() http.Header {
+	return r.header
+}
+
+// WriteHeader method will record the status of the response
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+}
+
+// Write method will record the body of the response
+func (r *recorder) Write(b []byte) (int, error) {
+	return r.body.Write(b)
+}
+
+// flush method will write the recorded response, with the given body instead
+// of the recorded one when it is not nil
+func (r *recorder) flush(wr http.ResponseWriter, body map[string]json.RawMessage) {
+	b := r.body.Bytes()
+	if body != nil {
+		if encoded, err := json.Marshal(body); err == nil {
+			b = encoded
+			r.header.Del("Content-Length")
+		}
+	}
+	for name, values := range r.header {
+		wr.Header()[name] = values
+	}
+	wr.WriteHeader(r.status)
+	wr.Write(b)
+}
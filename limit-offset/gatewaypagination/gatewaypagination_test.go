@@ -0,0 +1,101 @@
+package gatewaypagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/gatewaypagination"
+	"github.com/stretchr/testify/assert"
+)
+
+// gateway answers back a handler serving the users like a gateway route of a
+// list method following the page_size and page_token fields
+func gateway(t *testing.T, total uint) http.Handler {
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		query := req.URL.Query()
+		size, _ := strconv.ParseUint(query.Get("page_size"), 10, 32)
+		var offset uint
+		if token := query.Get("page_token"); token != "" {
+			var err error
+			offset, err = pagination.CursorOffset(nil, token)
+			assert.NoError(t, err)
+		}
+		users := []string{}
+		for i := offset; i < total && i < offset+uint(size); i++ {
+			users = append(users, "user"+strconv.FormatUint(uint64(i), 10))
+		}
+		res := map[string]interface{}{"users": users}
+		if offset+uint(size) < total {
+			res["nextPageToken"] = pagination.OffsetCursor(nil, offset+uint(size))
+		}
+		wr.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(wr).Encode(res)
+	})
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantUsers []string
+		wantLinks pagination.Links
+		wantLink  string
+	}{
+		{
+			name:      "First page",
+			url:       "/v1/users?page[limit]=2",
+			wantUsers: []string{"user0", "user1"},
+			wantLinks: pagination.Links{
+				First: "/v1/users?page[limit]=2&page[offset]=0",
+				Next:  "/v1/users?page[limit]=2&page[offset]=2",
+			},
+			wantLink: `</v1/users?page[limit]=2&page[offset]=2>; rel="next", </v1/users?page[limit]=2&page[offset]=0>; rel="first"`,
+		},
+		{
+			name:      "Last page",
+			url:       "/v1/users?page[limit]=2&page[offset]=4",
+			wantUsers: []string{"user4"},
+			wantLinks: pagination.Links{
+				First: "/v1/users?page[limit]=2&page[offset]=0",
+				Prev:  "/v1/users?page[limit]=2&page[offset]=2",
+			},
+			wantLink: `</v1/users?page[limit]=2&page[offset]=2>; rel="prev", </v1/users?page[limit]=2&page[offset]=0>; rel="first"`,
+		},
+	}
+
+	handler := gatewaypagination.Middleware(pagination.Policy{DefaultLimit: 10}, gateway(t, 5))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var body struct {
+				Users []string         `json:"users"`
+				Links pagination.Links `json:"links"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Equal(t, tt.wantUsers, body.Users)
+			assert.Equal(t, tt.wantLinks, body.Links)
+			assert.Equal(t, tt.wantLink, rec.Header().Get(pagination.HeaderLink))
+		})
+	}
+}
+
+func TestMiddlewareProtoFields(t *testing.T) {
+	handler := gatewaypagination.Middleware(pagination.Policy{DefaultLimit: 10}, gateway(t, 5))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users?page_size=1", nil))
+	assert.JSONEq(t, `{"users":["user0"],"nextPageToken":"`+pagination.OffsetCursor(nil, 1)+`"}`, rec.Body.String())
+	assert.Empty(t, rec.Header().Get(pagination.HeaderLink))
+}
+
+func TestMiddlewareInvalidParams(t *testing.T) {
+	handler := gatewaypagination.Middleware(pagination.Policy{DefaultLimit: 10, FilterSchema: pagination.FilterSchema{"age": {Type: pagination.FilterInt}}}, gateway(t, 5))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/users?filter[age]=old", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
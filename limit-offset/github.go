@@ -0,0 +1,102 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// GitHubParamPage is the page number param used by the GitHub preset, the
+	// first page is 1
+	GitHubParamPage = "page"
+	// GitHubParamPerPage is the page size param used by the GitHub preset
+	GitHubParamPerPage = "per_page"
+	// HeaderLink is the header carrying the links with the GitHub preset
+	HeaderLink = "Link"
+	// HeaderTotalCount is the header carrying the total count of items with
+	// the GitHub preset
+	HeaderTotalCount = "X-Total-Count"
+)
+
+// githubParamNames are the param names used on the links of the GitHub preset
+var githubParamNames = paramNames{
+	limit:   ParamPageLimit,
+	offset:  ParamPageOffset,
+	sort:    ParamSortBy,
+	orderBy: ParamOrderBy,
+	seed:    ParamSeed,
+	number:  GitHubParamPage,
+	size:    GitHubParamPerPage,
+}
+
+// FindGitHubParams will find the params on the request using the GitHub
+// conventions, page and per_page, applying the rules of the given policy. The
+// params always use the page number profile, see PaginateGitHub
+func FindGitHubParams(req *http.Request, policy Policy) (Params, error) {
+	var params Params
+	policy = policy.preset()
+	rawQuery, err := policy.applyDuplicateParams(req.URL.RawQuery, GitHubParamPage, GitHubParamPerPage)
+	if err == nil {
+		rawQuery = aliasQuery(rawQuery,
+			ParamPageNumber, GitHubParamPage,
+			ParamPageSize, GitHubParamPerPage,
+		)
+		err = policy.findParamsInto(req, rawQuery, &params)
+	}
+	params.Profile = PageProfileNumber
+	return params, policy.found(req, &params, err)
+}
+
+// PaginateGitHub will write the links of the page on the Link header and the
+// given total on the X-Total-Count header, like GitHub does, and answer back
+// the data of the page, which is the whole body of the response. The total is
+// the number of items of the whole query, which can be found with CachedCount
+func PaginateGitHub(wr http.ResponseWriter, data []interface{}, baseURL string, params Params, total uint) []interface{} {
+	params.pageServed(len(data))
+	links := buildLinksWithNames(baseURL, githubParamNames, params, len(data))
+	if total > 0 && params.Limit > 0 {
+		buf := acquireLinkBuffer()
+		sortName, sortValue := params.sortParam(githubParamNames)
+		last := (total - 1) / params.Limit * params.Limit
+		links.Last = buildLink(buf, baseURL, githubParamNames, params.Profile, params.Limit, last, params.offsets, params.defaults, sortName, sortValue, params.Seed, params.filterQuery())
+		releaseLinkBuffer(buf)
+	}
+	if header := links.Header(); header != "" {
+		wr.Header().Set(HeaderLink, header)
+	}
+	wr.Header().Set(HeaderTotalCount, strconv.FormatUint(uint64(total), 10))
+
+	body := buildData(data, params)
+	if body == nil {
+		body = []interface{}{}
+	}
+	params.paginated(context.Background(), Response{Data: body, Links: links})
+	return body
+}
+
+// Header method will format the links as the value of a Link header following
+// the RFC 8288, something like <url>; rel="next", <url>; rel="last". The empty
+// links are skipped
+func (l Links) Header() string {
+	var b strings.Builder
+	add := func(link, rel string) {
+		if link == "" {
+			return
+		}
+		if b.Len() > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('<')
+		b.WriteString(link)
+		b.WriteString(`>; rel="`)
+		b.WriteString(rel)
+		b.WriteByte('"')
+	}
+	add(l.Prev, "prev")
+	add(l.Next, "next")
+	add(l.Last, "last")
+	add(l.First, "first")
+	return b.String()
+}
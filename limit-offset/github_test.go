@@ -0,0 +1,87 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindGitHubParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 30}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantLimit  uint
+		wantOffset uint
+		wantErr    bool
+	}{
+		{
+			name:      "Only defaults",
+			url:       "app.quicka.co/api/sample",
+			wantLimit: 30,
+		},
+		{
+			name:       "Page and per page",
+			url:        "app.quicka.co/api/sample?page=3&per_page=10",
+			wantLimit:  10,
+			wantOffset: 20,
+		},
+		{
+			name:      "Only per page",
+			url:       "app.quicka.co/api/sample?per_page=50",
+			wantLimit: 50,
+		},
+		{
+			name:    "Invalid page",
+			url:     "app.quicka.co/api/sample?page=first",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			params, err := pagination.FindGitHubParams(req, policy)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+			assert.Equal(t, tt.wantOffset, params.Offset)
+			assert.Equal(t, pagination.PageProfileNumber, params.Profile)
+		})
+	}
+}
+
+func TestPaginateGitHub(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page=2&per_page=2", nil)
+	assert.Nil(t, err)
+	params, err := pagination.FindGitHubParams(req, pagination.Policy{DefaultLimit: 30})
+	assert.Nil(t, err)
+
+	wr := httptest.NewRecorder()
+	body := pagination.PaginateGitHub(wr, []interface{}{"c", "d", "e"}, "/users", params, 7)
+	assert.Equal(t, []interface{}{"c", "d"}, body)
+	assert.Equal(t, "7", wr.Header().Get(pagination.HeaderTotalCount))
+	assert.Equal(t, `</users?page=1&per_page=2>; rel="prev", `+
+		`</users?page=3&per_page=2>; rel="next", `+
+		`</users?page=4&per_page=2>; rel="last", `+
+		`</users?page=1&per_page=2>; rel="first"`, wr.Header().Get(pagination.HeaderLink))
+
+	encoded, err := json.Marshal(pagination.PaginateGitHub(httptest.NewRecorder(), nil, "/users", params, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(encoded))
+}
+
+func TestLinksHeader(t *testing.T) {
+	assert.Equal(t, "", pagination.Links{}.Header())
+	assert.Equal(t, `</users?page=2>; rel="next"`, pagination.Links{Next: "/users?page=2"}.Header())
+}
@@ -0,0 +1,71 @@
+// Package gormfilter converts the pagination filters into GORM clause
+// expressions, so the GORM users can apply the filters of the request without
+// building raw SQL
+package gormfilter
+
+import (
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Expressions will build the GORM expressions for the given filters, like
+// Filters.Where does the columns map works as an allowlist mapping the filter
+// fields into the columns, the filters of fields that aren't mapped are
+// ignored. The values coerced by a FilterSchema are used when present
+func Expressions(filters pagination.Filters, columns map[string]string) []clause.Expression {
+	var exprs []clause.Expression
+	for _, filter := range filters {
+		name, ok := columns[filter.Field]
+		if !ok {
+			continue
+		}
+		column := clause.Column{Name: name}
+		value := arg(filter, 0, filter.Value)
+		switch filter.Operator {
+		case pagination.OpEq:
+			exprs = append(exprs, clause.Eq{Column: column, Value: value})
+		case pagination.OpNe:
+			exprs = append(exprs, clause.Neq{Column: column, Value: value})
+		case pagination.OpGt:
+			exprs = append(exprs, clause.Gt{Column: column, Value: value})
+		case pagination.OpGte:
+			exprs = append(exprs, clause.Gte{Column: column, Value: value})
+		case pagination.OpLt:
+			exprs = append(exprs, clause.Lt{Column: column, Value: value})
+		case pagination.OpLte:
+			exprs = append(exprs, clause.Lte{Column: column, Value: value})
+		case pagination.OpLike:
+			exprs = append(exprs, clause.Like{Column: column, Value: value})
+		case pagination.OpIn:
+			values := make([]interface{}, 0, len(filter.Values))
+			for i, v := range filter.Values {
+				values = append(values, arg(filter, i, v))
+			}
+			exprs = append(exprs, clause.IN{Column: column, Values: values})
+		}
+	}
+	return exprs
+}
+
+// Scope will build a GORM scope applying the given filters
+//
+//	db.Scopes(gormfilter.Scope(params.Filters, columns)).Find(&users)
+func Scope(filters pagination.Filters, columns map[string]string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		exprs := Expressions(filters, columns)
+		if len(exprs) == 0 {
+			return db
+		}
+		return db.Clauses(clause.Where{Exprs: exprs})
+	}
+}
+
+// arg function will return the coerced value on the given position when the
+// filter was coerced, otherwise the raw value
+func arg(filter pagination.Filter, i int, raw string) interface{} {
+	if i < len(filter.Parsed) {
+		return filter.Parsed[i]
+	}
+	return raw
+}
@@ -0,0 +1,59 @@
+package gormfilter_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/gormfilter"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm/clause"
+)
+
+func TestExpressions(t *testing.T) {
+	columns := map[string]string{
+		"status":     "status",
+		"age":        "age",
+		"name":       "name",
+		"created_at": "users.created_at",
+	}
+
+	tests := []struct {
+		name    string
+		filters pagination.Filters
+		want    []clause.Expression
+	}{
+		{
+			name: "Without filters",
+		},
+		{
+			name: "All the operators",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+				{Field: "status", Operator: pagination.OpNe, Value: "banned"},
+				{Field: "age", Operator: pagination.OpGt, Value: "18", Parsed: []interface{}{int64(18)}},
+				{Field: "age", Operator: pagination.OpGte, Value: "18"},
+				{Field: "age", Operator: pagination.OpLt, Value: "65"},
+				{Field: "age", Operator: pagination.OpLte, Value: "65"},
+				{Field: "name", Operator: pagination.OpLike, Value: "jo%"},
+				{Field: "created_at", Operator: pagination.OpIn, Value: "a,b", Values: []string{"a", "b"}},
+				{Field: "password", Operator: pagination.OpEq, Value: "secret"},
+			},
+			want: []clause.Expression{
+				clause.Eq{Column: clause.Column{Name: "status"}, Value: "active"},
+				clause.Neq{Column: clause.Column{Name: "status"}, Value: "banned"},
+				clause.Gt{Column: clause.Column{Name: "age"}, Value: int64(18)},
+				clause.Gte{Column: clause.Column{Name: "age"}, Value: "18"},
+				clause.Lt{Column: clause.Column{Name: "age"}, Value: "65"},
+				clause.Lte{Column: clause.Column{Name: "age"}, Value: "65"},
+				clause.Like{Column: clause.Column{Name: "name"}, Value: "jo%"},
+				clause.IN{Column: clause.Column{Name: "users.created_at"}, Values: []interface{}{"a", "b"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, gormfilter.Expressions(tt.filters, columns))
+		})
+	}
+}
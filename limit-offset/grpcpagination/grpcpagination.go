@@ -0,0 +1,43 @@
+// Package grpcpagination provides the helpers for serving the paginated
+// collections on gRPC services, like streaming the whole collection walking
+// its pages
+package grpcpagination
+
+import (
+	"context"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// DefaultStreamPageSize is the size of the pages fetched by Stream when the
+// params have no limit
+const DefaultStreamPageSize = 100
+
+// Sender type is the server side of a server-streaming method, like the
+// grpc.ServerStreamingServer generated for the Stream variants of the list
+// methods
+type Sender[T any] interface {
+	Send(T) error
+	Context() context.Context
+}
+
+// Stream will implement a server-streaming method on top of the given
+// paginated fetch function, walking the pages from the offset of the given
+// params and sending their items one by one until a page comes with less
+// items than its limit. Only a page is kept in memory, as Send blocks while
+// the flow control window of the stream is full the pages are fetched at the
+// pace of the client, and the walk stops as soon as the stream is cancelled
+func Stream[T any](stream Sender[T], params pagination.Params, fetch func(ctx context.Context, params pagination.Params) ([]T, error)) error {
+	if params.Limit == 0 {
+		params.Limit = DefaultStreamPageSize
+	}
+	for item, err := range pagination.Walk(stream.Context(), params, fetch) {
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package grpcpagination_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/grpcpagination"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+var _ grpcpagination.Sender[*wrapperspb.StringValue] = grpc.ServerStreamingServer[wrapperspb.StringValue](nil)
+
+// sender type records the items sent on a stream
+type sender struct {
+	ctx  context.Context
+	sent []int
+	fail error
+}
+
+func (s *sender) Send(item int) error {
+	if s.fail != nil {
+		return s.fail
+	}
+	s.sent = append(s.sent, item)
+	return nil
+}
+
+func (s *sender) Context() context.Context {
+	return s.ctx
+}
+
+// fetchInts answers back a fetch function paginating the numbers from 0 to
+// total, recording the params of every call
+func fetchInts(total int, calls *[]pagination.Params) func(ctx context.Context, params pagination.Params) ([]int, error) {
+	return func(ctx context.Context, params pagination.Params) ([]int, error) {
+		*calls = append(*calls, params)
+		items := []int{}
+		for i := int(params.Offset); i < total && i < int(params.Offset+params.Limit); i++ {
+			items = append(items, i)
+		}
+		return items, nil
+	}
+}
+
+func TestStream(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		params    pagination.Params
+		wantSent  []int
+		wantCalls []pagination.Params
+	}{
+		{
+			name:      "Partial last page",
+			total:     5,
+			params:    pagination.Params{Limit: 2},
+			wantSent:  []int{0, 1, 2, 3, 4},
+			wantCalls: []pagination.Params{{Limit: 2}, {Limit: 2, Offset: 2}, {Limit: 2, Offset: 4}},
+		},
+		{
+			name:      "Empty last page",
+			total:     4,
+			params:    pagination.Params{Limit: 2, Offset: 2},
+			wantSent:  []int{2, 3},
+			wantCalls: []pagination.Params{{Limit: 2, Offset: 2}, {Limit: 2, Offset: 4}},
+		},
+		{
+			name:      "Default page size",
+			total:     3,
+			wantSent:  []int{0, 1, 2},
+			wantCalls: []pagination.Params{{Limit: grpcpagination.DefaultStreamPageSize}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []pagination.Params
+			stream := &sender{ctx: context.Background()}
+			assert.NoError(t, grpcpagination.Stream[int](stream, tt.params, fetchInts(tt.total, &calls)))
+			assert.Equal(t, tt.wantSent, stream.sent)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestStreamStops(t *testing.T) {
+	var calls []pagination.Params
+	failure := errors.New("client gone")
+	err := grpcpagination.Stream[int](&sender{ctx: context.Background(), fail: failure}, pagination.Params{Limit: 2}, fetchInts(10, &calls))
+	assert.ErrorIs(t, err, failure)
+	assert.Len(t, calls, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = nil
+	err = grpcpagination.Stream[int](&sender{ctx: ctx}, pagination.Params{Limit: 2}, fetchInts(10, &calls))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, calls)
+}
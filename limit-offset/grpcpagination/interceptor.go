@@ -0,0 +1,63 @@
+package grpcpagination
+
+import (
+	"context"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// UnaryServerInterceptor will build an interceptor applying the given policy
+// to the page_size and page_token fields of every request, see
+// paginationv1.NormalizeRequest, so the methods can't forget the checks. The
+// requests with a negative page_size or a page_token that can't be accepted
+// are refused with INVALID_ARGUMENT. The limits of the caller are applied,
+// see pagination.Policy.ForContext
+func UnaryServerInterceptor(policy pagination.Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := normalize(req, policy.ForContext(ctx)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor will build an interceptor applying the given policy
+// to every message received by the streaming methods like
+// UnaryServerInterceptor does
+func StreamServerInterceptor(policy pagination.Policy) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &normalizingStream{ServerStream: ss, policy: policy.ForContext(ss.Context())})
+	}
+}
+
+// normalizingStream type applies the policy to the messages received
+type normalizingStream struct {
+	grpc.ServerStream
+	policy pagination.Policy
+}
+
+// RecvMsg method will receive the next message applying the policy to it
+func (s *normalizingStream) RecvMsg(m any) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return normalize(m, s.policy)
+}
+
+// normalize function will apply the policy to the given message, the errors
+// are answered back as INVALID_ARGUMENT
+func normalize(m any, policy pagination.Policy) error {
+	msg, ok := m.(proto.Message)
+	if !ok {
+		return nil
+	}
+	if err := paginationv1.NormalizeRequest(msg, policy); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package grpcpagination_test
+
+import (
+	"context"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/grpcpagination"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var policy = pagination.Policy{DefaultLimit: 20, MaxLimit: 100}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	interceptor := grpcpagination.UnaryServerInterceptor(policy)
+	var received *paginationv1.PageRequest
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = req.(*paginationv1.PageRequest)
+		return &paginationv1.PageResponse{}, nil
+	}
+
+	_, err := interceptor(context.Background(), &paginationv1.PageRequest{PageSize: 500}, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(100), received.GetPageSize())
+
+	received = nil
+	_, err = interceptor(context.Background(), &paginationv1.PageRequest{PageToken: "forged"}, &grpc.UnaryServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Nil(t, received)
+}
+
+func TestUnaryServerInterceptorClientLimits(t *testing.T) {
+	policy := policy
+	policy.Clients = pagination.TierLimits(map[string]pagination.ClientLimits{"partner": {MaxLimit: 1000}})
+	interceptor := grpcpagination.UnaryServerInterceptor(policy)
+	var received *paginationv1.PageRequest
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = req.(*paginationv1.PageRequest)
+		return &paginationv1.PageResponse{}, nil
+	}
+
+	ctx := pagination.WithClientTier(context.Background(), "partner")
+	_, err := interceptor(ctx, &paginationv1.PageRequest{PageSize: 500}, &grpc.UnaryServerInfo{}, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(500), received.GetPageSize())
+}
+
+// serverStream type is a server stream receiving the given request
+type serverStream struct {
+	grpc.ServerStream
+	req *paginationv1.PageRequest
+}
+
+func (s *serverStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *serverStream) RecvMsg(m any) error {
+	m.(*paginationv1.PageRequest).PageSize = s.req.GetPageSize()
+	return nil
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	interceptor := grpcpagination.StreamServerInterceptor(policy)
+	handler := func(srv any, stream grpc.ServerStream) error {
+		req := &paginationv1.PageRequest{}
+		if err := stream.RecvMsg(req); err != nil {
+			return err
+		}
+		assert.Equal(t, int32(20), req.GetPageSize())
+		return nil
+	}
+	assert.NoError(t, interceptor(nil, &serverStream{req: &paginationv1.PageRequest{}}, &grpc.StreamServerInfo{}, handler))
+
+	err := interceptor(nil, &serverStream{req: &paginationv1.PageRequest{PageSize: -5}}, &grpc.StreamServerInfo{}, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,128 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// HandlerOption type defines the options of the Handler
+type HandlerOption func(*handlerConfig)
+
+// handlerConfig type keeps the options of a Handler
+type handlerConfig struct {
+	policy  Policy
+	baseURL string
+	count   func(ctx context.Context, params Params) (uint, error)
+	onError func(wr http.ResponseWriter, req *http.Request, status int, err error)
+}
+
+// WithPolicy option will find the params with the given policy, by default
+// the params are found with a DefaultLimit of 10
+func WithPolicy(policy Policy) HandlerOption {
+	return func(c *handlerConfig) {
+		c.policy = policy
+	}
+}
+
+// WithBaseURL option will build the links with the given base URL, by default
+// the path of the request is used, sanitized with SanitizeBaseURL
+func WithBaseURL(baseURL string) HandlerOption {
+	return func(c *handlerConfig) {
+		c.baseURL = baseURL
+	}
+}
+
+// WithCount option will build the pages with the total answered back by the
+// given count function, see PaginateCount. It is required by the policies with
+// ExactCount or DisableOverFetch, as the fetch function queries the limit as
+// it is and the last page can't be found with the extra item
+func WithCount(count func(ctx context.Context, params Params) (uint, error)) HandlerOption {
+	return func(c *handlerConfig) {
+		c.count = count
+	}
+}
+
+// WithErrorHandler option will write the errors with the given function, the
+// status is 400 for the params refused and 500 for the fetch errors. By
+// default a JSON body like {"error": "..."} is written, hiding the fetch errors
+func WithErrorHandler(onError func(wr http.ResponseWriter, req *http.Request, status int, err error)) HandlerOption {
+	return func(c *handlerConfig) {
+		c.onError = onError
+	}
+}
+
+// Handler will build the http handler of a whole list endpoint, it finds the
+// params on the request, or reuses the ones a middleware found with the same
+// policy (see Policy.WithParams), calls the fetch function with them, builds
+// the paginated response and writes it as JSON (see WriteJSON). The fetch
+// function must query one more item than the limit, like the Query method
+// does, unless the policy has ExactCount or DisableOverFetch, then the pages
+// are built with the total of WithCount and it panics when the option is
+// missing
+//
+//	http.Handle("/users", pagination.Handler(func(ctx context.Context, params pagination.Params) ([]User, error) {
+//	  return store.ListUsers(ctx, params)
+//	}, pagination.WithPolicy(usersPolicy)))
+func Handler[T any](fetch func(ctx context.Context, params Params) ([]T, error), opts ...HandlerOption) http.Handler {
+	config := handlerConfig{
+		policy:  Policy{DefaultLimit: 10},
+		onError: writeError,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	exact := config.policy.ExactCount || config.policy.DisableOverFetch
+	if exact && config.count == nil {
+		panic("pagination: the Handler needs WithCount for the policies with ExactCount or DisableOverFetch")
+	}
+	return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		params, err := config.policy.FoundParams(req)
+		if err != nil {
+			config.onError(wr, req, http.StatusBadRequest, err)
+			return
+		}
+		baseURL := config.baseURL
+		if baseURL == "" {
+			if baseURL, err = SanitizeBaseURL(req.URL.Path); err != nil {
+				config.onError(wr, req, http.StatusBadRequest, err)
+				return
+			}
+		}
+		items, err := fetch(req.Context(), params)
+		if err != nil {
+			config.onError(wr, req, http.StatusInternalServerError, err)
+			return
+		}
+		data := make([]interface{}, len(items))
+		for i, item := range items {
+			data[i] = item
+		}
+		var response Response
+		if exact {
+			response, err = PaginateCount(req.Context(), data, baseURL, params, func(ctx context.Context) (uint, error) {
+				return config.count(ctx, params)
+			})
+			if err != nil {
+				config.onError(wr, req, http.StatusInternalServerError, err)
+				return
+			}
+		} else {
+			response = PaginateContext(req.Context(), data, baseURL, params)
+		}
+		config.policy.SetDeepOffsetHeaders(wr, params)
+		WriteJSON(wr, req, response)
+	})
+}
+
+// writeError function is the default error handler of the Handler, the fetch
+// errors aren't answered back as they may leak internal details
+func writeError(wr http.ResponseWriter, req *http.Request, status int, err error) {
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		message = http.StatusText(status)
+	}
+	wr.Header().Set("Content-Type", "application/json")
+	wr.WriteHeader(status)
+	json.NewEncoder(wr).Encode(map[string]string{"error": message})
+}
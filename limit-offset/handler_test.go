@@ -0,0 +1,116 @@
+package pagination_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	users := []string{"a", "b", "c", "d"}
+	fetch := func(ctx context.Context, params pagination.Params) ([]string, error) {
+		end := params.Offset + params.Limit + 1
+		if end > uint(len(users)) {
+			end = uint(len(users))
+		}
+		return users[params.Offset:end], nil
+	}
+	failing := func(ctx context.Context, params pagination.Params) ([]string, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	tests := []struct {
+		name       string
+		handler    http.Handler
+		url        string
+		wantStatus int
+		wantBody   string
+	}{
+		{
+			name:       "Paginated response",
+			handler:    pagination.Handler(fetch, pagination.WithPolicy(pagination.Policy{DefaultLimit: 2})),
+			url:        "/users",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data": ["a", "b"], "links": {"first": "/users?page[limit]=2&page[offset]=0", "next": "/users?page[limit]=2&page[offset]=2"}}`,
+		},
+		{
+			name:       "Custom base URL",
+			handler:    pagination.Handler(fetch, pagination.WithBaseURL("https://api.quicka.co/users")),
+			url:        "/users?page[limit]=4",
+			wantStatus: http.StatusOK,
+			wantBody:   `{"data": ["a", "b", "c", "d"], "links": {"first": "https://api.quicka.co/users?page[limit]=4&page[offset]=0"}}`,
+		},
+		{
+			name:       "Params refused",
+			handler:    pagination.Handler(fetch),
+			url:        "/users?page[limit]=many",
+			wantStatus: http.StatusBadRequest,
+			wantBody:   `{"error": "strconv.ParseUint: parsing \"many\": invalid syntax"}`,
+		},
+		{
+			name:       "Fetch error hidden",
+			handler:    pagination.Handler(failing),
+			url:        "/users",
+			wantStatus: http.StatusInternalServerError,
+			wantBody:   `{"error": "Internal Server Error"}`,
+		},
+		{
+			name: "Custom error handler",
+			handler: pagination.Handler(failing, pagination.WithErrorHandler(func(wr http.ResponseWriter, req *http.Request, status int, err error) {
+				http.Error(wr, `{"message": "`+err.Error()+`"}`, http.StatusServiceUnavailable)
+			})),
+			url:        "/users",
+			wantStatus: http.StatusServiceUnavailable,
+			wantBody:   `{"message": "connection refused"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.url, nil))
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandlerWithCount(t *testing.T) {
+	users := []string{"a", "b", "c", "d"}
+	// The fetch queries the limit as it is, like params.Query does for these
+	// policies
+	fetch := func(ctx context.Context, params pagination.Params) ([]string, error) {
+		end := params.Offset + params.EffectiveLimit()
+		if end > uint(len(users)) {
+			end = uint(len(users))
+		}
+		return users[params.Offset:end], nil
+	}
+	count := func(ctx context.Context, params pagination.Params) (uint, error) {
+		return uint(len(users)), nil
+	}
+
+	for _, policy := range []pagination.Policy{
+		{DefaultLimit: 2, ExactCount: true},
+		{DefaultLimit: 2, DisableOverFetch: true},
+	} {
+		assert.Panics(t, func() { pagination.Handler(fetch, pagination.WithPolicy(policy)) })
+
+		rec := httptest.NewRecorder()
+		pagination.Handler(fetch, pagination.WithPolicy(policy), pagination.WithCount(count)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"data": ["a", "b"], "links": {"first": "/users?page[limit]=2&page[offset]=0", "next": "/users?page[limit]=2&page[offset]=2", "last": "/users?page[limit]=2&page[offset]=2"}, "meta": {"total": 4}}`, rec.Body.String())
+	}
+
+	failing := func(ctx context.Context, params pagination.Params) (uint, error) {
+		return 0, errors.New("connection refused")
+	}
+	rec := httptest.NewRecorder()
+	pagination.Handler(fetch, pagination.WithPolicy(pagination.Policy{ExactCount: true}), pagination.WithCount(failing)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users", nil))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
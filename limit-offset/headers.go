@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+const (
+	// HeaderPage is the header carrying the page number, starting on 1
+	HeaderPage = "X-Page"
+	// HeaderPerPage is the header carrying the page size
+	HeaderPerPage = "X-Per-Page"
+	// HeaderTotalPages is the header carrying the number of pages
+	HeaderTotalPages = "X-Total-Pages"
+	// HeaderExposeHeaders is the CORS header listing the headers the browser
+	// scripts can read
+	HeaderExposeHeaders = "Access-Control-Expose-Headers"
+)
+
+// pageHeaders are the headers written by WriteHeaders
+var pageHeaders = []string{HeaderTotalCount, HeaderPage, HeaderPerPage, HeaderTotalPages}
+
+// WriteHeaders will write the conventional pagination headers, X-Total-Count,
+// X-Page, X-Per-Page and X-Total-Pages, for the given params and total, as a
+// lot of data grid libraries read the totals from the headers only. They are
+// also added to the Access-Control-Expose-Headers, otherwise the browsers
+// hide them to the scripts of other origins, merged with the ones already
+// exposed. It must be called before writing the body
+func WriteHeaders(wr http.ResponseWriter, params Params, total uint) {
+	header := wr.Header()
+	header.Set(HeaderTotalCount, strconv.FormatUint(uint64(total), 10))
+	header.Set(HeaderPage, strconv.FormatUint(uint64(params.CurrentPage()), 10))
+	header.Set(HeaderPerPage, strconv.FormatUint(uint64(params.Limit), 10))
+	header.Set(HeaderTotalPages, strconv.FormatUint(uint64(params.TotalPages(total)), 10))
+	exposeHeaders(header, pageHeaders...)
+}
+
+// exposeHeaders function will merge the given names into the
+// Access-Control-Expose-Headers, keeping a single comma separated value
+// without duplicates, so writing the headers twice doesn't repeat them
+func exposeHeaders(header http.Header, names ...string) {
+	var exposed []string
+	seen := make(map[string]bool)
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			return
+		}
+		seen[strings.ToLower(name)] = true
+		exposed = append(exposed, name)
+	}
+	for _, value := range header.Values(HeaderExposeHeaders) {
+		for _, name := range strings.Split(value, ",") {
+			add(name)
+		}
+	}
+	for _, name := range names {
+		add(name)
+	}
+	header.Set(HeaderExposeHeaders, strings.Join(exposed, ", "))
+}
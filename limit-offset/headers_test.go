@@ -0,0 +1,63 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteHeaders(t *testing.T) {
+	tests := []struct {
+		name   string
+		params pagination.Params
+		total  uint
+		want   http.Header
+	}{
+		{
+			name:   "Middle page",
+			params: pagination.Params{Limit: 20, Offset: 20},
+			total:  213,
+			want: http.Header{
+				"X-Total-Count":                 {"213"},
+				"X-Page":                        {"2"},
+				"X-Per-Page":                    {"20"},
+				"X-Total-Pages":                 {"11"},
+				"Access-Control-Expose-Headers": {"X-Total-Count, X-Page, X-Per-Page, X-Total-Pages"},
+			},
+		},
+		{
+			name:   "Zero total",
+			params: pagination.Params{Limit: 20},
+			want: http.Header{
+				"X-Total-Count":                 {"0"},
+				"X-Page":                        {"1"},
+				"X-Per-Page":                    {"20"},
+				"X-Total-Pages":                 {"0"},
+				"Access-Control-Expose-Headers": {"X-Total-Count, X-Page, X-Per-Page, X-Total-Pages"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			pagination.WriteHeaders(rec, tt.params, tt.total)
+			assert.Equal(t, tt.want, rec.Header())
+		})
+	}
+}
+
+func TestWriteHeadersKeepsExposedHeaders(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set("Access-Control-Expose-Headers", "ETag")
+	rec.Header().Add("Access-Control-Expose-Headers", "Link, x-page")
+	pagination.WriteHeaders(rec, pagination.Params{Limit: 10}, 5)
+	assert.Equal(t, []string{"ETag, Link, x-page, X-Total-Count, X-Per-Page, X-Total-Pages"}, rec.Header().Values("Access-Control-Expose-Headers"))
+
+	// Writing the headers again doesn't repeat them
+	pagination.WriteHeaders(rec, pagination.Params{Limit: 10}, 5)
+	assert.Equal(t, []string{"ETag, Link, x-page, X-Total-Count, X-Per-Page, X-Total-Pages"}, rec.Header().Values("Access-Control-Expose-Headers"))
+}
@@ -0,0 +1,53 @@
+package pagination
+
+import "encoding/json"
+
+// stableResponse type is the encoding of the responses with the StableJSON,
+// the data and the four links are always there
+type stableResponse struct {
+	Data  []interface{}          `json:"data"`
+	Links stableLinks            `json:"links"`
+	Meta  map[string]interface{} `json:"meta,omitempty"`
+}
+
+// stableLinks type is the encoding of the links with the StableJSON, the
+// missing links are null
+type stableLinks struct {
+	First *string `json:"first"`
+	Prev  *string `json:"prev"`
+	Next  *string `json:"next"`
+	Last  *string `json:"last"`
+}
+
+// MarshalJSON method will encode the response, with the StableJSON the empty
+// pages have an empty data array and the missing links are null, otherwise
+// both are omitted
+func (r Response) MarshalJSON() ([]byte, error) {
+	if !r.stable {
+		// The response type doesn't have the methods, so it is encoded as usual
+		type response Response
+		return json.Marshal(response(r))
+	}
+	data := r.Data
+	if data == nil {
+		data = []interface{}{}
+	}
+	return json.Marshal(stableResponse{
+		Data: data,
+		Links: stableLinks{
+			First: nullable(r.Links.First),
+			Prev:  nullable(r.Links.Prev),
+			Next:  nullable(r.Links.Next),
+			Last:  nullable(r.Links.Last),
+		},
+		Meta: r.Meta,
+	})
+}
+
+// nullable function will answer back nil for the empty links
+func nullable(link string) *string {
+	if link == "" {
+		return nil
+	}
+	return &link
+}
@@ -0,0 +1,47 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy pagination.Policy
+		data   []interface{}
+		want   string
+	}{
+		{
+			name:   "Empty page",
+			policy: pagination.Policy{DefaultLimit: 2},
+			want:   `{"links":{"first":"/users?page[limit]=2\u0026page[offset]=0"}}`,
+		},
+		{
+			name:   "Empty page with stable JSON",
+			policy: pagination.Policy{DefaultLimit: 2, StableJSON: true},
+			want:   `{"data":[],"links":{"first":"/users?page[limit]=2\u0026page[offset]=0","prev":null,"next":null,"last":null}}`,
+		},
+		{
+			name:   "Page with stable JSON",
+			policy: pagination.Policy{DefaultLimit: 2, StableJSON: true},
+			data:   []interface{}{1, 2, 3},
+			want:   `{"data":[1,2],"links":{"first":"/users?page[limit]=2\u0026page[offset]=0","prev":null,"next":"/users?page[limit]=2\u0026page[offset]=2","last":null}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+			params, err := tt.policy.FindParams(req)
+			assert.NoError(t, err)
+			b, err := json.Marshal(pagination.Paginate(tt.data, "/users", params))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(b))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ParamSearch is the value for the free-text search parameter on http request,
+// it can be changed with the SearchParam of the Policy
+const ParamSearch = "q"
+
+// ListParams type encapsulates everything a list endpoint needs from the http
+// request, the pagination, sort and filters found on the embedded Params and
+// the free-text search term. This is the type meant to be passed through the
+// service layers
+type ListParams struct {
+	Params
+	// Search is the free-text search term found on the search param
+	Search string
+
+	// searchParam keeps the name of the search param used on the links
+	searchParam string
+}
+
+// FindListParams will find the list params on the request applying the rules
+// of the given policy
+func FindListParams(req *http.Request, policy Policy) (ListParams, error) {
+	list := ListParams{searchParam: policy.SearchParam}
+	// The raw query is found once, as it may carry a page token
+	rawQuery, err := policy.rawQuery(req)
+	if err == nil {
+		err = policy.findParamsInto(req, rawQuery, &list.Params)
+	}
+	if err = policy.found(req, &list.Params, err); err != nil {
+		return list, err
+	}
+	list.Search = lookupParam(rawQuery, list.searchName())
+	return list, nil
+}
+
+// Paginate will build a new paginated response with the given values like the
+// Paginate function does, the links also keep the search term
+func (l ListParams) Paginate(data []interface{}, baseURL string) Response {
+	l.pageServed(len(data))
+	response := Response{
+		Data:   buildData(data, l.Params),
+		Links:  buildLinksWithQuery(baseURL, l.paramNames(), l.Params, l.query(), len(data)),
+		Meta:   l.Params.meta(),
+		stable: l.StableJSON,
+	}
+	l.Params.paginated(context.Background(), response)
+	return response
+}
+
+// query method will encode the filters and the search term for the links
+func (l ListParams) query() string {
+	query := l.filterQuery()
+	if l.Search == "" {
+		return query
+	}
+	if query != "" {
+		query += "&"
+	}
+	return query + l.searchName() + "=" + url.QueryEscape(l.Search)
+}
+
+// searchName method will return the name of the search param
+func (l ListParams) searchName() string {
+	if l.searchParam == "" {
+		return ParamSearch
+	}
+	return l.searchParam
+}
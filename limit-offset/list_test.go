@@ -0,0 +1,147 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindListParams(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		FilterSchema: pagination.FilterSchema{"status": {Type: pagination.FilterString}},
+	}
+
+	tests := []struct {
+		name        string
+		url         string
+		wantLimit   uint
+		wantOffset  uint
+		wantSort    []pagination.Sort
+		wantFilters pagination.Filters
+		wantSearch  string
+		wantErr     error
+	}{
+		{
+			name:      "Only defaults",
+			url:       "app.quicka.co/api/sample",
+			wantLimit: 10,
+		},
+		{
+			name:       "Pagination, sort, filters and search",
+			url:        "app.quicka.co/api/sample?page[limit]=5&page[offset]=10&sort=name.asc&filter[status]=active&q=john+doe",
+			wantLimit:  5,
+			wantOffset: 10,
+			wantSort:   []pagination.Sort{{Field: "name", Order: "asc"}},
+			wantFilters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active", Parsed: []interface{}{"active"}},
+			},
+			wantSearch: "john doe",
+		},
+		{
+			name:       "First search wins",
+			url:        "app.quicka.co/api/sample?q=first&q=second",
+			wantLimit:  10,
+			wantSearch: "first",
+		},
+		{
+			name:    "Filter not allowed by the policy",
+			url:     "app.quicka.co/api/sample?filter[password]=secret",
+			wantErr: pagination.ErrInvalidFilter,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			list, err := pagination.FindListParams(req, policy)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, list.Limit)
+			assert.Equal(t, tt.wantOffset, list.Offset)
+			assert.Equal(t, tt.wantSort, list.Sort)
+			assert.Equal(t, tt.wantFilters, list.Filters)
+			assert.Equal(t, tt.wantSearch, list.Search)
+		})
+	}
+}
+
+func TestListParamsPaginate(t *testing.T) {
+	list := pagination.ListParams{
+		Params: pagination.Params{
+			Limit:   2,
+			Offset:  2,
+			Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}},
+		},
+	}
+	data := []interface{}{"a", "b", "c"}
+
+	assert.Equal(t, pagination.Paginate(data, "/sample", list.Params), list.Paginate(data, "/sample"))
+}
+
+func TestListParamsSearchLinks(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   pagination.Policy
+		url      string
+		wantNext string
+	}{
+		{
+			name:     "Default search param",
+			policy:   pagination.Policy{DefaultLimit: 2},
+			url:      "app.quicka.co/api/sample?q=john+doe&filter[status]=active",
+			wantNext: "/sample?page[limit]=2&page[offset]=2&filter[status]=active&q=john+doe",
+		},
+		{
+			name:     "Configured search param",
+			policy:   pagination.Policy{DefaultLimit: 2, SearchParam: "search"},
+			url:      "app.quicka.co/api/sample?search=50%25+off&q=ignored",
+			wantNext: "/sample?page[limit]=2&page[offset]=2&search=50%25+off",
+		},
+		{
+			name:     "Without search",
+			policy:   pagination.Policy{DefaultLimit: 2},
+			url:      "app.quicka.co/api/sample",
+			wantNext: "/sample?page[limit]=2&page[offset]=2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			list, err := pagination.FindListParams(req, tt.policy)
+			assert.Nil(t, err)
+
+			response := list.Paginate([]interface{}{"a", "b", "c"}, "/sample")
+			assert.Equal(t, []interface{}{"a", "b"}, response.Data)
+			assert.Equal(t, tt.wantNext, response.Links.Next)
+		})
+	}
+}
+
+func TestFindListParamsWithTokens(t *testing.T) {
+	hook := &parseRecordingHook{}
+	policy := pagination.Policy{DefaultLimit: 2, Tokens: pagination.NewTokenCodec(tokenKey), Metrics: hook}
+	req, err := http.NewRequest(http.MethodGet, "/sample?q=john", nil)
+	assert.Nil(t, err)
+	list, err := pagination.FindListParams(req, policy)
+	assert.Nil(t, err)
+
+	next := list.Paginate([]interface{}{"a", "b", "c"}, "/sample").Links.Next
+	req, err = http.NewRequest(http.MethodGet, next, nil)
+	assert.Nil(t, err)
+	list, err = pagination.FindListParams(req, policy)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), list.Offset)
+	assert.Equal(t, "john", list.Search)
+	assert.Len(t, hook.events, 2)
+}
@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// String method will describe the sort field like name.asc
+func (s Sort) String() string {
+	if s.Order == "" {
+		return s.Field
+	}
+	return s.Field + "." + s.Order
+}
+
+// LogValue method will log the sort field like name.asc
+func (s Sort) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// String method will describe the params for the logs, something like
+// limit=20 offset=40 sort=name.asc,created_at.desc. The sort, seed and filters
+// are only added when there are any, the filters with the Redactions of the
+// policy applied
+func (p Params) String() string {
+	var b strings.Builder
+	b.WriteString("limit=")
+	b.WriteString(strconv.FormatUint(uint64(p.Limit), 10))
+	b.WriteString(" offset=")
+	b.WriteString(strconv.FormatUint(uint64(p.Offset), 10))
+	if len(p.Sort) > 0 {
+		b.WriteString(" sort=")
+		b.WriteString(p.sortString())
+	}
+	if p.Seed != "" {
+		b.WriteString(" seed=")
+		b.WriteString(p.Seed)
+	}
+	if len(p.Filters) > 0 {
+		b.WriteString(" filters=")
+		b.WriteString(redactFilters(p.redactions, p.Filters).Encode())
+	}
+	return b.String()
+}
+
+// LogValue method will log the params as a group with the same keys String
+// uses
+func (p Params) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.Uint64("limit", uint64(p.Limit)),
+		slog.Uint64("offset", uint64(p.Offset)),
+	}
+	if len(p.Sort) > 0 {
+		attrs = append(attrs, slog.String("sort", p.sortString()))
+	}
+	if p.Seed != "" {
+		attrs = append(attrs, slog.String("seed", p.Seed))
+	}
+	if len(p.Filters) > 0 {
+		attrs = append(attrs, slog.String("filters", redactFilters(p.redactions, p.Filters).Encode()))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// sortString method will join the sort fields like name.asc,created_at.desc
+func (p Params) sortString() string {
+	fields := make([]string, 0, len(p.Sort))
+	for _, s := range p.Sort {
+		fields = append(fields, s.String())
+	}
+	return strings.Join(fields, ",")
+}
+
+// String method will describe the links that aren't empty for the logs,
+// something like first=/users?... next=/users?...
+func (l Links) String() string {
+	var b strings.Builder
+	l.each(func(rel, link string) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(rel)
+		b.WriteByte('=')
+		b.WriteString(link)
+	})
+	return b.String()
+}
+
+// LogValue method will log the links that aren't empty as a group
+func (l Links) LogValue() slog.Value {
+	attrs := make([]slog.Attr, 0, 4)
+	l.each(func(rel, link string) {
+		attrs = append(attrs, slog.String(rel, link))
+	})
+	return slog.GroupValue(attrs...)
+}
+
+// each method will call the given function for every link that isn't empty
+func (l Links) each(fn func(rel, link string)) {
+	for _, link := range []struct{ rel, link string }{
+		{"first", l.First},
+		{"prev", l.Prev},
+		{"next", l.Next},
+		{"last", l.Last},
+	} {
+		if link.link != "" {
+			fn(link.rel, link.link)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package pagination_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsString(t *testing.T) {
+	tests := []struct {
+		name   string
+		params pagination.Params
+		want   string
+	}{
+		{
+			name:   "Only limit and offset",
+			params: pagination.Params{Limit: 20, Offset: 40},
+			want:   "limit=20 offset=40",
+		},
+		{
+			name: "Sort, seed and filters",
+			params: pagination.Params{
+				Limit:   20,
+				Sort:    []pagination.Sort{{Field: "name", Order: "asc"}, {Field: "created_at", Order: "desc"}},
+				Seed:    "42",
+				Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}},
+			},
+			want: "limit=20 offset=0 sort=name.asc,created_at.desc seed=42 filters=filter[status]=active",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.params.String())
+		})
+	}
+}
+
+func TestLinksString(t *testing.T) {
+	links := pagination.Links{First: "/users?page[limit]=2&page[offset]=0", Next: "/users?page[limit]=2&page[offset]=2"}
+	assert.Equal(t, "first=/users?page[limit]=2&page[offset]=0 next=/users?page[limit]=2&page[offset]=2", links.String())
+	assert.Equal(t, "", pagination.Links{}.String())
+	assert.Equal(t, "name", pagination.Sort{Field: "name"}.String())
+}
+
+func TestLogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey || a.Key == slog.LevelKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+	params := pagination.Params{Limit: 20, Offset: 40, Sort: []pagination.Sort{{Field: "name", Order: "asc"}}}
+	links := pagination.Links{Next: "/users"}
+	logger.Info("list", "params", params, "links", links, "sort", params.Sort[0])
+	assert.Equal(t, "msg=list params.limit=20 params.offset=40 params.sort=name.asc links.next=/users sort=name.asc\n", buf.String())
+}
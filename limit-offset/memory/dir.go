@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"io/fs"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// DirEntry type encapsulates the information about an entry of a directory
+// answered back by PaginateDir, its json names are the fields it can be
+// sorted by
+type DirEntry struct {
+	Name    string    `json:"name"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mtime"`
+	IsDir   bool      `json:"dir"`
+}
+
+// PaginateDir will list the entries of the given directory of the file
+// system, sort them with the sort of the params, by name, size, mtime or dir,
+// and build the paginated response. The entries are sorted by name when there
+// is no sort, like fs.ReadDir does
+func PaginateDir(fsys fs.FS, dir, baseURL string, params pagination.Params) (pagination.Response, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return pagination.Response{}, err
+	}
+	items := make([]DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			return pagination.Response{}, err
+		}
+		items = append(items, DirEntry{
+			Name:    entry.Name(),
+			Size:    info.Size(),
+			ModTime: info.ModTime(),
+			IsDir:   entry.IsDir(),
+		})
+	}
+	return Paginate(items, baseURL, params, Compare[DirEntry]), nil
+}
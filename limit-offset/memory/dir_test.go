@@ -0,0 +1,62 @@
+package memory_test
+
+import (
+	"testing"
+	"testing/fstest"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var files = fstest.MapFS{
+	"reports/b.csv":       {Data: []byte("12345"), ModTime: day},
+	"reports/a.csv":       {Data: []byte("123"), ModTime: day.Add(time.Hour)},
+	"reports/c.csv":       {Data: []byte("1"), ModTime: day.Add(2 * time.Hour)},
+	"reports/2024/q1.csv": {Data: []byte("1"), ModTime: day},
+}
+
+func TestPaginateDir(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    pagination.Params
+		wantNames []string
+		wantNext  bool
+	}{
+		{
+			name:      "By name without sort",
+			params:    pagination.Params{Limit: 2},
+			wantNames: []string{"2024", "a.csv"},
+			wantNext:  true,
+		},
+		{
+			name:      "By size",
+			params:    pagination.Params{Limit: 10, Sort: []pagination.Sort{{Field: "dir", Order: "desc"}, {Field: "size", Order: "desc"}}},
+			wantNames: []string{"2024", "b.csv", "a.csv", "c.csv"},
+		},
+		{
+			name:      "By modification time",
+			params:    pagination.Params{Limit: 2, Offset: 2, Sort: []pagination.Sort{{Field: "mtime", Order: "desc"}, {Field: "name", Order: "asc"}}},
+			wantNames: []string{"b.csv", "2024"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := memory.PaginateDir(files, "reports", "/files/reports", tt.params)
+			assert.NoError(t, err)
+			names := make([]string, 0, len(response.Data))
+			for _, entry := range response.Data {
+				names = append(names, entry.(memory.DirEntry).Name)
+			}
+			assert.Equal(t, tt.wantNames, names)
+			assert.Equal(t, tt.wantNext, response.Links.Next != "")
+		})
+	}
+}
+
+func TestPaginateDirNotFound(t *testing.T) {
+	_, err := memory.PaginateDir(files, "missing", "/files/missing", pagination.Params{Limit: 10})
+	assert.Error(t, err)
+}
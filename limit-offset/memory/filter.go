@@ -0,0 +1,145 @@
+package memory
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// Filter will answer back the items matching all the given filters, see
+// Match. The given items are not modified
+func Filter[T any](items []T, filters pagination.Filters) []T {
+	if len(filters) == 0 {
+		return items
+	}
+	matched := make([]T, 0, len(items))
+	for _, item := range items {
+		if Match(item, filters) {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// Match will check if the given struct, or pointer to struct, matches all the
+// given filters. The fields are found like Compare does and the values of the
+// filters are parsed into the type of the field, so the numbers, booleans and
+// times (RFC 3339 or dates) are compared by value. The like operator follows
+// the SQL wildcards, % and _. The items without the field, or with a value
+// that can't be parsed, don't match
+func Match[T any](item T, filters pagination.Filters) bool {
+	v := reflect.ValueOf(item)
+	for _, filter := range filters {
+		field, ok := fieldValue(v, filter.Field)
+		if !ok || !matches(field, filter) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches function will check if the value of the field matches the filter
+func matches(field reflect.Value, filter pagination.Filter) bool {
+	switch filter.Operator {
+	case pagination.OpIn:
+		for _, value := range filter.Values {
+			if v, ok := parse(value, field.Type()); ok && compareValues(field, v) == 0 {
+				return true
+			}
+		}
+		return false
+	case pagination.OpLike:
+		return field.Kind() == reflect.String && like(filter.Value).MatchString(field.String())
+	}
+	v, ok := parse(filter.Value, field.Type())
+	if !ok {
+		return false
+	}
+	c := compareValues(field, v)
+	switch filter.Operator {
+	case pagination.OpEq:
+		return c == 0
+	case pagination.OpNe:
+		return c != 0
+	case pagination.OpGt:
+		return c > 0
+	case pagination.OpGte:
+		return c >= 0
+	case pagination.OpLt:
+		return c < 0
+	case pagination.OpLte:
+		return c <= 0
+	}
+	return false
+}
+
+// parse function will parse the value of a filter into the given type
+func parse(value string, t reflect.Type) (reflect.Value, bool) {
+	if t == timeType {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			if parsed, err = time.Parse("2006-01-02", value); err != nil {
+				return reflect.Value{}, false
+			}
+		}
+		return reflect.ValueOf(parsed), true
+	}
+	v := reflect.New(t).Elem()
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, t.Bits())
+		if err != nil {
+			return v, false
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(value, 10, t.Bits())
+		if err != nil {
+			return v, false
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, t.Bits())
+		if err != nil {
+			return v, false
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return v, false
+		}
+		v.SetBool(b)
+	case reflect.String:
+		v.SetString(value)
+	default:
+		return v, false
+	}
+	return v, true
+}
+
+// like function will compile the given SQL LIKE pattern into a regexp, the
+// backslash escapes the wildcards
+func like(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString(`(?s)^`)
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		case c == '%':
+			b.WriteString(`.*`)
+		case c == '_':
+			b.WriteString(`.`)
+		default:
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	b.WriteByte('$')
+	return regexp.MustCompile(b.String())
+}
@@ -0,0 +1,102 @@
+package memory_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		name    string
+		filters pagination.Filters
+		want    []setting
+	}{
+		{
+			name:    "Without filters",
+			filters: nil,
+			want:    settings,
+		},
+		{
+			name:    "Equal string",
+			filters: pagination.Filters{{Field: "key", Operator: pagination.OpEq, Value: "locale"}},
+			want:    []setting{settings[1]},
+		},
+		{
+			name:    "Numbers compared by value",
+			filters: pagination.Filters{{Field: "priority", Operator: pagination.OpGte, Value: "2"}},
+			want:    []setting{settings[0], settings[2]},
+		},
+		{
+			name:    "Boolean",
+			filters: pagination.Filters{{Field: "enabled", Operator: pagination.OpNe, Value: "true"}},
+			want:    []setting{settings[1]},
+		},
+		{
+			name:    "Time as RFC 3339",
+			filters: pagination.Filters{{Field: "updated_at", Operator: pagination.OpGt, Value: "2024-01-01T01:00:00Z"}},
+			want:    []setting{settings[0]},
+		},
+		{
+			name:    "Time as date",
+			filters: pagination.Filters{{Field: "updated_at", Operator: pagination.OpLte, Value: "2024-01-01"}},
+			want:    []setting{settings[1]},
+		},
+		{
+			name:    "In",
+			filters: pagination.Filters{{Field: "key", Operator: pagination.OpIn, Values: []string{"theme", "currency"}}},
+			want:    []setting{settings[0], settings[2]},
+		},
+		{
+			name:    "Like",
+			filters: pagination.Filters{{Field: "key", Operator: pagination.OpLike, Value: "%e_e"}},
+			want:    []setting{settings[0]},
+		},
+		{
+			name: "Several filters",
+			filters: pagination.Filters{
+				{Field: "priority", Operator: pagination.OpEq, Value: "2"},
+				{Field: "key", Operator: pagination.OpLt, Value: "m"},
+			},
+			want: []setting{settings[2]},
+		},
+		{
+			name:    "Value not matching the field type",
+			filters: pagination.Filters{{Field: "priority", Operator: pagination.OpEq, Value: "high"}},
+			want:    []setting{},
+		},
+		{
+			name:    "Unknown and hidden fields",
+			filters: pagination.Filters{{Field: "Secret", Operator: pagination.OpEq, Value: "a"}},
+			want:    []setting{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, memory.Filter(settings, tt.filters))
+		})
+	}
+}
+
+func TestMatchLikeEscapes(t *testing.T) {
+	item := struct {
+		Name string `json:"name"`
+	}{Name: "100%"}
+	assert.True(t, memory.Match(item, pagination.Filters{{Field: "name", Operator: pagination.OpLike, Value: `100\%`}}))
+	assert.False(t, memory.Match(item, pagination.Filters{{Field: "name", Operator: pagination.OpLike, Value: `10\_%`}}))
+	assert.True(t, memory.Match(&item, pagination.Filters{{Field: "name", Operator: pagination.OpLike, Value: "1%"}}))
+}
+
+func TestPaginateFilters(t *testing.T) {
+	params := pagination.Params{
+		Limit:   1,
+		Sort:    []pagination.Sort{{Field: "key", Order: "asc"}},
+		Filters: pagination.Filters{{Field: "enabled", Operator: pagination.OpEq, Value: "true"}},
+	}
+	response := memory.Paginate(settings, "/settings", params, memory.Compare[setting])
+	assert.Equal(t, []interface{}{settings[2]}, response.Data)
+	assert.NotEmpty(t, response.Links.Next)
+}
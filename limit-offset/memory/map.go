@@ -0,0 +1,43 @@
+package memory
+
+import (
+	"cmp"
+	"slices"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// Entry type encapsulates a key and its value of the maps paginated with
+// PaginateMap
+type Entry[K cmp.Ordered, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// PaginateMap will paginate the entries of the given map like Paginate does.
+// The map iteration order is random, so the entries are sorted by key before
+// applying the sort of the params, which keeps the same pages on every request
+// and breaks the ties of the sort. The compare function can be nil, then only
+// the key field can be sorted
+func PaginateMap[K cmp.Ordered, V any](m map[K]V, baseURL string, params pagination.Params, compare func(a, b Entry[K, V], field string) int) pagination.Response {
+	entries := make([]Entry[K, V], 0, len(m))
+	for key, value := range m {
+		entries = append(entries, Entry[K, V]{Key: key, Value: value})
+	}
+	slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+		return cmp.Compare(a.Key, b.Key)
+	})
+	if compare == nil {
+		compare = compareKeys[K, V]
+	}
+	return Paginate(entries, baseURL, params, compare)
+}
+
+// compareKeys function will compare the given entries by key when sorting by
+// the key field
+func compareKeys[K cmp.Ordered, V any](a, b Entry[K, V], field string) int {
+	if field != "key" {
+		return 0
+	}
+	return cmp.Compare(a.Key, b.Key)
+}
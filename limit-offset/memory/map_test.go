@@ -0,0 +1,47 @@
+package memory_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+var flags = map[string]int{"beta": 2, "alpha": 1, "delta": 1, "gamma": 3}
+
+func TestPaginateMap(t *testing.T) {
+	type entry = memory.Entry[string, int]
+	tests := []struct {
+		name     string
+		params   pagination.Params
+		compare  func(a, b entry, field string) int
+		wantData []interface{}
+	}{
+		{
+			name:     "Key order without sort",
+			params:   pagination.Params{Limit: 2, Offset: 2},
+			wantData: []interface{}{entry{Key: "delta", Value: 1}, entry{Key: "gamma", Value: 3}},
+		},
+		{
+			name:     "Key descending",
+			params:   pagination.Params{Limit: 2, Sort: []pagination.Sort{{Field: "key", Order: "desc"}}},
+			wantData: []interface{}{entry{Key: "gamma", Value: 3}, entry{Key: "delta", Value: 1}},
+		},
+		{
+			name:     "Value with the key breaking the ties",
+			params:   pagination.Params{Limit: 3, Sort: []pagination.Sort{{Field: "value", Order: "asc"}}},
+			compare:  memory.Compare[entry],
+			wantData: []interface{}{entry{Key: "alpha", Value: 1}, entry{Key: "delta", Value: 1}, entry{Key: "beta", Value: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				response := memory.PaginateMap(flags, "/flags", tt.params, tt.compare)
+				assert.Equal(t, tt.wantData, response.Data)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// Package memory paginates the collections kept in memory, like small config
+// collections, building the same responses the SQL backed endpoints answer
+// back
+package memory
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// Paginate will keep the given items matching the filters of the params, see
+// Match, sort them with the sort of the params, take the page the params are
+// pointing to and build the paginated response like the Paginate function
+// does. The compare function answers back a negative number
+// when a goes before b sorting by the given field ascending, zero when they
+// are equal and a positive number otherwise, Compare can be used for structs.
+// The given items are not modified
+func Paginate[T any](items []T, baseURL string, params pagination.Params, compare func(a, b T, field string) int) pagination.Response {
+	return pagination.Paginate(page(sorted(Filter(items, params.Filters), params.Sort, compare), params), baseURL, params)
+}
+
+// sorted function will answer back a sorted copy of the given items, the
+// items keep their order when there is no sort
+func sorted[T any](items []T, sort []pagination.Sort, compare func(a, b T, field string) int) []T {
+	if len(sort) == 0 {
+		return items
+	}
+	items = slices.Clone(items)
+	slices.SortStableFunc(items, func(a, b T) int {
+		for _, s := range sort {
+			c := compare(a, b, s.Field)
+			if strings.EqualFold(s.Order, "desc") {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return items
+}
+
+// page function will take the items of the page the params are pointing to,
+// plus the next one so Paginate knows if there are more pages
+func page[T any](items []T, params pagination.Params) []interface{} {
+	if params.Offset >= uint(len(items)) {
+		return nil
+	}
+	end := params.Offset + params.Limit + 1
+	if end > uint(len(items)) {
+		end = uint(len(items))
+	}
+	data := make([]interface{}, 0, end-params.Offset)
+	for _, item := range items[params.Offset:end] {
+		data = append(data, item)
+	}
+	return data
+}
+
+// Compare will compare the given structs, or pointers to structs, by the
+// given field, found by the name of its pagination tag, then its json tag and
+// then its Go name. The numbers, strings, booleans and times are compared by
+// value, any other kind by its fmt representation, and the structs without
+// the field are equal. It is meant for being given to Paginate
+//
+//	type User struct {
+//	  Name      string    `json:"name"`
+//	  CreatedAt time.Time `json:"created_at"`
+//	}
+func Compare[T any](a, b T, field string) int {
+	va, oka := fieldValue(reflect.ValueOf(a), field)
+	vb, okb := fieldValue(reflect.ValueOf(b), field)
+	if !oka || !okb {
+		return 0
+	}
+	return compareValues(va, vb)
+}
+
+// fieldIndexes caches the index of the fields of each struct type by name
+var fieldIndexes sync.Map
+
+// fieldValue function will find the value of the given field on the struct
+func fieldValue(v reflect.Value, field string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+	index, ok := fields(v.Type())[field]
+	if !ok {
+		return reflect.Value{}, false
+	}
+	return v.Field(index), true
+}
+
+// fields function will map the names of the fields of the given struct type
+// into their index
+func fields(t reflect.Type) map[string]int {
+	if cached, ok := fieldIndexes.Load(t); ok {
+		return cached.(map[string]int)
+	}
+	names := make(map[string]int, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name := f.Tag.Get("pagination")
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name, _, _ = strings.Cut(f.Tag.Get("json"), ",")
+		}
+		if name == "" || name == "-" {
+			name = f.Name
+		}
+		names[name] = i
+	}
+	fieldIndexes.Store(t, names)
+	return names
+}
+
+// timeType is the type of the time.Time values
+var timeType = reflect.TypeOf(time.Time{})
+
+// compareValues function will compare two values of the same field
+func compareValues(a, b reflect.Value) int {
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Compare(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.String:
+		return cmp.Compare(a.String(), b.String())
+	case reflect.Bool:
+		if a.Bool() == b.Bool() {
+			return 0
+		}
+		if !a.Bool() {
+			return -1
+		}
+		return 1
+	default:
+		return cmp.Compare(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+	}
+}
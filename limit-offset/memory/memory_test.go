@@ -0,0 +1,101 @@
+package memory_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type setting struct {
+	Key       string    `json:"key"`
+	Priority  int       `json:"priority"`
+	Enabled   bool      `pagination:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Secret    string    `pagination:"-"`
+}
+
+var (
+	day      = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	settings = []setting{
+		{Key: "theme", Priority: 2, Enabled: true, UpdatedAt: day.Add(2 * time.Hour), Secret: "b"},
+		{Key: "locale", Priority: 1, UpdatedAt: day, Secret: "a"},
+		{Key: "currency", Priority: 2, Enabled: true, UpdatedAt: day.Add(time.Hour), Secret: "c"},
+	}
+)
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    pagination.Params
+		wantData  []interface{}
+		wantLinks pagination.Links
+	}{
+		{
+			name:     "Original order without sort",
+			params:   pagination.Params{Limit: 2},
+			wantData: []interface{}{settings[0], settings[1]},
+			wantLinks: pagination.Links{
+				First: "/settings?page[limit]=2&page[offset]=0",
+				Next:  "/settings?page[limit]=2&page[offset]=2",
+			},
+		},
+		{
+			name:     "Sorted by several fields",
+			params:   pagination.Params{Limit: 3, Sort: []pagination.Sort{{Field: "priority", Order: "desc"}, {Field: "key", Order: "asc"}}},
+			wantData: []interface{}{settings[2], settings[0], settings[1]},
+			wantLinks: pagination.Links{
+				First: "/settings?page[limit]=3&page[offset]=0&sort=priority.desc,key.asc",
+			},
+		},
+		{
+			name:     "Second page sorted by time",
+			params:   pagination.Params{Limit: 2, Offset: 2, Sort: []pagination.Sort{{Field: "updated_at", Order: "asc"}}},
+			wantData: []interface{}{settings[0]},
+			wantLinks: pagination.Links{
+				First: "/settings?page[limit]=2&page[offset]=0&sort=updated_at.asc",
+				Prev:  "/settings?page[limit]=2&page[offset]=0&sort=updated_at.asc",
+			},
+		},
+		{
+			name:   "Offset beyond the items",
+			params: pagination.Params{Limit: 2, Offset: 10},
+			wantLinks: pagination.Links{
+				First: "/settings?page[limit]=2&page[offset]=0",
+				Prev:  "/settings?page[limit]=2&page[offset]=8",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := memory.Paginate(settings, "/settings", tt.params, memory.Compare[setting])
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantLinks, response.Links)
+		})
+	}
+	assert.Equal(t, "theme", settings[0].Key)
+}
+
+func TestCompare(t *testing.T) {
+	a, b := settings[1], settings[0]
+	assert.Equal(t, -1, memory.Compare(a, b, "priority"))
+	assert.Equal(t, 1, memory.Compare(b, a, "key"))
+	assert.Equal(t, -1, memory.Compare(a, b, "enabled"))
+	assert.Equal(t, -1, memory.Compare(a, b, "updated_at"))
+	assert.Equal(t, -1, memory.Compare(&a, &b, "updated_at"))
+	assert.Equal(t, 0, memory.Compare(a, b, "Secret"))
+	assert.Equal(t, 0, memory.Compare(a, b, "unknown"))
+}
+
+func TestPaginateCustomCompare(t *testing.T) {
+	words := []string{"b", "C", "a"}
+	insensitive := func(a, b string, field string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+	response := memory.Paginate(words, "/words", pagination.Params{Limit: 10, Sort: []pagination.Sort{{Field: "value", Order: "asc"}}}, insensitive)
+	assert.Equal(t, []interface{}{"a", "b", "C"}, response.Data)
+}
@@ -0,0 +1,155 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ParamCursor is the param carrying the composite cursor of the pages built
+// by Merge
+const ParamCursor = "page[cursor]"
+
+// mergeCursorParam is the param of the cursor query keeping the offset of
+// every source
+const mergeCursorParam = "offsets"
+
+// MergeSource type is a source of the items merged by Merge, like a shard. It
+// answers back up to the limit of the given params from their offset, sorted
+// with their sort and matching their filters
+type MergeSource[T any] func(ctx context.Context, params Params) ([]T, error)
+
+// Merge type merges several sources already sorted with the same sort into a
+// single page, like the shards of the data of a tenant or a database and its
+// cache. As the items taken from each source depend on the others the pages
+// can't be located by a single offset, so the next page is located by a
+// composite cursor keeping the offset of every source, carried on the
+// page[cursor] param of the next link
+type Merge[T any] struct {
+	// Sources are the sources merged, the cursors depend on their order
+	Sources []MergeSource[T]
+	// Compare compares two items by the given field like memory.Compare does,
+	// answering back a negative number when a goes before b sorting by the
+	// field ascending. The ties are broken by the order of the sources
+	Compare func(a, b T, field string) int
+	// Tokens signs the cursors, when there is none they are just encoded with
+	// base64
+	Tokens *TokenCodec
+}
+
+// Page method will build the page of the given params continuing from the
+// given cursor, the first page when it is empty. The offset of the params is
+// ignored, the pages beyond the first one are located by the cursor. Each
+// source is asked for the limit plus one item concurrently, and a *TokenError
+// is returned for the cursors that can't be accepted
+func (m Merge[T]) Page(ctx context.Context, baseURL string, params Params, cursor string) (Response, error) {
+	offsets, err := m.offsets(cursor)
+	if err != nil {
+		return Response{}, err
+	}
+	heads, err := m.fetch(ctx, params, offsets)
+	if err != nil {
+		return Response{}, err
+	}
+	data := make([]interface{}, 0, params.Limit)
+	for uint(len(data)) < params.Limit {
+		next := -1
+		for i, head := range heads {
+			if len(head) > 0 && (next < 0 || m.compare(head[0], heads[next][0], params.Sort) < 0) {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+		data = append(data, heads[next][0])
+		heads[next] = heads[next][1:]
+		offsets[next]++
+	}
+
+	params.Offset = 0
+	params.tokens = nil
+	response := Response{
+		Data:   data,
+		Links:  Links{First: buildLinks(baseURL, params, 0).First},
+		Meta:   params.meta(),
+		stable: params.StableJSON,
+	}
+	for _, head := range heads {
+		if len(head) > 0 {
+			response.Links.Next = response.Links.First + "&" + ParamCursor + "=" + url.QueryEscape(m.cursor(offsets))
+			break
+		}
+	}
+	return response, nil
+}
+
+// fetch method will ask every source for its items from the given offsets
+func (m Merge[T]) fetch(ctx context.Context, params Params, offsets []uint) ([][]T, error) {
+	heads := make([][]T, len(m.Sources))
+	errs := make([]error, len(m.Sources))
+	var wg sync.WaitGroup
+	for i, source := range m.Sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p := params
+			p.Offset = offsets[i]
+			p.Limit = params.Limit + 1
+			heads[i], errs[i] = source(ctx, p)
+		}()
+	}
+	wg.Wait()
+	return heads, errors.Join(errs...)
+}
+
+// compare method will compare the given items with the given sort
+func (m Merge[T]) compare(a, b T, sort []Sort) int {
+	for _, s := range sort {
+		c := m.Compare(a, b, s.Field)
+		if strings.EqualFold(s.Order, "desc") {
+			c = -c
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// cursor method will build the composite cursor for the given offsets
+func (m Merge[T]) cursor(offsets []uint) string {
+	values := make([]string, len(offsets))
+	for i, offset := range offsets {
+		values[i] = strconv.FormatUint(uint64(offset), 10)
+	}
+	return encodeCursor(m.Tokens, mergeCursorParam+"="+strings.Join(values, ","), "", "")
+}
+
+// offsets method will answer back the offset of every source kept inside the
+// given cursor, all of them are zero when there is no cursor
+func (m Merge[T]) offsets(cursor string) ([]uint, error) {
+	offsets := make([]uint, len(m.Sources))
+	if cursor == "" {
+		return offsets, nil
+	}
+	query, err := cursorQuery(m.Tokens, cursor)
+	if err != nil {
+		return nil, err
+	}
+	values := strings.Split(lookupParam(query, mergeCursorParam), ",")
+	if len(values) != len(offsets) {
+		return nil, &TokenError{Failure: TokenMalformed, Reason: "the cursor doesn't match the sources"}
+	}
+	for i, value := range values {
+		offset, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			return nil, &TokenError{Failure: TokenMalformed, Reason: "the cursor is malformed"}
+		}
+		offsets[i] = uint(offset)
+	}
+	return offsets, nil
+}
@@ -0,0 +1,90 @@
+package pagination_test
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+// shard answers back a merge source paginating the given sorted items
+func shard(items ...int) pagination.MergeSource[int] {
+	return func(ctx context.Context, params pagination.Params) ([]int, error) {
+		if params.Offset >= uint(len(items)) {
+			return nil, nil
+		}
+		end := min(params.Offset+params.Limit, uint(len(items)))
+		return items[params.Offset:end], nil
+	}
+}
+
+func compareInts(a, b int, field string) int {
+	return cmp.Compare(a, b)
+}
+
+func TestMerge(t *testing.T) {
+	merge := pagination.Merge[int]{
+		Sources: []pagination.MergeSource[int]{shard(1, 4, 5, 9), shard(2, 3, 8), shard()},
+		Compare: compareInts,
+	}
+	params := pagination.Params{Limit: 3, Sort: []pagination.Sort{{Field: "id", Order: "asc"}}}
+
+	var pages [][]interface{}
+	cursor := ""
+	for {
+		response, err := merge.Page(context.Background(), "/accounts", params, cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, "/accounts?page[limit]=3&page[offset]=0&sort=id.asc", response.Links.First)
+		pages = append(pages, response.Data)
+		if response.Links.Next == "" {
+			break
+		}
+		next, err := url.Parse(response.Links.Next)
+		assert.NoError(t, err)
+		cursor = next.Query().Get(pagination.ParamCursor)
+	}
+	assert.Equal(t, [][]interface{}{{1, 2, 3}, {4, 5, 8}, {9}}, pages)
+}
+
+func TestMergeDescending(t *testing.T) {
+	merge := pagination.Merge[int]{
+		Sources: []pagination.MergeSource[int]{shard(9, 5, 4), shard(8, 3)},
+		Compare: compareInts,
+		Tokens:  pagination.NewTokenCodec([]byte("secret")),
+	}
+	params := pagination.Params{Limit: 4, Sort: []pagination.Sort{{Field: "id", Order: "desc"}}}
+	response, err := merge.Page(context.Background(), "/accounts", params, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{9, 8, 5, 4}, response.Data)
+	next, _ := url.Parse(response.Links.Next)
+	response, err = merge.Page(context.Background(), "/accounts", params, next.Query().Get(pagination.ParamCursor))
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{3}, response.Data)
+	assert.Empty(t, response.Links.Next)
+}
+
+func TestMergeErrors(t *testing.T) {
+	failure := errors.New("shard unavailable")
+	merge := pagination.Merge[int]{
+		Sources: []pagination.MergeSource[int]{
+			shard(1),
+			func(ctx context.Context, params pagination.Params) ([]int, error) { return nil, failure },
+		},
+		Compare: compareInts,
+	}
+	_, err := merge.Page(context.Background(), "/accounts", pagination.Params{Limit: 2}, "")
+	assert.ErrorIs(t, err, failure)
+
+	_, err = merge.Page(context.Background(), "/accounts", pagination.Params{Limit: 2}, "not a cursor!")
+	assert.ErrorIs(t, err, pagination.ErrTokenMalformed)
+
+	other := pagination.Merge[int]{Sources: []pagination.MergeSource[int]{shard(1, 2, 3)}, Compare: compareInts}
+	response, _ := other.Page(context.Background(), "/accounts", pagination.Params{Limit: 1}, "")
+	next, _ := url.Parse(response.Links.Next)
+	_, err = merge.Page(context.Background(), "/accounts", pagination.Params{Limit: 1}, next.Query().Get(pagination.ParamCursor))
+	assert.ErrorContains(t, err, "doesn't match the sources")
+}
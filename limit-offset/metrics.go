@@ -0,0 +1,186 @@
+package pagination
+
+import (
+	"expvar"
+	"sync"
+)
+
+// MetricsHook interface defines the hooks called while paginating, so we can
+// see how deep the clients actually paginate and tune the limits. Set it on the
+// Metrics of the Policy, the params found by the policy keep it and call
+// OnPageServed when they are paginated. The hooks are called on the request
+// goroutine, so they should be fast and safe for concurrent use
+type MetricsHook interface {
+	// OnParse is called every time the policy finds the params of a request
+	OnParse(event ParseEvent)
+	// OnPageServed is called every time a page is built with Paginate
+	OnPageServed(event PageEvent)
+}
+
+// TokenMetricsHook interface can be implemented by a MetricsHook for being told
+// about the page tokens refused, see TokenFailure
+type TokenMetricsHook interface {
+	// OnTokenRejected is called every time a page token is refused
+	OnTokenRejected(failure TokenFailure)
+}
+
+// ParseEvent type encapsulates the information about the params found on a
+// request
+type ParseEvent struct {
+	Limit  uint
+	Offset uint
+	// Depth is the page number requested, starting on 1
+	Depth uint
+	// Sort are the sort fields used
+	Sort []string
+	// Err is the validation error of the request, nil when the params are valid
+	Err error
+}
+
+// PageEvent type encapsulates the information about a page served
+type PageEvent struct {
+	Limit  uint
+	Offset uint
+	// Depth is the page number served, starting on 1
+	Depth uint
+	// Items is the number of items on the page
+	Items int
+	// Last is true when there isn't a next page
+	Last bool
+}
+
+// NopMetricsHook type is a MetricsHook that does nothing, useful for embedding
+// it when only one of the hooks is needed
+type NopMetricsHook struct{}
+
+// OnParse will do nothing
+func (NopMetricsHook) OnParse(ParseEvent) {}
+
+// OnPageServed will do nothing
+func (NopMetricsHook) OnPageServed(PageEvent) {}
+
+// newParseEvent function will build the parse event for the given params
+func newParseEvent(params Params, err error) ParseEvent {
+	fields := make([]string, 0, len(params.Sort))
+	for _, s := range params.Sort {
+		fields = append(fields, s.Field)
+	}
+	return ParseEvent{
+		Limit:  params.Limit,
+		Offset: params.Offset,
+		Depth:  pageNumber(params),
+		Sort:   fields,
+		Err:    err,
+	}
+}
+
+// tokenRejected function will call the OnTokenRejected hook of the given
+// metrics when it implements the TokenMetricsHook
+func tokenRejected(metrics MetricsHook, failure TokenFailure) {
+	if hook, ok := metrics.(TokenMetricsHook); ok {
+		hook.OnTokenRejected(failure)
+	}
+}
+
+// pageServed method will call the OnPageServed hook, when there is one, for a
+// page built with the given data size, which includes the extra item
+func (p Params) pageServed(dataSize int) {
+	if p.metrics == nil {
+		return
+	}
+	items := dataSize
+	if !p.unlimited && uint(items) > p.Limit {
+		items = int(p.Limit)
+	}
+	p.metrics.OnPageServed(PageEvent{
+		Limit:  p.Limit,
+		Offset: p.Offset,
+		Depth:  pageNumber(p),
+		Items:  items,
+		Last:   p.unlimited || uint(dataSize) <= p.Limit,
+	})
+}
+
+// depthBuckets are the upper bounds of the page depths counted by the
+// ExpvarMetrics and the UsageStats, the deeper pages are counted on the
+// "deeper" bucket
+var depthBuckets = []struct {
+	max  uint
+	name string
+}{
+	{1, "1"},
+	{10, "2-10"},
+	{100, "11-100"},
+	{1000, "101-1000"},
+}
+
+// maxExpvarSortFields is the number of sort fields counted by the
+// ExpvarMetrics, the sort fields are sent by the clients so the new ones
+// beyond it are counted as "other"
+const maxExpvarSortFields = 100
+
+// ExpvarMetrics type is an example MetricsHook publishing the counters on
+// expvar, so they can be read on /debug/vars
+type ExpvarMetrics struct {
+	vars *expvar.Map
+
+	mu         sync.Mutex
+	maxDepth   expvar.Int
+	sortFields int
+}
+
+// NewExpvarMetrics will publish the pagination counters under the given expvar
+// name, like expvar.Publish it panics when the name is already used. The
+// counters are parses, parse_errors, pages, last_pages, depth (counting the
+// pages served by depth bucket), sort (counting the sort fields used, up to
+// 100 fields, the rest are counted as other), token_rejections (counting the page tokens refused by failure) and max_depth
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{vars: expvar.NewMap(name)}
+	m.vars.Set("depth", new(expvar.Map))
+	m.vars.Set("token_rejections", new(expvar.Map))
+	m.vars.Set("sort", new(expvar.Map))
+	m.vars.Set("max_depth", &m.maxDepth)
+	return m
+}
+
+// OnParse will count the parse and its sort fields
+func (m *ExpvarMetrics) OnParse(event ParseEvent) {
+	m.vars.Add("parses", 1)
+	if event.Err != nil {
+		m.vars.Add("parse_errors", 1)
+		return
+	}
+	sort := m.vars.Get("sort").(*expvar.Map)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, field := range event.Sort {
+		if sort.Get(field) == nil {
+			if m.sortFields >= maxExpvarSortFields {
+				field = "other"
+			} else {
+				m.sortFields++
+			}
+		}
+		sort.Add(field, 1)
+	}
+}
+
+// OnPageServed will count the page on its depth bucket
+func (m *ExpvarMetrics) OnPageServed(event PageEvent) {
+	m.vars.Add("pages", 1)
+	if event.Last {
+		m.vars.Add("last_pages", 1)
+	}
+	m.vars.Get("depth").(*expvar.Map).Add(depthBucket(event.Depth), 1)
+
+	m.mu.Lock()
+	if int64(event.Depth) > m.maxDepth.Value() {
+		m.maxDepth.Set(int64(event.Depth))
+	}
+	m.mu.Unlock()
+}
+
+// OnTokenRejected will count the token refused by its failure
+func (m *ExpvarMetrics) OnTokenRejected(failure TokenFailure) {
+	m.vars.Get("token_rejections").(*expvar.Map).Add(string(failure), 1)
+}
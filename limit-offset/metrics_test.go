@@ -0,0 +1,91 @@
+package pagination_test
+
+import (
+	"expvar"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingHook struct {
+	parses []pagination.ParseEvent
+	pages  []pagination.PageEvent
+}
+
+func (h *recordingHook) OnParse(event pagination.ParseEvent) {
+	h.parses = append(h.parses, event)
+}
+
+func (h *recordingHook) OnPageServed(event pagination.PageEvent) {
+	h.pages = append(h.pages, event)
+}
+
+func TestPolicyMetrics(t *testing.T) {
+	hook := &recordingHook{}
+	policy := pagination.Policy{DefaultLimit: 10, Metrics: hook}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[limit]=5&page[offset]=20&sort=name.asc,created_at.desc", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []pagination.ParseEvent{
+		{Limit: 5, Offset: 20, Depth: 5, Sort: []string{"name", "created_at"}},
+	}, hook.parses)
+
+	pagination.Paginate(make([]interface{}, 6), "/sample", params)
+	pagination.NewLinkTemplate("/sample", params).Paginate(make([]interface{}, 3), params)
+	assert.Equal(t, []pagination.PageEvent{
+		{Limit: 5, Offset: 20, Depth: 5, Items: 5},
+		{Limit: 5, Offset: 20, Depth: 5, Items: 3, Last: true},
+	}, hook.pages)
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[limit]=wrong", nil)
+	assert.Nil(t, err)
+	_, err = policy.FindParams(req)
+	assert.NotNil(t, err)
+	assert.Equal(t, err, hook.parses[1].Err)
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	metrics := pagination.NewExpvarMetrics("pagination_test")
+	policy := pagination.Policy{DefaultLimit: 10, Metrics: metrics}
+
+	for _, url := range []string{
+		"app.quicka.co/api/sample?sort=name.asc",
+		"app.quicka.co/api/sample?page[offset]=150&sort=name.asc",
+		"app.quicka.co/api/sample?page[offset]=wrong",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		if params, err := policy.FindParams(req); err == nil {
+			pagination.Paginate(make([]interface{}, 11), "/sample", params)
+		}
+	}
+
+	vars := expvar.Get("pagination_test").(*expvar.Map)
+	assert.Equal(t, "3", vars.Get("parses").String())
+	assert.Equal(t, "1", vars.Get("parse_errors").String())
+	assert.Equal(t, "2", vars.Get("pages").String())
+	assert.Equal(t, "16", vars.Get("max_depth").String())
+	assert.Equal(t, `{"1": 1, "11-100": 1}`, vars.Get("depth").String())
+	assert.Equal(t, `{"name": 2}`, vars.Get("sort").String())
+}
+
+func TestExpvarMetricsBoundedSort(t *testing.T) {
+	metrics := pagination.NewExpvarMetrics("pagination_bounded_test")
+	for i := 0; i < 150; i++ {
+		metrics.OnParse(pagination.ParseEvent{Sort: []string{fmt.Sprintf("field%d", i)}})
+	}
+	metrics.OnParse(pagination.ParseEvent{Sort: []string{"field0"}})
+
+	sort := expvar.Get("pagination_bounded_test").(*expvar.Map).Get("sort").(*expvar.Map)
+	var fields int
+	sort.Do(func(expvar.KeyValue) { fields++ })
+	assert.Equal(t, 101, fields)
+	assert.Equal(t, "2", sort.Get("field0").String())
+	assert.Equal(t, "50", sort.Get("other").String())
+	assert.Nil(t, sort.Get("field120"))
+}
@@ -0,0 +1,120 @@
+// Package mongofilter converts the pagination filters into MongoDB query
+// documents, so the Mongo backed endpoints accept the same filter params as
+// the SQL ones
+package mongofilter
+
+import (
+	"regexp"
+	"strings"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// mongoOperators maps the filter operators into the MongoDB ones
+var mongoOperators = map[pagination.Operator]string{
+	pagination.OpEq:   "$eq",
+	pagination.OpNe:   "$ne",
+	pagination.OpGt:   "$gt",
+	pagination.OpGte:  "$gte",
+	pagination.OpLt:   "$lt",
+	pagination.OpLte:  "$lte",
+	pagination.OpIn:   "$in",
+	pagination.OpLike: "$regex",
+}
+
+// Filter will build the MongoDB filter document for the given filters, like
+// Filters.Where does the fields map works as an allowlist mapping the filter
+// fields into the document fields, the filters of fields that aren't mapped
+// are ignored. The filters of the same field are merged, so
+// filter[age][gte]=18&filter[age][lt]=65 becomes
+//
+//	bson.D{{Key: "age", Value: bson.D{{Key: "$gte", Value: "18"}, {Key: "$lt", Value: "65"}}}}
+//
+// When the same operator is used more than once for a field the filters are
+// joined with $and instead. The values coerced by a FilterSchema are used when
+// present and the like patterns are converted into anchored regular expressions
+func Filter(filters pagination.Filters, fields map[string]string) bson.D {
+	doc := bson.D{}
+	var and bson.A
+	merge := true
+	for _, filter := range filters {
+		field, ok := fields[filter.Field]
+		if !ok {
+			continue
+		}
+		condition := bson.E{Key: mongoOperators[filter.Operator], Value: value(filter)}
+		and = append(and, bson.D{{Key: field, Value: bson.D{condition}}})
+		if !merge {
+			continue
+		}
+		i := indexOf(doc, field)
+		switch {
+		case i < 0:
+			doc = append(doc, bson.E{Key: field, Value: bson.D{condition}})
+		case indexOf(doc[i].Value.(bson.D), condition.Key) < 0:
+			doc[i].Value = append(doc[i].Value.(bson.D), condition)
+		default:
+			// The same operator twice on a field can't be merged into a single
+			// document, we keep collecting the conditions for the $and
+			merge = false
+		}
+	}
+	if !merge {
+		return bson.D{{Key: "$and", Value: and}}
+	}
+	return doc
+}
+
+// value function will return the value used on the document for the filter
+func value(filter pagination.Filter) interface{} {
+	if filter.Operator == pagination.OpIn {
+		values := make(bson.A, 0, len(filter.Values))
+		for i, v := range filter.Values {
+			values = append(values, parsed(filter, i, v))
+		}
+		return values
+	}
+	if filter.Operator == pagination.OpLike {
+		return likeRegex(filter.Value)
+	}
+	return parsed(filter, 0, filter.Value)
+}
+
+// parsed function will return the coerced value on the given position when the
+// filter was coerced, otherwise the raw value
+func parsed(filter pagination.Filter, i int, raw string) interface{} {
+	if i < len(filter.Parsed) {
+		return filter.Parsed[i]
+	}
+	return raw
+}
+
+// likeRegex function will convert a SQL like pattern, where % matches any
+// sequence of characters and _ a single one, into an anchored regex
+func likeRegex(pattern string) string {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteByte('.')
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteByte('$')
+	return b.String()
+}
+
+// indexOf function will return the position of the given key on the document
+func indexOf(doc bson.D, key string) int {
+	for i, e := range doc {
+		if e.Key == key {
+			return i
+		}
+	}
+	return -1
+}
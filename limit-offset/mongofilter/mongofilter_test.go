@@ -0,0 +1,61 @@
+package mongofilter_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/mongofilter"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+func TestFilter(t *testing.T) {
+	fields := map[string]string{
+		"status": "status",
+		"age":    "age",
+		"name":   "profile.name",
+	}
+
+	tests := []struct {
+		name    string
+		filters pagination.Filters
+		want    bson.D
+	}{
+		{
+			name: "Without filters",
+			want: bson.D{},
+		},
+		{
+			name: "All the operators",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpIn, Value: "active,pending", Values: []string{"active", "pending"}},
+				{Field: "age", Operator: pagination.OpGte, Value: "18", Parsed: []interface{}{int64(18)}},
+				{Field: "age", Operator: pagination.OpLt, Value: "65", Parsed: []interface{}{int64(65)}},
+				{Field: "name", Operator: pagination.OpLike, Value: "jo_n%.doe"},
+				{Field: "password", Operator: pagination.OpEq, Value: "secret"},
+			},
+			want: bson.D{
+				{Key: "status", Value: bson.D{{Key: "$in", Value: bson.A{"active", "pending"}}}},
+				{Key: "age", Value: bson.D{{Key: "$gte", Value: int64(18)}, {Key: "$lt", Value: int64(65)}}},
+				{Key: "profile.name", Value: bson.D{{Key: "$regex", Value: `^jo.n.*\.doe$`}}},
+			},
+		},
+		{
+			name: "Same operator twice on a field",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpNe, Value: "deleted"},
+				{Field: "status", Operator: pagination.OpNe, Value: "banned"},
+			},
+			want: bson.D{{Key: "$and", Value: bson.A{
+				bson.D{{Key: "status", Value: bson.D{{Key: "$ne", Value: "deleted"}}}},
+				bson.D{{Key: "status", Value: bson.D{{Key: "$ne", Value: "banned"}}}},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, mongofilter.Filter(tt.filters, fields))
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// PaginateNDJSON will paginate the given stream of newline delimited JSON,
+// skipping the offset lines, reading the page plus the next line, so we know
+// if there are more pages, and building the paginated response like Paginate
+// does, with every line as a json.RawMessage. The empty lines are ignored and
+// the rest of the stream is never read, so big export files can be previewed
+// without loading them. The lines of the page must be valid JSON
+func PaginateNDJSON(r io.Reader, baseURL string, params Params) (Response, error) {
+	var (
+		data    []interface{}
+		skipped uint
+		line    int
+	)
+	reader := bufio.NewReader(r)
+	for uint(len(data)) <= params.Limit {
+		raw, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Response{}, err
+		}
+		line++
+		if raw = bytes.TrimSpace(raw); len(raw) > 0 {
+			switch {
+			case skipped < params.Offset:
+				skipped++
+			case uint(len(data)) == params.Limit:
+				// the next line only tells there are more pages
+				data = append(data, nil)
+			case !json.Valid(raw):
+				return Response{}, fmt.Errorf("pagination: invalid JSON on line %d", line)
+			default:
+				data = append(data, json.RawMessage(raw))
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	return Paginate(data, baseURL, params), nil
+}
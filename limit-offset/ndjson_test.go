@@ -0,0 +1,83 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+const export = `{"id":1}
+{"id":2}
+
+{"id":3}
+{"id":4}
+not json`
+
+func TestPaginateNDJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    pagination.Params
+		wantData  []interface{}
+		wantLinks pagination.Links
+		wantErr   bool
+	}{
+		{
+			name:     "First page",
+			params:   pagination.Params{Limit: 2},
+			wantData: []interface{}{json.RawMessage(`{"id":1}`), json.RawMessage(`{"id":2}`)},
+			wantLinks: pagination.Links{
+				First: "/exports/1?page[limit]=2&page[offset]=0",
+				Next:  "/exports/1?page[limit]=2&page[offset]=2",
+			},
+		},
+		{
+			name:     "Empty lines are ignored",
+			params:   pagination.Params{Limit: 1, Offset: 2},
+			wantData: []interface{}{json.RawMessage(`{"id":3}`)},
+			wantLinks: pagination.Links{
+				First: "/exports/1?page[limit]=1&page[offset]=0",
+				Prev:  "/exports/1?page[limit]=1&page[offset]=1",
+				Next:  "/exports/1?page[limit]=1&page[offset]=3",
+			},
+		},
+		{
+			name:     "The next line is not parsed",
+			params:   pagination.Params{Limit: 1, Offset: 3},
+			wantData: []interface{}{json.RawMessage(`{"id":4}`)},
+			wantLinks: pagination.Links{
+				First: "/exports/1?page[limit]=1&page[offset]=0",
+				Prev:  "/exports/1?page[limit]=1&page[offset]=2",
+				Next:  "/exports/1?page[limit]=1&page[offset]=4",
+			},
+		},
+		{
+			name:    "Invalid line on the page",
+			params:  pagination.Params{Limit: 2, Offset: 4},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := pagination.PaginateNDJSON(strings.NewReader(export), "/exports/1", tt.params)
+			if tt.wantErr {
+				assert.EqualError(t, err, "pagination: invalid JSON on line 6")
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantLinks, response.Links)
+		})
+	}
+}
+
+func TestPaginateNDJSONReadError(t *testing.T) {
+	failure := errors.New("connection reset")
+	_, err := pagination.PaginateNDJSON(iotest.ErrReader(failure), "/exports/1", pagination.Params{Limit: 2})
+	assert.ErrorIs(t, err, failure)
+}
@@ -0,0 +1,83 @@
+package pagination
+
+import "strings"
+
+// Normalize method will answer back the canonical version of the params for
+// the given policy, so two semantically identical requests end up with the
+// same values, useful for cache keys and idempotency checks:
+//
+//   - a zero limit uses the DefaultLimit and the limit is clamped to MaxLimit
+//   - the sort orders are lower case, asc when missing, the repeated fields
+//     and the ones not allowed by the policy are dropped and the DefaultSort
+//     is used when there is no sort left
+//   - the filters are sorted like Filters.Encode does
+//
+// The params given are not modified
+func (p Params) Normalize(policy Policy) Params {
+	if p.Limit == 0 {
+		p.Limit = policy.DefaultLimit
+	}
+	if policy.MaxLimit > 0 && p.Limit > policy.MaxLimit {
+		p.Limit = policy.MaxLimit
+	}
+
+	sort := make([]Sort, 0, len(p.Sort))
+	for _, s := range p.Sort {
+		if len(policy.AllowedSorts) > 0 && !policy.sortAllowed(s.Field) || sortHasField(sort, s.Field) {
+			continue
+		}
+		s.Order = strings.ToLower(s.Order)
+		if s.Order == "" {
+			s.Order = "asc"
+		}
+		sort = append(sort, s)
+	}
+	p.Sort = sort
+	policy.applyDefaultSort(&p)
+	p.sortValue = ""
+
+	if len(p.Filters) > 0 {
+		p.Filters = p.Filters.sorted()
+	}
+	return p
+}
+
+// Equal method will check if the params select the same page than the given
+// ones, that is the same limit, offset, sort, seed and filters. The formats
+// used on the links are not compared, normalize both params first for
+// ignoring the differences Normalize removes
+func (p Params) Equal(other Params) bool {
+	if p.Limit != other.Limit || p.Offset != other.Offset || p.unlimited != other.unlimited || p.Seed != other.Seed || len(p.Sort) != len(other.Sort) {
+		return false
+	}
+	for i := range p.Sort {
+		if !sortEqual(p.Sort[i], other.Sort[i]) {
+			return false
+		}
+	}
+	return p.Filters.Encode() == other.Filters.Encode()
+}
+
+// sortHasField function will check if the given field is already on the sort
+func sortHasField(sort []Sort, field string) bool {
+	for _, s := range sort {
+		if s.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEqual function will check if both sort fields are the same, including
+// the columns and collation used on the query
+func sortEqual(a, b Sort) bool {
+	if a.Field != b.Field || a.Order != b.Order || a.Collation != b.Collation || len(a.Columns) != len(b.Columns) {
+		return false
+	}
+	for i := range a.Columns {
+		if a.Columns[i] != b.Columns[i] {
+			return false
+		}
+	}
+	return true
+}
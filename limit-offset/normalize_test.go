@@ -0,0 +1,84 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParamsNormalize(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		MaxLimit:     100,
+		DefaultSort:  []pagination.Sort{{Field: "id", Order: "asc"}},
+		AllowedSorts: []string{"id", "name", "created_at"},
+	}
+
+	tests := []struct {
+		name   string
+		params pagination.Params
+		want   pagination.Params
+	}{
+		{
+			name:   "Defaults applied",
+			params: pagination.Params{},
+			want:   pagination.Params{Limit: 10, Sort: []pagination.Sort{{Field: "id", Order: "asc"}}},
+		},
+		{
+			name: "Limit clamped and sort canonicalized",
+			params: pagination.Params{
+				Limit: 500,
+				Sort: []pagination.Sort{
+					{Field: "name", Order: "DESC"},
+					{Field: "password", Order: "asc"},
+					{Field: "created_at"},
+					{Field: "name", Order: "asc"},
+				},
+			},
+			want: pagination.Params{
+				Limit: 100,
+				Sort:  []pagination.Sort{{Field: "name", Order: "desc"}, {Field: "created_at", Order: "asc"}},
+			},
+		},
+		{
+			name: "Filters sorted",
+			params: pagination.Params{
+				Limit:   20,
+				Sort:    []pagination.Sort{{Field: "id", Order: "asc"}},
+				Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}, {Field: "age", Operator: pagination.OpGt, Value: "30"}},
+			},
+			want: pagination.Params{
+				Limit:   20,
+				Sort:    []pagination.Sort{{Field: "id", Order: "asc"}},
+				Filters: pagination.Filters{{Field: "age", Operator: pagination.OpGt, Value: "30"}, {Field: "status", Operator: pagination.OpEq, Value: "active"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.params.Normalize(policy))
+		})
+	}
+}
+
+func TestParamsEqual(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10}
+	find := func(url string) pagination.Params {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		params, err := policy.FindParams(req)
+		assert.Nil(t, err)
+		return params
+	}
+
+	dot := find("app.quicka.co/api/sample?sort=name.asc&filter[status]=active&filter[age][gt]=30")
+	prefix := find("app.quicka.co/api/sample?filter[age][gt]=30&sort=name&filter[status]=active&page[limit]=10")
+	assert.True(t, dot.Normalize(policy).Equal(prefix.Normalize(policy)))
+
+	other := find("app.quicka.co/api/sample?sort=name.desc&filter[status]=active&filter[age][gt]=30")
+	assert.False(t, dot.Normalize(policy).Equal(other.Normalize(policy)))
+	assert.False(t, dot.Equal(pagination.Params{Limit: 10}))
+}
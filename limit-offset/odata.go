@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+const (
+	// ODataParamTop is the OData system query option for the page limit
+	ODataParamTop = "$top"
+	// ODataParamSkip is the OData system query option for the page offset
+	ODataParamSkip = "$skip"
+	// ODataParamOrderBy is the OData system query option for the sort, it uses
+	// the pair format, like name desc,created_at
+	ODataParamOrderBy = "$orderby"
+	// ODataParamCount is the OData system query option asking for the total
+	// count of items
+	ODataParamCount = "$count"
+)
+
+// odataParamNames are the param names used on the OData links, the sort is
+// always written on the $orderby option
+var odataParamNames = paramNames{
+	limit:   ODataParamTop,
+	offset:  ODataParamSkip,
+	sort:    ODataParamOrderBy,
+	orderBy: ODataParamOrderBy,
+	seed:    ParamSeed,
+	number:  ParamPageNumber,
+	size:    ParamPageSize,
+}
+
+// ODataParams type encapsulates the params found on a request using the OData
+// system query options, like the ones sent by Excel or Power Query
+type ODataParams struct {
+	Params
+	// Count is true when the client asked for the total count of items with
+	// $count=true, the total can be found with CachedCount and given to
+	// Paginate
+	Count bool
+}
+
+// ODataResponse type encapsulates a paginated response with the OData shape,
+// the clients follow the next link until there is none
+type ODataResponse struct {
+	Value    []interface{} `json:"value"`
+	Count    *uint         `json:"@odata.count,omitempty"`
+	NextLink string        `json:"@odata.nextLink,omitempty"`
+}
+
+// FindODataParams will find the params on the request using the OData system
+// query options $top, $skip, $orderby and $count, applying the rules of the
+// given policy. The OData $filter option is not supported, the filters are
+// found on the usual filter params
+func FindODataParams(req *http.Request, policy Policy) (ODataParams, error) {
+	var odata ODataParams
+	policy.SortFormat = SortFormatPair
+	policy.LinkSortFormat = SortFormatPair
+	policy = policy.preset()
+	rawQuery, err := policy.applyDuplicateParams(req.URL.RawQuery, ODataParamTop, ODataParamSkip, ODataParamOrderBy, ODataParamCount)
+	if err == nil {
+		rawQuery, err = odataQuery(rawQuery, &odata)
+	}
+	if err == nil {
+		err = policy.findParamsInto(req, rawQuery, &odata.Params)
+	}
+	return odata, policy.found(req, &odata.Params, err)
+}
+
+// Paginate method will build the OData response for the given data, the total
+// is only added when the client asked for it with $count=true
+func (o ODataParams) Paginate(data []interface{}, baseURL string, total uint) ODataResponse {
+	o.pageServed(len(data))
+	value := buildData(data, o.Params)
+	if value == nil {
+		value = []interface{}{}
+	}
+	response := ODataResponse{
+		Value:    value,
+		NextLink: buildLinksWithQuery(baseURL, odataParamNames, o.Params, o.query(), len(data)).Next,
+	}
+	if o.Count {
+		response.Count = &total
+	}
+	o.paginated(context.Background(), Response{Data: value, Links: Links{Next: response.NextLink}})
+	return response
+}
+
+// query method will encode the filters and the $count option for the links
+func (o ODataParams) query() string {
+	query := o.filterQuery()
+	if !o.Count {
+		return query
+	}
+	if query != "" {
+		query += "&"
+	}
+	return query + ODataParamCount + "=true"
+}
+
+// odataQuery function will translate the OData system query options of the
+// given raw query into the pagination params, see aliasQuery
+func odataQuery(rawQuery string, odata *ODataParams) (string, error) {
+	if count := lookupParam(rawQuery, ODataParamCount); count != "" {
+		var err error
+		if odata.Count, err = strconv.ParseBool(count); err != nil {
+			return "", err
+		}
+	}
+	return aliasQuery(rawQuery,
+		ParamPageLimit, ODataParamTop,
+		ParamPageOffset, ODataParamSkip,
+		ParamOrderBy, ODataParamOrderBy,
+	), nil
+}
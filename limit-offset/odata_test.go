@@ -0,0 +1,95 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindODataParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10}
+
+	tests := []struct {
+		name       string
+		url        string
+		wantLimit  uint
+		wantOffset uint
+		wantSort   []pagination.Sort
+		wantCount  bool
+		wantErr    bool
+	}{
+		{
+			name:      "Only defaults",
+			url:       "app.quicka.co/api/sample",
+			wantLimit: 10,
+		},
+		{
+			name:       "Top, skip and orderby",
+			url:        "app.quicka.co/api/sample?$top=5&$skip=20&$orderby=name%20desc,age",
+			wantLimit:  5,
+			wantOffset: 20,
+			wantSort:   []pagination.Sort{{Field: "name", Order: "desc"}, {Field: "age", Order: "asc"}},
+		},
+		{
+			name:      "Count requested",
+			url:       "app.quicka.co/api/sample?$count=true",
+			wantLimit: 10,
+			wantCount: true,
+		},
+		{
+			name:    "Invalid count",
+			url:     "app.quicka.co/api/sample?$count=yes",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid top",
+			url:     "app.quicka.co/api/sample?$top=many",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			odata, err := pagination.FindODataParams(req, policy)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, odata.Limit)
+			assert.Equal(t, tt.wantOffset, odata.Offset)
+			if tt.wantSort != nil {
+				assert.Equal(t, tt.wantSort, odata.Sort)
+			}
+			assert.Equal(t, tt.wantCount, odata.Count)
+		})
+	}
+}
+
+func TestODataPaginate(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?$top=2&$skip=2&$orderby=name%20desc&$count=true", nil)
+	assert.Nil(t, err)
+	odata, err := pagination.FindODataParams(req, pagination.Policy{DefaultLimit: 10})
+	assert.Nil(t, err)
+
+	response := odata.Paginate([]interface{}{"c", "d", "e"}, "/users", 7)
+	body, err := json.Marshal(response)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"value": ["c", "d"],
+		"@odata.count": 7,
+		"@odata.nextLink": "/users?$top=2&$skip=4&$orderby=name+desc&$count=true"
+	}`, string(body))
+
+	odata.Count = false
+	response = odata.Paginate([]interface{}{}, "/users", 7)
+	body, err = json.Marshal(response)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"value": []}`, string(body))
+}
@@ -0,0 +1,46 @@
+package pagination
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// offsetEncoding type encapsulates how the offsets are written on the links,
+// as plain integers or as opaque values, see Policy.OpaqueOffsets
+type offsetEncoding struct {
+	opaque bool
+	tokens *TokenCodec
+}
+
+// append method will append the given offset into the buffer
+func (e offsetEncoding) append(b []byte, offset uint) []byte {
+	if !e.opaque {
+		return strconv.AppendUint(b, uint64(offset), 10)
+	}
+	return append(b, OffsetCursor(e.tokens, offset)...)
+}
+
+// decodeOffset method will translate the opaque offset of the given raw query
+// into the plain one, placed before the raw query so it is the one found. The
+// offsets that can't be accepted, including the plain integers, are refused
+// with a *TokenError, logged and reported to the metrics hook and, when
+// tampered, to the OnTamperedToken callback
+func (p Policy) decodeOffset(req *http.Request, rawQuery string) (string, error) {
+	name := p.names.orDefault().offset
+	value := lookupParam(rawQuery, name)
+	if value == "" {
+		return rawQuery, nil
+	}
+	offset, err := CursorOffset(p.OffsetTokens, value)
+	if err != nil {
+		var tokenErr *TokenError
+		if errors.As(err, &tokenErr) {
+			p.log(req.Context(), "pagination: offset rejected", "failure", tokenErr.Failure, "reason", tokenErr.Reason)
+			tokenRejected(p.Metrics, tokenErr.Failure)
+			p.reportTampering(req, p.OffsetTokens, tokenErr)
+		}
+		return "", err
+	}
+	return name + "=" + strconv.FormatUint(uint64(offset), 10) + "&" + rawQuery, nil
+}
@@ -0,0 +1,64 @@
+package pagination_test
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOpaqueOffsets(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit:  2,
+		OpaqueOffsets: true,
+		OffsetTokens:  pagination.NewTokenCodec(tokenKey),
+	}
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?sort=name.asc", nil))
+	assert.NoError(t, err)
+	links := pagination.Paginate(make([]interface{}, 3), "/users", params).Links
+
+	next, err := url.Parse(links.Next)
+	assert.NoError(t, err)
+	offset := next.Query().Get(pagination.ParamPageOffset)
+	assert.NotEqual(t, "2", offset)
+	assert.Equal(t, "2", next.Query().Get(pagination.ParamPageLimit))
+	assert.Equal(t, "name.asc", next.Query().Get("sort"))
+
+	params, err = policy.FindParams(httptest.NewRequest("GET", links.Next, nil))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(2), params.Offset)
+	assert.Equal(t, []pagination.Sort{{Field: "name", Order: "asc"}}, params.Sort)
+
+	tests := []struct {
+		name    string
+		offset  string
+		wantErr error
+	}{
+		{name: "Plain integer", offset: "1000000", wantErr: pagination.ErrTokenMalformed},
+		{name: "Unsigned cursor", offset: pagination.OffsetCursor(nil, 1000000), wantErr: pagination.ErrTokenTampered},
+		{name: "Signed with another key", offset: pagination.OffsetCursor(pagination.NewTokenCodec([]byte("another key of 32 bytes for test")), 10), wantErr: pagination.ErrTokenTampered},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := policy.FindParams(httptest.NewRequest("GET", "/users?page[offset]="+url.QueryEscape(tt.offset), nil))
+			assert.ErrorIs(t, err, tt.wantErr)
+			_, err = policy.FindParamsStrict(httptest.NewRequest("GET", "/users?page[offset]="+url.QueryEscape(tt.offset), nil))
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOpaqueOffsetsUnsigned(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, OpaqueOffsets: true}
+	req := httptest.NewRequest("GET", "/users?page[offset]="+pagination.OffsetCursor(nil, 30), nil)
+	params, err := policy.FindParams(req)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(30), params.Offset)
+
+	links := pagination.Paginate(make([]interface{}, 5), "/users", params).Links
+	assert.Equal(t, "/users?page[limit]=10&page[offset]="+pagination.OffsetCursor(nil, 0), links.First)
+	assert.Equal(t, "/users?page[limit]=10&page[offset]="+pagination.OffsetCursor(nil, 20), links.Prev)
+}
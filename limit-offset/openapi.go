@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"sort"
+	"strings"
+)
+
+// OpenAPIParameter type encapsulates an OpenAPI 3 parameter object, it can be
+// marshaled into JSON as it is
+type OpenAPIParameter struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Schema      *OpenAPISchema `json:"schema"`
+}
+
+// OpenAPISchema type encapsulates the subset of the OpenAPI 3 schema object
+// used for describing the pagination params and responses
+type OpenAPISchema struct {
+	Type                 string                    `json:"type,omitempty"`
+	Format               string                    `json:"format,omitempty"`
+	Description          string                    `json:"description,omitempty"`
+	Minimum              *uint                     `json:"minimum,omitempty"`
+	Maximum              *uint                     `json:"maximum,omitempty"`
+	Default              interface{}               `json:"default,omitempty"`
+	Enum                 []string                  `json:"enum,omitempty"`
+	Items                *OpenAPISchema            `json:"items,omitempty"`
+	Properties           map[string]*OpenAPISchema `json:"properties,omitempty"`
+	AdditionalProperties bool                      `json:"additionalProperties,omitempty"`
+	Required             []string                  `json:"required,omitempty"`
+	Nullable             bool                      `json:"nullable,omitempty"`
+}
+
+// OpenAPIParameters will describe the query params accepted by the policy as
+// OpenAPI 3 parameter objects, using the actual param names, defaults and
+// limits of the policy so the generated docs always match the code
+func (p Policy) OpenAPIParameters() []OpenAPIParameter {
+	names := p.names.orDefault()
+	zero := uint(0)
+	one := uint(1)
+	limit := &OpenAPISchema{Type: "integer", Minimum: &zero, Default: p.DefaultLimit}
+	size := &OpenAPISchema{Type: "integer", Minimum: &zero, Default: p.DefaultLimit}
+	if p.MaxLimit > 0 {
+		limit.Maximum = &p.MaxLimit
+		size.Maximum = &p.MaxLimit
+	}
+	params := []OpenAPIParameter{
+		queryParam(names.limit, "Maximum number of items of the page.", limit),
+		queryParam(names.offset, "Number of items skipped before the page.", &OpenAPISchema{Type: "integer", Minimum: &zero, Default: p.DefaultOffset}),
+		queryParam(names.number, "Page number, starting on 1, used instead of the offset.", &OpenAPISchema{Type: "integer", Minimum: &one}),
+		queryParam(names.size, "Page size used together with the page number.", size),
+	}
+	if p.SortFormat != SortFormatPair {
+		params = append(params, queryParam(names.sort, p.sortDescription("Comma separated sort fields, like name.asc,created_at.desc or -created_at,name."), &OpenAPISchema{Type: "string"}))
+	}
+	if p.SortFormat == SortFormatAuto || p.SortFormat == SortFormatPair {
+		params = append(params, queryParam(names.orderBy, p.sortDescription("Comma separated sort pairs, like name asc,created_at desc."), &OpenAPISchema{Type: "string"}))
+	}
+	if p.RandomSort {
+		params = append(params, queryParam(names.seed, "Seed of the random sort, keep it for getting the same order on every page.", &OpenAPISchema{Type: "integer", Minimum: &zero}))
+	}
+	if p.Tokens != nil {
+		params = append(params, queryParam(ParamPageToken, "Opaque token of the page, taken from the links.", &OpenAPISchema{Type: "string"}))
+	}
+	params = append(params, p.filterParameters()...)
+	if p.SearchParam != "" {
+		params = append(params, queryParam(p.SearchParam, "Free-text search term.", &OpenAPISchema{Type: "string"}))
+	}
+	return params
+}
+
+// OpenAPIResponseSchema will describe the paginated responses as an OpenAPI 3
+// schema, using the given schema for the items of the data. With the
+// StableJSON the data and the four links are required, the links nullable
+func (p Policy) OpenAPIResponseSchema(item *OpenAPISchema) *OpenAPISchema {
+	link := func(description string) *OpenAPISchema {
+		return &OpenAPISchema{Type: "string", Format: "uri-reference", Description: description, Nullable: p.StableJSON}
+	}
+	required, linksRequired := []string{"links"}, []string(nil)
+	if p.StableJSON {
+		required, linksRequired = []string{"data", "links"}, []string{"first", "prev", "next", "last"}
+	}
+	return &OpenAPISchema{
+		Type:     "object",
+		Required: required,
+		Properties: map[string]*OpenAPISchema{
+			"data": {Type: "array", Items: item},
+			"links": {
+				Type:     "object",
+				Required: linksRequired,
+				Properties: map[string]*OpenAPISchema{
+					"first": link("Link to the first page."),
+					"prev":  link("Link to the previous page, missing on the first page."),
+					"next":  link("Link to the next page, missing on the last page."),
+					"last":  link("Link to the last page, when it is known."),
+				},
+			},
+			"meta": {Type: "object", AdditionalProperties: true},
+		},
+	}
+}
+
+// sortDescription method will complete the given description of the sort
+// params with the fields allowed by the policy
+func (p Policy) sortDescription(description string) string {
+	if len(p.AllowedSorts) == 0 {
+		return description
+	}
+	return description + " Allowed fields: " + strings.Join(p.AllowedSorts, ", ") + "."
+}
+
+// filterParameters method will describe the filter params of the schema of
+// the policy, sorted by field
+func (p Policy) filterParameters() []OpenAPIParameter {
+	if p.FilterFormat == FilterFormatRSQL {
+		return []OpenAPIParameter{
+			queryParam(ParamFilter, "RSQL filter expression, like status==active;age=gt=30.", &OpenAPISchema{Type: "string"}),
+		}
+	}
+	fields := make([]string, 0, len(p.FilterSchema))
+	for field := range p.FilterSchema {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	params := make([]OpenAPIParameter, 0, len(fields))
+	for _, field := range fields {
+		f := p.FilterSchema[field]
+		ops := make([]string, 0, len(operators))
+		for _, op := range operators {
+			if f.accepts(op) {
+				ops = append(ops, string(op))
+			}
+		}
+		description := "Filter by " + field + ", the operators " + strings.Join(ops, ", ") + " can be used with " + ParamFilter + "[" + field + "][operator]."
+		params = append(params, queryParam(ParamFilter+"["+field+"]", description, f.openAPISchema()))
+	}
+	return params
+}
+
+// openAPISchema method will describe the values accepted by the field
+func (f FilterField) openAPISchema() *OpenAPISchema {
+	switch f.Type {
+	case FilterInt:
+		return &OpenAPISchema{Type: "integer", Format: "int64"}
+	case FilterBool:
+		return &OpenAPISchema{Type: "boolean"}
+	case FilterTime:
+		return &OpenAPISchema{Type: "string", Format: "date-time"}
+	case FilterUUID:
+		return &OpenAPISchema{Type: "string", Format: "uuid"}
+	case FilterEnum:
+		return &OpenAPISchema{Type: "string", Enum: f.Enum}
+	}
+	return &OpenAPISchema{Type: "string"}
+}
+
+// queryParam function will build a query parameter object
+func queryParam(name, description string, schema *OpenAPISchema) OpenAPIParameter {
+	return OpenAPIParameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      schema,
+	}
+}
@@ -0,0 +1,77 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyOpenAPIParameters(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		MaxLimit:     100,
+		SortFormat:   pagination.SortFormatDot,
+		AllowedSorts: []string{"name", "created_at"},
+		FilterSchema: pagination.FilterSchema{
+			"status": {Type: pagination.FilterEnum, Enum: []string{"active", "pending"}},
+			"age":    {Type: pagination.FilterInt},
+		},
+		SearchParam: "q",
+	}
+
+	body, err := json.Marshal(policy.OpenAPIParameters())
+	assert.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"name": "page[limit]", "in": "query", "description": "Maximum number of items of the page.", "schema": {"type": "integer", "minimum": 0, "maximum": 100, "default": 10}},
+		{"name": "page[offset]", "in": "query", "description": "Number of items skipped before the page.", "schema": {"type": "integer", "minimum": 0, "default": 0}},
+		{"name": "page[number]", "in": "query", "description": "Page number, starting on 1, used instead of the offset.", "schema": {"type": "integer", "minimum": 1}},
+		{"name": "page[size]", "in": "query", "description": "Page size used together with the page number.", "schema": {"type": "integer", "minimum": 0, "maximum": 100, "default": 10}},
+		{"name": "sort", "in": "query", "description": "Comma separated sort fields, like name.asc,created_at.desc or -created_at,name. Allowed fields: name, created_at.", "schema": {"type": "string"}},
+		{"name": "filter[age]", "in": "query", "description": "Filter by age, the operators eq, ne, gt, gte, lt, lte, in can be used with filter[age][operator].", "schema": {"type": "integer", "format": "int64"}},
+		{"name": "filter[status]", "in": "query", "description": "Filter by status, the operators eq, ne, in can be used with filter[status][operator].", "schema": {"type": "string", "enum": ["active", "pending"]}},
+		{"name": "q", "in": "query", "description": "Free-text search term.", "schema": {"type": "string"}}
+	]`, string(body))
+}
+
+func TestPolicyOpenAPIParametersFormats(t *testing.T) {
+	names := func(params []pagination.OpenAPIParameter) []string {
+		var names []string
+		for _, param := range params {
+			names = append(names, param.Name)
+		}
+		return names
+	}
+
+	assert.Equal(t,
+		[]string{"page[limit]", "page[offset]", "page[number]", "page[size]", "sort", "order_by"},
+		names(pagination.Policy{}.OpenAPIParameters()),
+	)
+	assert.Equal(t,
+		[]string{"page[limit]", "page[offset]", "page[number]", "page[size]", "order_by", "seed", "page[token]", "filter"},
+		names(pagination.Policy{
+			SortFormat:   pagination.SortFormatPair,
+			RandomSort:   true,
+			Tokens:       pagination.NewTokenCodec([]byte("key")),
+			FilterFormat: pagination.FilterFormatRSQL,
+		}.OpenAPIParameters()),
+	)
+}
+
+func TestPolicyOpenAPIResponseSchema(t *testing.T) {
+	schema := pagination.Policy{}.OpenAPIResponseSchema(&pagination.OpenAPISchema{Type: "object"})
+
+	assert.Equal(t, "object", schema.Type)
+	assert.Equal(t, []string{"links"}, schema.Required)
+	assert.Equal(t, &pagination.OpenAPISchema{Type: "object"}, schema.Properties["data"].Items)
+	assert.Equal(t, "uri-reference", schema.Properties["links"].Properties["next"].Format)
+}
+
+func TestPolicyOpenAPIResponseSchemaStableJSON(t *testing.T) {
+	schema := pagination.Policy{StableJSON: true}.OpenAPIResponseSchema(&pagination.OpenAPISchema{Type: "object"})
+
+	assert.Equal(t, []string{"data", "links"}, schema.Required)
+	assert.Equal(t, []string{"first", "prev", "next", "last"}, schema.Properties["links"].Required)
+	assert.True(t, schema.Properties["links"].Properties["prev"].Nullable)
+}
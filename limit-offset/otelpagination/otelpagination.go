@@ -0,0 +1,117 @@
+// Package otelpagination records the pagination params on OpenTelemetry spans,
+// so every service uses the same attribute names
+package otelpagination
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// AttrLimit is the attribute with the limit of the page
+	AttrLimit = attribute.Key("page.limit")
+	// AttrOffset is the attribute with the offset of the page
+	AttrOffset = attribute.Key("page.offset")
+	// AttrDepth is the attribute with the page number, starting on 1
+	AttrDepth = attribute.Key("page.depth")
+	// AttrStrategy is the attribute with the pagination strategy
+	AttrStrategy = attribute.Key("page.strategy")
+	// AttrCountTotal is the attribute with the total found by a count query
+	AttrCountTotal = attribute.Key("page.count.total")
+	// AttrCountCached is the attribute telling if the total came from the cache
+	AttrCountCached = attribute.Key("page.count.cached")
+)
+
+const (
+	// StrategyOffset is the strategy of the limit and offset pagination
+	StrategyOffset = "offset"
+
+	// EventCount is the event recorded for the count queries
+	EventCount = "pagination.count"
+	// EventInvalidParams is the event recorded when the params of the request
+	// aren't valid
+	EventInvalidParams = "pagination.invalid_params"
+	// EventTokenDecodeFailure is the event recorded when a page token can't be
+	// decoded
+	EventTokenDecodeFailure = "pagination.token_decode_failure"
+)
+
+// Attributes will answer back the span attributes for the given params
+func Attributes(params pagination.Params) []attribute.KeyValue {
+	depth := uint(1)
+	if params.Limit > 0 {
+		depth = params.Offset/params.Limit + 1
+	}
+	return []attribute.KeyValue{
+		AttrLimit.Int64(int64(params.Limit)),
+		AttrOffset.Int64(int64(params.Offset)),
+		AttrDepth.Int64(int64(depth)),
+		AttrStrategy.String(StrategyOffset),
+	}
+}
+
+// Annotate will set the attributes of the given params on the span of the
+// given context
+func Annotate(ctx context.Context, params pagination.Params) {
+	trace.SpanFromContext(ctx).SetAttributes(Attributes(params)...)
+}
+
+// Middleware will build a middleware finding the params of each request with
+// the given policy and setting them on the span of the request, the span has
+// to be started by a previous middleware, like the otelhttp one. The invalid
+// params are recorded as an event, or the token decode failure one for the
+// page tokens, the request is still served as the handler is the one
+// answering back the error. The params are kept on the context of the
+// request, the handlers get them with policy.FoundParams instead of finding
+// them again
+func Middleware(policy pagination.Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+			params, err := policy.FindParams(req)
+			ctx := req.Context()
+			switch {
+			case errors.Is(err, pagination.ErrInvalidToken):
+				RecordTokenDecodeFailure(ctx, err)
+			case err != nil:
+				trace.SpanFromContext(ctx).AddEvent(EventInvalidParams, trace.WithAttributes(attribute.String("error", err.Error())))
+			default:
+				Annotate(ctx, params)
+			}
+			next.ServeHTTP(wr, req.WithContext(policy.WithParams(ctx, params, err)))
+		})
+	}
+}
+
+// CachedCount works like pagination.CachedCount recording an event with the
+// total and whether it came from the cache on the span of the given context
+func CachedCount(ctx context.Context, cache pagination.CountCache, key string, ttl time.Duration, count func(ctx context.Context) (uint, error)) (uint, error) {
+	cached := true
+	total, err := pagination.CachedCount(ctx, cache, key, ttl, func(ctx context.Context) (uint, error) {
+		cached = false
+		return count(ctx)
+	})
+	span := trace.SpanFromContext(ctx)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return total, err
+	}
+	span.AddEvent(EventCount, trace.WithAttributes(
+		AttrCountTotal.Int64(int64(total)),
+		AttrCountCached.Bool(cached),
+	))
+	return total, nil
+}
+
+// RecordTokenDecodeFailure will record the given error decoding a page token
+// as an event on the span of the given context
+func RecordTokenDecodeFailure(ctx context.Context, err error) {
+	trace.SpanFromContext(ctx).AddEvent(EventTokenDecodeFailure, trace.WithAttributes(attribute.String("error", err.Error())))
+}
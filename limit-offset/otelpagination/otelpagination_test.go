@@ -0,0 +1,129 @@
+package otelpagination_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/otelpagination"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracer() (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	recorder := tracetest.NewSpanRecorder()
+	return recorder, sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+}
+
+func TestAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.Int64("page.limit", 10),
+		attribute.Int64("page.offset", 20),
+		attribute.Int64("page.depth", 3),
+		attribute.String("page.strategy", "offset"),
+	}, otelpagination.Attributes(pagination.Params{Limit: 10, Offset: 20}))
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder, provider := newTracer()
+	handler := otelpagination.Middleware(pagination.Policy{DefaultLimit: 10})(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {}))
+
+	for _, url := range []string{"/sample?page[offset]=30", "/sample?page[offset]=wrong"} {
+		ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+		req := httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+		span.End()
+	}
+
+	spans := recorder.Ended()
+	assert.Equal(t, 2, len(spans))
+	assert.Contains(t, spans[0].Attributes(), attribute.Int64("page.depth", 4))
+	assert.Equal(t, 0, len(spans[0].Events()))
+	assert.Equal(t, 1, len(spans[1].Events()))
+	assert.Equal(t, otelpagination.EventInvalidParams, spans[1].Events()[0].Name)
+}
+
+type parseCountingHook struct {
+	pagination.NopMetricsHook
+	parses int
+}
+
+func (h *parseCountingHook) OnParse(event pagination.ParseEvent) {
+	h.parses++
+}
+
+func TestMiddlewareFindsTheParamsOnce(t *testing.T) {
+	recorder, provider := newTracer()
+	hook := &parseCountingHook{}
+	policy := pagination.Policy{DefaultLimit: 10, Metrics: hook, Tokens: pagination.NewTokenCodec([]byte("0123456789abcdef0123456789abcdef"))}
+	var found []pagination.Params
+	handler := otelpagination.Middleware(policy)(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {
+		params, err := policy.FoundParams(req)
+		if err == nil {
+			found = append(found, params)
+		}
+	}))
+
+	for _, url := range []string{"/sample?page[offset]=30", "/sample?page[token]=garbage"} {
+		ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, url, nil).WithContext(ctx))
+		span.End()
+	}
+
+	assert.Equal(t, 2, hook.parses)
+	if assert.Len(t, found, 1) {
+		assert.Equal(t, uint(30), found[0].Offset)
+	}
+	spans := recorder.Ended()
+	if assert.Equal(t, 1, len(spans[1].Events())) {
+		assert.Equal(t, otelpagination.EventTokenDecodeFailure, spans[1].Events()[0].Name)
+	}
+}
+
+func TestMiddlewareRedactsTheError(t *testing.T) {
+	recorder, provider := newTracer()
+	policy := pagination.Policy{
+		FilterSchema: pagination.FilterSchema{"age": {Type: pagination.FilterInt}},
+		Redactions:   []pagination.Redactor{pagination.RedactFields("age")},
+	}
+	handler := otelpagination.Middleware(policy)(http.HandlerFunc(func(wr http.ResponseWriter, req *http.Request) {}))
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/sample?filter[age]=jane%40example.com", nil).WithContext(ctx))
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	if assert.Equal(t, 1, len(events)) {
+		for _, attr := range events[0].Attributes {
+			assert.NotContains(t, attr.Value.Emit(), "jane")
+		}
+	}
+}
+
+func TestCachedCount(t *testing.T) {
+	recorder, provider := newTracer()
+	cache := pagination.NewMemoryCountCache()
+	count := func(ctx context.Context) (uint, error) { return 42, nil }
+
+	ctx, span := provider.Tracer("test").Start(context.Background(), "request")
+	for i := 0; i < 2; i++ {
+		total, err := otelpagination.CachedCount(ctx, cache, "users", time.Minute, count)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(42), total)
+	}
+	otelpagination.RecordTokenDecodeFailure(ctx, errors.New("wrong token"))
+	span.End()
+
+	events := recorder.Ended()[0].Events()
+	assert.Equal(t, 3, len(events))
+	assert.Equal(t, otelpagination.EventCount, events[0].Name)
+	assert.Contains(t, events[0].Attributes, attribute.Bool("page.count.cached", false))
+	assert.Contains(t, events[1].Attributes, attribute.Bool("page.count.cached", true))
+	assert.Equal(t, otelpagination.EventTokenDecodeFailure, events[2].Name)
+}
@@ -0,0 +1,34 @@
+package pagination
+
+// TotalPages method will compute the number of pages needed for the given
+// total of items, a partial last page counts as a page. It is zero when there
+// are no items or the limit is zero
+func (p Params) TotalPages(total uint) uint {
+	if total == 0 || p.Limit == 0 {
+		return 0
+	}
+	return (total + p.Limit - 1) / p.Limit
+}
+
+// CurrentPage method will compute the number of the page, starting on 1, the
+// params are pointing to. The offsets that aren't a multiple of the limit
+// belong to the page containing them
+func (p Params) CurrentPage() uint {
+	return pageNumber(p)
+}
+
+// ItemRange method will compute the range of items shown on the page for the
+// given total, starting on 1 and both included, like the 21 and 40 of showing
+// 21-40 of 213. The last page ends on the total and both are zero when the
+// page is empty, that is when the offset is beyond the total or the limit is
+// zero
+func (p Params) ItemRange(total uint) (from, to uint) {
+	if p.Limit == 0 || p.Offset >= total {
+		return 0, 0
+	}
+	to = p.Offset + p.Limit
+	if to > total {
+		to = total
+	}
+	return p.Offset + 1, to
+}
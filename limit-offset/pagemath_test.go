@@ -0,0 +1,85 @@
+package pagination_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageMath(t *testing.T) {
+	tests := []struct {
+		name           string
+		params         pagination.Params
+		total          uint
+		wantTotalPages uint
+		wantPage       uint
+		wantFrom       uint
+		wantTo         uint
+	}{
+		{
+			name:           "Middle page",
+			params:         pagination.Params{Limit: 20, Offset: 20},
+			total:          213,
+			wantTotalPages: 11,
+			wantPage:       2,
+			wantFrom:       21,
+			wantTo:         40,
+		},
+		{
+			name:           "Partial last page",
+			params:         pagination.Params{Limit: 20, Offset: 200},
+			total:          213,
+			wantTotalPages: 11,
+			wantPage:       11,
+			wantFrom:       201,
+			wantTo:         213,
+		},
+		{
+			name:           "Exact last page",
+			params:         pagination.Params{Limit: 10, Offset: 10},
+			total:          20,
+			wantTotalPages: 2,
+			wantPage:       2,
+			wantFrom:       11,
+			wantTo:         20,
+		},
+		{
+			name:     "Zero total",
+			params:   pagination.Params{Limit: 10},
+			wantPage: 1,
+		},
+		{
+			name:           "Offset beyond the total",
+			params:         pagination.Params{Limit: 10, Offset: 50},
+			total:          20,
+			wantTotalPages: 2,
+			wantPage:       6,
+		},
+		{
+			name:     "Zero limit",
+			params:   pagination.Params{Offset: 5},
+			total:    20,
+			wantPage: 1,
+		},
+		{
+			name:           "Offset not aligned",
+			params:         pagination.Params{Limit: 10, Offset: 15},
+			total:          30,
+			wantTotalPages: 3,
+			wantPage:       2,
+			wantFrom:       16,
+			wantTo:         25,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantTotalPages, tt.params.TotalPages(tt.total))
+			assert.Equal(t, tt.wantPage, tt.params.CurrentPage())
+			from, to := tt.params.ItemRange(tt.total)
+			assert.Equal(t, tt.wantFrom, from)
+			assert.Equal(t, tt.wantTo, to)
+		})
+	}
+}
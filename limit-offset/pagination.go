@@ -1,8 +1,11 @@
 package pagination
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
 )
@@ -14,150 +17,716 @@ const (
 	ParamPageOffset = "page[offset]"
 	// ParamSortBy is the value for the sorting query
 	ParamSortBy = "sort"
+	// ParamOrderBy is the value for the sorting query when the pair format is
+	// used, see SortFormatPair
+	ParamOrderBy = "order_by"
+	// ParamSeed is the value for the seed of the random sort, see SortRandom
+	ParamSeed = "seed"
+	// ParamPageNumber is the value for the page number parameter of the
+	// JSON:API profile, starting on 1, see PageProfileNumber
+	ParamPageNumber = "page[number]"
+	// ParamPageSize is the value for the page size parameter of the JSON:API
+	// profile, see PageProfileNumber
+	ParamPageSize = "page[size]"
 )
 
+// PageProfile type defines which params are used for moving through the pages
+type PageProfile int
+
+const (
+	// PageProfileOffset uses the page[limit] and page[offset] params, it is the
+	// default profile
+	PageProfileOffset PageProfile = iota
+	// PageProfileNumber uses the page[number] and page[size] params recommended
+	// by JSON:API, they are translated into the limit and offset
+	PageProfileNumber
+)
+
+// paramNames type keeps the names of the params used on the http request for
+// the limit, offset and sort values
+type paramNames struct {
+	limit   string
+	offset  string
+	sort    string
+	orderBy string
+	seed    string
+	number  string
+	size    string
+}
+
+// defaultParamNames are the param names used when paginating a single resource
+var defaultParamNames = paramNames{
+	limit:   ParamPageLimit,
+	offset:  ParamPageOffset,
+	sort:    ParamSortBy,
+	orderBy: ParamOrderBy,
+	seed:    ParamSeed,
+	number:  ParamPageNumber,
+	size:    ParamPageSize,
+}
+
+// rawParams type keeps the raw values of the pagination params found on the
+// http request
+type rawParams struct {
+	limit   string
+	offset  string
+	sort    string
+	orderBy string
+	seed    string
+	number  string
+	size    string
+}
+
 // Paginate will build a new paginated response with the given values
 func Paginate(data []interface{}, baseURL string, params Params) Response {
-	return Response{
-		Data:  buildData(data, params),
-		Links: buildLinks(baseURL, params, len(data)),
+	params.pageServed(len(data))
+	response := Response{
+		Data:   buildData(data, params),
+		Links:  buildLinks(baseURL, params, len(data)),
+		Meta:   params.meta(),
+		stable: params.StableJSON,
 	}
+	params.paginated(context.Background(), response)
+	return response
 }
 
 // Response type encapsulates the information related with a paginated response
 type Response struct {
 	Data  []interface{} `json:"data,omitempty"`
 	Links Links         `json:"links"`
+	// Meta keeps the extra information about the response, like the hints for
+	// the clients
+	Meta map[string]interface{} `json:"meta,omitempty"`
+
+	// stable is true when the data and the links are always encoded, see
+	// Params.StableJSON
+	stable bool
 }
 
 // Links type encapsulates the information about how we can move through the
-// different pages on a paginated reponse
+// different pages on a paginated reponse. The params of the links always have
+// the same order, so the links are byte-identical across releases: the limit
+// and the offset (or the page number and size), the sort and the seed, the
+// filters sorted like Filters.Encode, the rest of params and the signature of
+// the LinkSigner. CanonicalQuery applies the same order to any query
 type Links struct {
-	First string `json:"first,omitempty"`
-	Prev  string `json:"prev,omitempty"`
-	Next  string `json:"next,omitempty"`
+	First string `json:"first,omitempty" example:"/users?page[limit]=10&page[offset]=0"`
+	Prev  string `json:"prev,omitempty" example:"/users?page[limit]=10&page[offset]=10"`
+	Next  string `json:"next,omitempty" example:"/users?page[limit]=10&page[offset]=30"`
 	Last  string `json:"last,omitempty"`
 }
 
-// Sort type encapsulates the information needed for order and sort a query, the
-// field will have the name column to be sorted and the order will have the value
-// of asc or desc
-type Sort struct {
-	Field string
-	Order string
-}
-
 // Params type encapsulates the information gathered from the http request
 type Params struct {
 	Limit  uint
 	Offset uint
 	Sort   []Sort
+	// TieBreaker is a unique column appended to the ORDER BY of the query when
+	// the sort doesn't include it already, guaranteeing a stable order between
+	// pages. It is never added to the links
+	TieBreaker string
+	// Dialect is the SQL dialect used for the parts of the query that aren't
+	// standard, like the collations
+	Dialect Dialect
+	// Seed is the seed of the random sort, it is kept on the links so all the
+	// pages use the same random order, see SortRandom
+	Seed string
+	// SortFormat is the format used for the sort param on the links, when the
+	// params come from a request it is the format used by the client
+	SortFormat SortFormat
+	// Profile is the profile of the params used on the links, when the params
+	// come from a request it is the profile used by the client
+	Profile PageProfile
+	// Filters are the filters found on the request, they are kept on the links
+	// using the canonical serialization of Filters.Encode
+	Filters Filters
+	// FilterFormat is the format used for the filters on the links, when the
+	// params come from a policy it is the format accepted by the policy
+	FilterFormat FilterFormat
+	// ExactCount is true when the total of items is known, the Query fetches
+	// the real limit instead of the extra item used for finding the last page
+	// and the pages are built with PaginateTotal
+	ExactCount bool
+	// StableJSON makes the responses always encode the data, as an empty
+	// array for the empty pages, and the four links, as null when missing,
+	// for the strict clients expecting a stable schema
+	StableJSON bool
+	// DisableOverFetch makes the Query fetch the limit as it is, without the
+	// extra item, for the callers fetching it on their own SQL builders. The
+	// pages only have the Next link when the data has the extra item
+	DisableOverFetch bool
+
+	// sortValue keeps the sort value found on the request, it is reused by
+	// SortValue as long as it still represents the Sort slice
+	sortValue string
+	// metrics keeps the hook of the policy that found the params, it is called
+	// when the params are paginated
+	metrics MetricsHook
+	// deepOffset is true when the offset is beyond the MaxOffsetDepth of the
+	// policy, deepOffsetHint when the hint should be added to the meta
+	deepOffset     bool
+	deepOffsetHint bool
+	// debug is true when the debug meta should be added to the response
+	debug bool
+	// tokens is the codec used for the links when the policy uses page tokens,
+	// correlationID the ID of the request embedded on them and scope the
+	// principal they are bound to
+	tokens        *TokenCodec
+	correlationID string
+	scope         string
+	// redactions are the rules applied to the values of the debug meta
+	redactions []Redactor
+	// offsets is how the offsets are written on the links and signer signs
+	// them when the policy has a LinkSigner
+	offsets offsetEncoding
+	signer  *LinkSigner
+	// defaults are the default limit and offset omitted from the links
+	defaults linkDefaults
+	// unlimited is true when the params fetch the full result set, see
+	// Unlimited
+	unlimited bool
+	// onPaginate and resource are the OnPaginate callback and the Resource of
+	// the policy
+	onPaginate func(ctx context.Context, info PaginateInfo)
+	resource   string
+	// names are the param names used on the links, the default ones when it
+	// is empty, see New
+	names paramNames
+}
+
+// paramNames method will answer back the param names used on the links
+func (p Params) paramNames() paramNames {
+	return p.names.orDefault()
+}
+
+// orDefault method will answer back the default param names when the names
+// are empty
+func (n paramNames) orDefault() paramNames {
+	if n == (paramNames{}) {
+		return defaultParamNames
+	}
+	return n
 }
 
 // SortURL will convert the sort slice into a URL parameters
 func (p Params) SortURL() (sortParams string) {
 	if len(p.Sort) > 0 {
-		sortParams = fmt.Sprintf("%s=", ParamSortBy)
-		tmp := []string{}
-		for _, s := range p.Sort {
-			tmp = append(tmp, fmt.Sprintf("%s.%s", s.Field, s.Order))
+		names := p.paramNames()
+		name, value := p.sortParam(names)
+		b := appendQueryValue([]byte(name+"="), value)
+		if p.Seed != "" {
+			b = append(b, "&"+names.seed+"="+url.QueryEscape(p.Seed)...)
 		}
-		sortParams += strings.Join(tmp, ",")
+		sortParams = string(b)
 	}
 	return sortParams
 }
 
+// sortParam method will return the name and the value of the param used for
+// the sort on the links, that depends on the sort format
+func (p Params) sortParam(names paramNames) (name, value string) {
+	if p.SortFormat == SortFormatPair {
+		return names.orderBy, p.SortValue()
+	}
+	return names.sort, p.SortValue()
+}
+
+// SortValue will return the value of the sort parameter that represents the
+// sort slice, something like name.asc,created_at.desc (or the value of the
+// order_by param when the pair format is used). When the params come from
+// FindParams the value found on the request is reused instead of serializing
+// the sort slice again, unless the slice was modified after that
+func (p Params) SortValue() string {
+	if p.sortValue != "" && sortMatches(p.Sort, p.sortValue, p.SortFormat) {
+		return p.sortValue
+	}
+	return string(appendSortValue(nil, p.Sort, p.SortFormat))
+}
+
 // Query method will build the part of the SQL query that should be attached to
-// the end of the parent query
+// the end of the parent query, surrounded by spaces. Prefer Compose, which
+// builds the clauses on the standard order without the stray whitespace
 func (p Params) Query() string {
-	// This p.Limit + 1 is the approach for know about the last page without having
-	// the extra count query
-	query := fmt.Sprintf(" LIMIT %d OFFSET %d ", p.Limit+1, p.Offset)
-	if len(p.Sort) > 0 {
-		query += "ORDER BY "
-		tmp := []string{}
-		for _, s := range p.Sort {
-			tmp = append(tmp, fmt.Sprintf("%s %s", s.Field, s.Order))
-		}
-		query += strings.Join(tmp, ",")
+	query := " "
+	if !p.unlimited {
+		query = fmt.Sprintf(" LIMIT %d OFFSET %d ", p.EffectiveLimit(), p.Offset)
+	}
+	if orderBy := p.OrderBy(); orderBy != "" {
+		query += "ORDER BY " + orderBy
 	}
 	return query
 }
 
+// EffectiveLimit method will answer back the limit fetched by the Query, that
+// is the limit plus the extra item used for knowing about the last page
+// without having the extra count query. It is the limit as it is with the
+// ExactCount, where the total is known already, or the DisableOverFetch, and
+// zero when the params are Unlimited
+func (p Params) EffectiveLimit() uint {
+	if p.unlimited {
+		return 0
+	}
+	if p.ExactCount || p.DisableOverFetch {
+		return p.Limit
+	}
+	return p.Limit + 1
+}
+
+// OrderBy method will build the columns of the ORDER BY clause for the sort,
+// like name asc,created_at desc, including the collations and the tie
+// breaker. It is empty when there is nothing to sort by
+func (p Params) OrderBy() string {
+	tmp := []string{}
+	for _, s := range p.Sort {
+		collation := ""
+		if s.Collation != "" {
+			collation = p.Dialect.collate(s.Collation)
+		}
+		for _, column := range s.columns() {
+			tmp = append(tmp, fmt.Sprintf("%s%s %s", column, collation, s.direction()))
+		}
+	}
+	if p.needsTieBreaker() {
+		order := OrderAsc
+		if len(p.Sort) > 0 {
+			order = p.Sort[len(p.Sort)-1].direction()
+		}
+		tmp = append(tmp, fmt.Sprintf("%s %s", p.TieBreaker, order))
+	}
+	return strings.Join(tmp, ",")
+}
+
+// needsTieBreaker method will check if the tie breaker column should be added
+// to the query, that is when there is one and it isn't sorted already
+func (p Params) needsTieBreaker() bool {
+	if p.TieBreaker == "" {
+		return false
+	}
+	for _, s := range p.Sort {
+		for _, column := range s.columns() {
+			if column == p.TieBreaker {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// filterQuery method will encode the filters for the links using the filter
+// format of the params
+func (p Params) filterQuery() string {
+	if p.FilterFormat == FilterFormatRSQL {
+		return p.Filters.EncodeRSQL()
+	}
+	return p.Filters.Encode()
+}
+
 // FindParams will find for the pagination params on the request otherwise will
 // answer back with the given defaults
 func FindParams(req *http.Request, defaultOffset, defaultLimit uint) (Params, error) {
-	params := Params{
-		Limit:  defaultLimit,
-		Offset: defaultOffset,
+	var params Params
+	err := FindParamsInto(req, defaultOffset, defaultLimit, &params)
+	return params, err
+}
+
+// FindParamsInto works like FindParams but fills the given params instead of
+// building a new one, the backing array of params.Sort is reused so it can be
+// combined with AcquireParams for avoiding allocations on hot endpoints
+func FindParamsInto(req *http.Request, defaultOffset, defaultLimit uint, params *Params) error {
+	return Policy{
+		DefaultOffset: defaultOffset,
+		DefaultLimit:  defaultLimit,
+	}.FindParamsInto(req, params)
+}
+
+// findParams function will fill the given params, that already contains the
+// defaults, with the values found on the raw query for the given param names
+// and accepting the given sort format. The seed is only accepted when it is a
+// number, as the ones generated by NewSeed
+func findParams(rawQuery string, names paramNames, format SortFormat, params *Params) error {
+	params.Sort = params.Sort[:0]
+	params.sortValue = ""
+	raw := lookupParams(rawQuery, names)
+	if raw.seed != "" {
+		if _, err := parseNumber(names.seed, raw.seed); err != nil {
+			return err
+		}
 	}
-	limit := req.URL.Query().Get(ParamPageLimit)
-	offset := req.URL.Query().Get(ParamPageOffset)
-	sort := req.URL.Query().Get(ParamSortBy)
+	params.Seed = raw.seed
 
-	if limit != "" {
-		convertedLimit, err := strconv.ParseUint(limit, 10, 32)
+	params.Profile = PageProfileOffset
+	if raw.limit == "" && raw.offset == "" && (raw.number != "" || raw.size != "") {
+		if err := findPageNumber(raw, names, params); err != nil {
+			return err
+		}
+	}
+
+	if raw.limit != "" {
+		convertedLimit, err := parseNumber(names.limit, raw.limit)
 		if err != nil {
-			return params, err
+			return err
 		}
-		params.Limit = uint(convertedLimit)
+		params.Limit = convertedLimit
 	}
 
-	if offset != "" {
-		convertedOffset, err := strconv.ParseUint(offset, 10, 32)
+	if raw.offset != "" {
+		convertedOffset, err := parseNumber(names.offset, raw.offset)
 		if err != nil {
-			return params, err
-		}
-		params.Offset = uint(convertedOffset)
-	}
-
-	if sort != "" {
-		sortFields := strings.Split(sort, ",")
-		for _, field := range sortFields {
-			// The format of sort and order values shoulde be something
-			// like this name.asc or name.desc
-			v := strings.Split(field, ".")
-			if len(v) == 2 {
-				params.Sort = append(params.Sort, Sort{
-					Field: v[0],
-					Order: v[1],
-				})
-			}
+			return err
+		}
+		params.Offset = convertedOffset
+	}
+
+	rawSort := raw.sort
+	switch {
+	case format == SortFormatPair || format == SortFormatAuto && raw.sort == "":
+		rawSort = raw.orderBy
+		params.Sort, params.SortFormat = appendOrderBy(params.Sort, raw.orderBy)
+	default:
+		params.Sort, params.SortFormat = appendSort(params.Sort, raw.sort, format)
+	}
+	// Keep the raw value only when we didn't drop mallformed fields, otherwise
+	// it doesn't represent the sort slice anymore
+	if sortMatches(params.Sort, rawSort, params.SortFormat) {
+		params.sortValue = rawSort
+	}
+
+	return nil
+}
+
+// findPageNumber function will translate the page number and size found on the
+// raw params into the limit and offset of the given params
+func findPageNumber(raw rawParams, names paramNames, params *Params) error {
+	params.Profile = PageProfileNumber
+	if raw.size != "" {
+		size, err := parseNumber(names.size, raw.size)
+		if err != nil {
+			return err
+		}
+		params.Limit = size
+	}
+	if raw.number != "" {
+		number, err := parseNumber(names.number, raw.number)
+		if err != nil {
+			return err
+		}
+		if number == 0 {
+			return errPageNumberZero
 		}
+		params.Offset = (number - 1) * params.Limit
 	}
+	return nil
+}
 
-	return params, nil
+// parseNumber function will parse the value of the given numeric param, the
+// negative values and the ones that don't fit on 32 bits are refused with a
+// *NumberError, the rest of problems with the error of strconv.ParseUint
+func parseNumber(name, value string) (uint, error) {
+	n, err := strconv.ParseUint(value, 10, 32)
+	if err == nil {
+		return uint(n), nil
+	}
+	if errors.Is(err, strconv.ErrRange) {
+		return 0, &NumberError{Param: name, Value: value, Err: ErrValueTooLarge}
+	}
+	if digits, ok := strings.CutPrefix(value, "-"); ok {
+		if _, digitsErr := strconv.ParseUint(digits, 10, 64); digitsErr == nil || errors.Is(digitsErr, strconv.ErrRange) {
+			return 0, &NumberError{Param: name, Value: value, Err: ErrNegativeValue}
+		}
+	}
+	return 0, err
+}
+
+// errPageNumberZero is the error returned for the page number 0, as the pages
+// start on 1
+var errPageNumberZero = errors.New("pagination: the page number starts on 1")
+
+// lookupParams function will scan the raw query once looking for the pagination
+// params, it follows the same rules as url.ParseQuery so the first occurrence
+// of each param wins and malformed pairs are ignored, but without allocating
+// the whole url.Values map
+func lookupParams(rawQuery string, names paramNames) (raw rawParams) {
+	var foundLimit, foundOffset, foundSort, foundOrderBy, foundSeed, foundNumber, foundSize bool
+	for rawQuery != "" && !(foundLimit && foundOffset && foundSort && foundOrderBy && foundSeed && foundNumber && foundSize) {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		key, ok := unescapeQuery(key)
+		if !ok {
+			continue
+		}
+		var found *bool
+		var dst *string
+		switch key {
+		case names.limit:
+			found, dst = &foundLimit, &raw.limit
+		case names.offset:
+			found, dst = &foundOffset, &raw.offset
+		case names.sort:
+			found, dst = &foundSort, &raw.sort
+		case names.orderBy:
+			found, dst = &foundOrderBy, &raw.orderBy
+		case names.seed:
+			found, dst = &foundSeed, &raw.seed
+		case names.number:
+			found, dst = &foundNumber, &raw.number
+		case names.size:
+			found, dst = &foundSize, &raw.size
+		default:
+			continue
+		}
+		if *found {
+			continue
+		}
+		if value, ok = unescapeQuery(value); ok {
+			*found, *dst = true, value
+		}
+	}
+	return raw
+}
+
+// lookupParam function will look for the first occurrence of a single param on
+// the raw query, following the same rules as lookupParams
+func lookupParam(rawQuery, name string) string {
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		if pair == "" || strings.Contains(pair, ";") {
+			continue
+		}
+		key, value, _ := strings.Cut(pair, "=")
+		if key, ok := unescapeQuery(key); !ok || key != name {
+			continue
+		}
+		if value, ok := unescapeQuery(value); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// aliasQuery function will translate the params of the given raw query named
+// with an alias into the given names, the pairs of names and aliases follow
+// each other. The translated params are placed before the raw query, so they
+// are found first, and the rest of it is kept so the filters are still found
+func aliasQuery(rawQuery string, namesAndAliases ...string) string {
+	var b strings.Builder
+	for i := 0; i+1 < len(namesAndAliases); i += 2 {
+		if value := lookupParam(rawQuery, namesAndAliases[i+1]); value != "" {
+			b.WriteString(url.QueryEscape(namesAndAliases[i]))
+			b.WriteByte('=')
+			b.WriteString(url.QueryEscape(value))
+			b.WriteByte('&')
+		}
+	}
+	b.WriteString(rawQuery)
+	return b.String()
+}
+
+// withoutPageParams function will remove from the given raw query the params
+// locating the page, the limit, offset, number, size and token, for the
+// presets where the page is only located by their own params, like the page
+// tokens of the AIP-158
+func withoutPageParams(rawQuery string) string {
+	names := defaultParamNames
+	var b strings.Builder
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		key, _, _ := strings.Cut(pair, "=")
+		if key, ok := unescapeQuery(key); pair == "" || ok && (key == names.limit ||
+			key == names.offset || key == names.number || key == names.size || key == ParamPageToken) {
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteByte('&')
+		}
+		b.WriteString(pair)
+	}
+	return b.String()
+}
+
+// unescapeQuery function will only pay the unescape cost when the given value
+// contains escaped characters
+func unescapeQuery(s string) (string, bool) {
+	if !strings.ContainsAny(s, "%+") {
+		return s, true
+	}
+	unescaped, err := url.QueryUnescape(s)
+	return unescaped, err == nil
 }
 
 // buildLinks function will build the links for navigate through the pages
 // using the given criteria
 func buildLinks(baseURL string, params Params, dataSize int) (links Links) {
-	sortURL := params.SortURL()
-	links.First = fmt.Sprintf("%s?%s=%d&%s=%d", baseURL, ParamPageLimit, params.Limit, ParamPageOffset, 0)
-	if sortURL != "" {
-		links.First += fmt.Sprintf("&%s", sortURL)
+	return buildLinksWithNames(baseURL, params.paramNames(), params, dataSize)
+}
+
+// buildLinksWithNames function will build the links like buildLinks does but
+// using the given param names
+func buildLinksWithNames(baseURL string, names paramNames, params Params, dataSize int) (links Links) {
+	return buildLinksWithQuery(baseURL, names, params, params.filterQuery(), dataSize)
+}
+
+// buildLinksWithQuery function will build the links like buildLinksWithNames
+// does but appending the given encoded query, like the filters, to each link
+func buildLinksWithQuery(baseURL string, names paramNames, params Params, query string, dataSize int) (links Links) {
+	if params.unlimited {
+		return links
 	}
+	buf := acquireLinkBuffer()
+	defer releaseLinkBuffer(buf)
 
+	link := params.linker(buf, baseURL, names, query)
+	links.First = link(0)
 	if uint(dataSize) > params.Limit {
-		links.Next = fmt.Sprintf("%s?%s=%d&%s=%d", baseURL, ParamPageLimit, params.Limit, ParamPageOffset, params.Offset+params.Limit)
-		if sortURL != "" {
-			links.Next += fmt.Sprintf("&%s", sortURL)
-		}
+		links.Next = link(params.Offset + params.Limit)
 	}
 	if params.Offset > 0 {
-		links.Prev = fmt.Sprintf("%s?%s=%d&%s=%d", baseURL, ParamPageLimit, params.Limit, ParamPageOffset, params.Offset-params.Limit)
-		if sortURL != "" {
-			links.Prev += fmt.Sprintf("&%s", sortURL)
-		}
+		links.Prev = link(prevOffset(params))
 	}
 	return links
 }
 
+// linker method will answer back a function building the link of the page on
+// the given offset, the links carry a page token or a signature when the
+// params use them
+func (p Params) linker(buf *[]byte, baseURL string, names paramNames, query string) func(offset uint) string {
+	sortName, sortValue := p.sortParam(names)
+	return func(offset uint) string {
+		l := buildLink(buf, baseURL, names, p.Profile, p.Limit, offset, p.offsets, p.defaults, sortName, sortValue, p.Seed, query)
+		if p.tokens != nil {
+			_, query, _ := strings.Cut(l, "?")
+			l = p.tokens.link(baseURL, query, p.correlationID, p.scope)
+		}
+		if p.signer != nil {
+			l = p.signer.Sign(l)
+		}
+		return l
+	}
+}
+
+// selfQuery method will answer back the canonical query of the link to the page
+// of the params carrying the given encoded query, without the page token nor
+// the signature
+func (p Params) selfQuery(query string) string {
+	buf := acquireLinkBuffer()
+	defer releaseLinkBuffer(buf)
+
+	names := p.paramNames()
+	sortName, sortValue := p.sortParam(names)
+	link := buildLink(buf, "", names, p.Profile, p.Limit, p.Offset, p.offsets, p.defaults, sortName, sortValue, p.Seed, query)
+	return canonicalQuery(strings.TrimPrefix(link, "?"), names)
+}
+
+// buildLink function will write a single page link into the given buffer and
+// return it as a string, the buffer is reset before writing. The extra query,
+// like the filters, is given already encoded. The limit and offset equal to
+// the given defaults are skipped when they should be omitted
+func buildLink(buf *[]byte, baseURL string, names paramNames, profile PageProfile, limit, offset uint, offsets offsetEncoding, defaults linkDefaults, sortName, sortValue, seed, query string) string {
+	b := append((*buf)[:0], baseURL...)
+	b = append(b, '?')
+	start := len(b)
+	if profile == PageProfileNumber {
+		// The size is kept when the number is omitted, so the clients keep
+		// using the page number profile
+		omitNumber := defaults.omitOffset(offset)
+		if !omitNumber {
+			b = append(b, names.number...)
+			b = append(b, '=')
+			b = strconv.AppendUint(b, uint64(pageNumber(Params{Limit: limit, Offset: offset})), 10)
+		}
+		if omitNumber || !defaults.omitLimit(limit) {
+			b = appendSeparator(b, start)
+			b = append(b, names.size...)
+			b = append(b, '=')
+			b = strconv.AppendUint(b, uint64(limit), 10)
+		}
+	} else {
+		if !defaults.omitLimit(limit) {
+			b = append(b, names.limit...)
+			b = append(b, '=')
+			b = strconv.AppendUint(b, uint64(limit), 10)
+		}
+		if !defaults.omitOffset(offset) {
+			b = appendSeparator(b, start)
+			b = append(b, names.offset...)
+			b = append(b, '=')
+			b = offsets.append(b, offset)
+		}
+	}
+	if sortValue != "" {
+		b = appendSeparator(b, start)
+		b = append(b, sortName...)
+		b = append(b, '=')
+		b = appendQueryValue(b, sortValue)
+		if seed != "" {
+			b = append(b, '&')
+			b = append(b, names.seed...)
+			b = append(b, '=')
+			b = append(b, url.QueryEscape(seed)...)
+		}
+	}
+	if query != "" {
+		b = appendSeparator(b, start)
+		b = append(b, query...)
+	}
+	if len(b) == start {
+		b = b[:start-1]
+	}
+	*buf = b
+	return string(b)
+}
+
+// appendSeparator function will append the separator between the params into
+// the buffer unless nothing was written after the given start of the query
+func appendSeparator(b []byte, start int) []byte {
+	if len(b) == start {
+		return b
+	}
+	return append(b, '&')
+}
+
+// linkDefaults type keeps the default limit and offset of the policy omitted
+// from the links, see Policy.OmitDefaultParams
+type linkDefaults struct {
+	omit   bool
+	limit  uint
+	offset uint
+}
+
+// omitLimit method will answer back if the given limit should be omitted
+func (d linkDefaults) omitLimit(limit uint) bool {
+	return d.omit && limit == d.limit
+}
+
+// omitOffset method will answer back if the given offset should be omitted
+func (d linkDefaults) omitOffset(offset uint) bool {
+	return d.omit && offset == d.offset
+}
+
+// appendQueryValue function will append the given value into the buffer
+// escaping the spaces, the rest of the value is written as it is
+func appendQueryValue(b []byte, value string) []byte {
+	for {
+		i := strings.IndexByte(value, ' ')
+		if i < 0 {
+			return append(b, value...)
+		}
+		b = append(b, value[:i]...)
+		b = append(b, '+')
+		value = value[i+1:]
+	}
+}
+
 // buildData function will handle the situation of deal with an extra limit for
 // avoid extra count query, so in case we should remove the last item we will
 // remove it
 func buildData(data []interface{}, params Params) []interface{} {
-	if uint(len(data)) > params.Limit {
+	if !params.unlimited && uint(len(data)) > params.Limit {
 		data = data[:len(data)-1]
 	}
 	return data
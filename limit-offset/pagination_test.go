@@ -1,6 +1,7 @@
 package pagination_test
 
 import (
+	"errors"
 	"net/http"
 	"testing"
 
@@ -42,6 +43,136 @@ func TestFindOffSetAndLimitParams(t *testing.T) {
 	assert.Equal(t, 0, len(defaultValueParams.Sort))
 }
 
+func TestFindParamsRawQueryScan(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want pagination.Params
+	}{
+		{
+			name: "Should read escaped param names",
+			url:  "app.quicka.co/api/sample?page%5Blimit%5D=5&page%5Boffset%5D=10&sort=name.asc",
+			want: pagination.Params{
+				Limit:  5,
+				Offset: 10,
+				Sort:   []pagination.Sort{{Field: "name", Order: "asc"}},
+			},
+		},
+		{
+			name: "Should take the first occurrence of each param",
+			url:  "app.quicka.co/api/sample?page[limit]=5&page[limit]=50&page[offset]=10&page[offset]=100",
+			want: pagination.Params{
+				Limit:  5,
+				Offset: 10,
+			},
+		},
+		{
+			name: "Should ignore malformed pairs",
+			url:  "app.quicka.co/api/sample?page[limit]=%zz&page[limit]=5&other=1;2&&page[offset]=10",
+			want: pagination.Params{
+				Limit:  5,
+				Offset: 10,
+			},
+		},
+		{
+			name: "Should unescape the values",
+			url:  "app.quicka.co/api/sample?sort=name.asc%2Ccreated_at.desc",
+			want: pagination.Params{
+				Sort: []pagination.Sort{
+					{Field: "name", Order: "asc"},
+					{Field: "created_at", Order: "desc"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := pagination.FindParams(req, 0, 0)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want.Limit, params.Limit)
+			assert.Equal(t, tt.want.Offset, params.Offset)
+			assert.Equal(t, tt.want.Sort, params.Sort)
+		})
+	}
+}
+
+func TestFindPageNumberParams(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		wantLimit   uint
+		wantOffset  uint
+		wantProfile pagination.PageProfile
+		wantErr     bool
+		wantNext    string
+	}{
+		{
+			name:        "Number and size",
+			url:         "app.quicka.co/api/sample?page[number]=3&page[size]=5",
+			wantLimit:   5,
+			wantOffset:  10,
+			wantProfile: pagination.PageProfileNumber,
+			wantNext:    "/sample?page[number]=4&page[size]=5",
+		},
+		{
+			name:        "Only the number uses the default size",
+			url:         "app.quicka.co/api/sample?page[number]=2",
+			wantLimit:   4,
+			wantOffset:  4,
+			wantProfile: pagination.PageProfileNumber,
+			wantNext:    "/sample?page[number]=3&page[size]=4",
+		},
+		{
+			name:        "Only the size starts on the first page",
+			url:         "app.quicka.co/api/sample?page[size]=10",
+			wantLimit:   10,
+			wantProfile: pagination.PageProfileNumber,
+			wantNext:    "/sample?page[number]=2&page[size]=10",
+		},
+		{
+			name:        "Limit and offset win",
+			url:         "app.quicka.co/api/sample?page[number]=3&page[size]=5&page[limit]=2&page[offset]=6",
+			wantLimit:   2,
+			wantOffset:  6,
+			wantProfile: pagination.PageProfileOffset,
+			wantNext:    "/sample?page[limit]=2&page[offset]=8",
+		},
+		{
+			name:    "The page number starts on 1",
+			url:     "app.quicka.co/api/sample?page[number]=0",
+			wantErr: true,
+		},
+		{
+			name:    "Wrong page size",
+			url:     "app.quicka.co/api/sample?page[size]=big",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			params, err := pagination.FindParams(req, 0, 4)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+			assert.Equal(t, tt.wantOffset, params.Offset)
+			assert.Equal(t, tt.wantProfile, params.Profile)
+
+			links := pagination.Paginate(make([]interface{}, tt.wantLimit+1), "/sample", params).Links
+			assert.Equal(t, tt.wantNext, links.Next)
+		})
+	}
+}
+
 func TestFindSortAndOrderParams(t *testing.T) {
 	tests := []struct {
 		name string
@@ -164,6 +295,83 @@ func TestQueryBuilder(t *testing.T) {
 			},
 			want: " LIMIT 3 OFFSET 34 ORDER BY last_name asc,created_at desc",
 		},
+		{
+			name: "Tie breaker without sort",
+			args: pagination.Params{
+				Limit:      uint(10),
+				TieBreaker: "id",
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY id asc",
+		},
+		{
+			name: "Tie breaker following the last order",
+			args: pagination.Params{
+				Limit:      uint(10),
+				TieBreaker: "id",
+				Sort: []pagination.Sort{
+					{
+						Field: "last_name",
+						Order: "asc",
+					},
+					{
+						Field: "created_at",
+						Order: "desc",
+					},
+				},
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY last_name asc,created_at desc,id desc",
+		},
+		{
+			name: "Tie breaker already sorted",
+			args: pagination.Params{
+				Limit:      uint(10),
+				TieBreaker: "id",
+				Sort: []pagination.Sort{
+					{
+						Field: "id",
+						Order: "desc",
+					},
+				},
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY id desc",
+		},
+		{
+			name: "Tie breaker never copies the client order",
+			args: pagination.Params{
+				Limit:      uint(10),
+				TieBreaker: "id",
+				Sort: []pagination.Sort{
+					{
+						Field: "last_name",
+						Order: "DESC; DROP TABLE users--",
+					},
+				},
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY last_name asc,id asc",
+		},
+		{
+			name: "Tie breaker following an upper case order",
+			args: pagination.Params{
+				Limit:      uint(10),
+				TieBreaker: "id",
+				Sort: []pagination.Sort{
+					{
+						Field: "last_name",
+						Order: "DESC",
+					},
+				},
+			},
+			want: " LIMIT 11 OFFSET 0 ORDER BY last_name desc,id desc",
+		},
+		{
+			name: "Over fetch disabled",
+			args: pagination.Params{
+				Limit:            uint(10),
+				Offset:           uint(20),
+				DisableOverFetch: true,
+			},
+			want: " LIMIT 10 OFFSET 20 ",
+		},
 	}
 
 	for _, tt := range tests {
@@ -173,6 +381,26 @@ func TestQueryBuilder(t *testing.T) {
 	}
 }
 
+func TestEffectiveLimit(t *testing.T) {
+	assert.Equal(t, uint(11), pagination.Params{Limit: 10}.EffectiveLimit())
+	assert.Equal(t, uint(10), pagination.Params{Limit: 10, DisableOverFetch: true}.EffectiveLimit())
+	assert.Equal(t, uint(10), pagination.Params{Limit: 10, ExactCount: true}.EffectiveLimit())
+
+	req, _ := http.NewRequest(http.MethodGet, "/users?page[limit]=10", nil)
+	params, err := pagination.Policy{DisableOverFetch: true}.FindParams(req)
+	assert.NoError(t, err)
+	assert.Equal(t, uint(10), params.EffectiveLimit())
+	response := pagination.Paginate([]interface{}{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, "/users", params)
+	assert.Len(t, response.Data, 10)
+	assert.Empty(t, response.Links.Next)
+}
+
+func TestOrderBy(t *testing.T) {
+	params := pagination.Params{Sort: []pagination.Sort{{Field: "name", Order: "asc"}, {Field: "created_at", Order: "desc"}}, TieBreaker: "id"}
+	assert.Equal(t, "name asc,created_at desc,id desc", params.OrderBy())
+	assert.Empty(t, pagination.Params{}.OrderBy())
+}
+
 func TestSortURLMethod(t *testing.T) {
 	tests := []struct {
 		name string
@@ -221,6 +449,35 @@ func TestSortURLMethod(t *testing.T) {
 	}
 }
 
+func TestSortValueMethod(t *testing.T) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?sort=name.asc,created_at.desc,asc(muz)",
+		nil,
+	)
+	assert.Nil(t, err)
+	params, err := pagination.FindParams(req, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "name.asc,created_at.desc", params.SortValue())
+	assert.Equal(t, "sort=name.asc,created_at.desc", params.SortURL())
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=name.asc", nil)
+	assert.Nil(t, err)
+	params, err = pagination.FindParams(req, 0, 0)
+	assert.Nil(t, err)
+	assert.Equal(t, "name.asc", params.SortValue())
+
+	params.Sort[0].Order = "desc"
+	assert.Equal(t, "name.desc", params.SortValue())
+
+	params.Sort = append(params.Sort, pagination.Sort{Field: "id", Order: "asc"})
+	assert.Equal(t, "name.desc,id.asc", params.SortValue())
+
+	params.Sort = nil
+	assert.Equal(t, "", params.SortValue())
+	assert.Equal(t, "", params.SortURL())
+}
+
 func TestPaginatedResponseBuilder(t *testing.T) {
 	type testArgs struct {
 		data    []string
@@ -393,3 +650,99 @@ func TestPaginatedDataSize(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkFindParams(b *testing.B) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?filter=active&page[limit]=5&page[offset]=10&sort=name.asc,created_at.desc",
+		nil,
+	)
+	assert.Nil(b, err)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := pagination.FindParams(req, 0, 10); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkPaginateFoundParams(b *testing.B) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?page[limit]=10&page[offset]=20&sort=name.asc,created_at.desc",
+		nil,
+	)
+	assert.Nil(b, err)
+	params, err := pagination.FindParams(req, 0, 10)
+	assert.Nil(b, err)
+	data := make([]interface{}, 11)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pagination.Paginate(data, "/sample", params)
+	}
+}
+
+func TestFindParamsNumberErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		url         string
+		wantErr     error
+		wantParam   string
+		wantValue   string
+		wantMessage string
+	}{
+		{
+			name:        "Negative limit",
+			url:         "/users?page[limit]=-5",
+			wantErr:     pagination.ErrNegativeValue,
+			wantParam:   "page[limit]",
+			wantValue:   "-5",
+			wantMessage: `pagination: invalid page[limit]="-5": the value can't be negative`,
+		},
+		{
+			name:        "Offset too large",
+			url:         "/users?page[offset]=99999999999999",
+			wantErr:     pagination.ErrValueTooLarge,
+			wantParam:   "page[offset]",
+			wantValue:   "99999999999999",
+			wantMessage: `pagination: invalid page[offset]="99999999999999": the value is bigger than 4294967295`,
+		},
+		{
+			name:        "Negative page number",
+			url:         "/users?page[number]=-2&page[size]=10",
+			wantErr:     pagination.ErrNegativeValue,
+			wantParam:   "page[number]",
+			wantValue:   "-2",
+			wantMessage: `pagination: invalid page[number]="-2": the value can't be negative`,
+		},
+		{
+			name:        "Page size too large",
+			url:         "/users?page[size]=5000000000",
+			wantErr:     pagination.ErrValueTooLarge,
+			wantParam:   "page[size]",
+			wantValue:   "5000000000",
+			wantMessage: `pagination: invalid page[size]="5000000000": the value is bigger than 4294967295`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			_, err := pagination.FindParams(req, 0, 10)
+			assert.ErrorIs(t, err, tt.wantErr)
+			var numberErr *pagination.NumberError
+			if assert.ErrorAs(t, err, &numberErr) {
+				assert.Equal(t, tt.wantParam, numberErr.Param)
+				assert.Equal(t, tt.wantValue, numberErr.Value)
+			}
+			assert.EqualError(t, err, tt.wantMessage)
+		})
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/users?page[limit]=-many", nil)
+	_, err := pagination.FindParams(req, 0, 10)
+	var numberErr *pagination.NumberError
+	assert.False(t, errors.As(err, &numberErr))
+}
@@ -0,0 +1,79 @@
+package paginationtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Update rewrites the golden files with the snapshots instead of comparing
+// them, it is enabled with the PAGINATIONTEST_UPDATE environment variable
+//
+//	PAGINATIONTEST_UPDATE=1 go test ./...
+var Update = os.Getenv("PAGINATIONTEST_UPDATE") != ""
+
+// Snapshot will render the envelope of the given paginated response, like a
+// pagination.Response or the page of any other encoder, as a canonical string
+// for the golden files. The response is encoded as JSON with the keys sorted
+// and the arrays of the first level, the data, elided into their number of
+// items, so the snapshot only changes when the links or the meta change
+func Snapshot(resp interface{}) (string, error) {
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return "", err
+	}
+	var envelope interface{}
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return "", err
+	}
+	if fields, ok := envelope.(map[string]interface{}); ok {
+		for key, value := range fields {
+			if items, ok := value.([]interface{}); ok {
+				fields[key] = fmt.Sprintf("<%d items>", len(items))
+			}
+		}
+	}
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(envelope); err != nil {
+		return "", err
+	}
+	return out.String(), nil
+}
+
+// AssertGolden will check the Snapshot of the given response against the
+// golden file on the given path, usually under testdata. With Update the
+// golden file is written instead
+func AssertGolden(t testing.TB, path string, resp interface{}) bool {
+	t.Helper()
+	got, err := Snapshot(resp)
+	if err != nil {
+		t.Errorf("paginationtest: the snapshot of the response can't be rendered: %v", err)
+		return false
+	}
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err == nil {
+			err = os.WriteFile(path, []byte(got), 0o644)
+		}
+		if err != nil {
+			t.Errorf("paginationtest: the golden file %s can't be written: %v", path, err)
+			return false
+		}
+		return true
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("paginationtest: the golden file %s can't be read, run the tests with PAGINATIONTEST_UPDATE=1 for creating it: %v", path, err)
+		return false
+	}
+	if got != string(want) {
+		t.Errorf("paginationtest: the snapshot doesn't match the golden file %s\ngot:\n%s\nwant:\n%s", path, got, want)
+		return false
+	}
+	return true
+}
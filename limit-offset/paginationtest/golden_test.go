@@ -0,0 +1,64 @@
+package paginationtest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/paginationtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	response := pagination.Paginate([]interface{}{1, 2, 3}, "/users", pagination.Params{Limit: 2})
+	snapshot, err := paginationtest.Snapshot(response)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "data": "<2 items>",
+  "links": {
+    "first": "/users?page[limit]=2&page[offset]=0",
+    "next": "/users?page[limit]=2&page[offset]=2"
+  }
+}
+`, snapshot)
+
+	page := pagination.AIPPage{Key: "books", Items: []interface{}{"a"}, NextPageToken: "token"}
+	snapshot, err = paginationtest.Snapshot(page)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+  "books": "<1 items>",
+  "next_page_token": "token"
+}
+`, snapshot)
+}
+
+func TestAssertGolden(t *testing.T) {
+	update := paginationtest.Update
+	paginationtest.Update = false
+	defer func() { paginationtest.Update = update }()
+
+	response := pagination.PaginateTotal([]interface{}{1, 2}, "/users", pagination.Params{Limit: 2}, 5)
+	response.Links.Last = ""
+	assert.True(t, paginationtest.AssertGolden(t, filepath.Join("testdata", "response.golden"), response))
+
+	rec := &recorder{}
+	response.Links.Next = ""
+	assert.False(t, paginationtest.AssertGolden(rec, filepath.Join("testdata", "response.golden"), response))
+	assert.Len(t, rec.failures, 1)
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	update := paginationtest.Update
+	paginationtest.Update = true
+	defer func() { paginationtest.Update = update }()
+
+	path := filepath.Join(t.TempDir(), "testdata", "users.golden")
+	response := pagination.Paginate(nil, "/users", pagination.Params{Limit: 2})
+	assert.True(t, paginationtest.AssertGolden(t, path, response))
+
+	golden, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	snapshot, _ := paginationtest.Snapshot(response)
+	assert.Equal(t, snapshot, string(golden))
+}
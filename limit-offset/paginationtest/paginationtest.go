@@ -0,0 +1,154 @@
+// Package paginationtest provides the assertions for testing the pagination
+// of the list endpoints, walking their pages like the clients do
+package paginationtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// MaxPages is the maximum number of pages walked by AssertCompleteTraversal,
+// the traversal fails when the endpoint has more pages
+var MaxPages = 1000
+
+// AssertNoDuplicateItems will check that no item appears on more than one of
+// the given pages, or twice on the same page, which happens when the sort of
+// the endpoint isn't stable. The items are compared by their JSON encoding
+func AssertNoDuplicateItems(t testing.TB, pages []pagination.Response) bool {
+	t.Helper()
+	seen := make(map[string]int)
+	ok := true
+	for i, page := range pages {
+		for _, item := range page.Data {
+			key := itemKey(item)
+			if first, found := seen[key]; found {
+				t.Errorf("paginationtest: the item %s of the page %d was already on the page %d", key, i+1, first+1)
+				ok = false
+				continue
+			}
+			seen[key] = i
+		}
+	}
+	return ok
+}
+
+// AssertCompleteTraversal will walk the pages of the endpoint served by the
+// given handler, starting on the target and following the Next links, and
+// check that the pages have the total of items, without duplicates, and that
+// the links of every page are well formed. The traversal stops on the pages
+// that loop, after MaxPages and on the failed requests. The pages walked are
+// answered back
+func AssertCompleteTraversal(t testing.TB, server http.Handler, target string, total int) []pagination.Response {
+	t.Helper()
+	var pages []pagination.Response
+	visited := make(map[string]bool)
+	items := 0
+	complete := true
+	for link := target; link != ""; {
+		if visited[link] {
+			t.Errorf("paginationtest: the link %q was already visited, the pages loop", link)
+			complete = false
+			break
+		}
+		if len(pages) == MaxPages {
+			t.Errorf("paginationtest: the endpoint has more than %d pages", MaxPages)
+			complete = false
+			break
+		}
+		visited[link] = true
+		page, err := get(server, link)
+		if err != nil {
+			t.Errorf("paginationtest: %v", err)
+			complete = false
+			break
+		}
+		AssertLinksWellFormed(t, page)
+		pages = append(pages, page)
+		items += len(page.Data)
+		link = page.Links.Next
+	}
+	if complete && items != total {
+		t.Errorf("paginationtest: the %d pages have %d items instead of %d", len(pages), items, total)
+	}
+	AssertNoDuplicateItems(t, pages)
+	return pages
+}
+
+// AssertLinksWellFormed will check the links of the given response, the First
+// link is there, every link is a valid URL to the same path with params that
+// can be found, and the Prev and Next links don't point to the same page
+func AssertLinksWellFormed(t testing.TB, resp pagination.Response) bool {
+	t.Helper()
+	if resp.Links.First == "" {
+		t.Errorf("paginationtest: the first link is missing")
+		return false
+	}
+	ok := true
+	path := ""
+	for _, link := range []struct{ rel, href string }{
+		{"first", resp.Links.First},
+		{"prev", resp.Links.Prev},
+		{"next", resp.Links.Next},
+		{"last", resp.Links.Last},
+	} {
+		if link.href == "" {
+			continue
+		}
+		u, err := url.Parse(link.href)
+		if err != nil {
+			t.Errorf("paginationtest: the %s link %q is not a valid URL: %v", link.rel, link.href, err)
+			ok = false
+			continue
+		}
+		if path == "" {
+			path = u.Path
+		} else if u.Path != path {
+			t.Errorf("paginationtest: the %s link %q doesn't point to %s", link.rel, link.href, path)
+			ok = false
+		}
+		req := &http.Request{Method: http.MethodGet, URL: u}
+		if _, err := pagination.FindParams(req, 0, 0); err != nil {
+			t.Errorf("paginationtest: the params of the %s link %q can't be found: %v", link.rel, link.href, err)
+			ok = false
+		}
+	}
+	if resp.Links.Prev != "" && resp.Links.Prev == resp.Links.Next {
+		t.Errorf("paginationtest: the prev and next links point to the same page %q", resp.Links.Next)
+		ok = false
+	}
+	return ok
+}
+
+// get function will request the given link to the handler, decoding the
+// paginated response
+func get(server http.Handler, link string) (pagination.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, link, nil)
+	if err != nil {
+		return pagination.Response{}, err
+	}
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		return pagination.Response{}, fmt.Errorf("%q answered back the status %d instead of 200", link, rec.Code)
+	}
+	var page pagination.Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		return pagination.Response{}, fmt.Errorf("%q answered back an invalid paginated response: %w", link, err)
+	}
+	return page, nil
+}
+
+// itemKey function will answer back the key comparing the given item
+func itemKey(item interface{}) string {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Sprintf("%#v", item)
+	}
+	return string(b)
+}
@@ -0,0 +1,113 @@
+package paginationtest_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/paginationtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder type is a testing.TB recording the failures instead of failing
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// users function will build the handler of a list endpoint with the given
+// number of users, the fetch function can be changed for breaking it
+func users(total int, fetch func(items []int, params pagination.Params) []int) http.Handler {
+	items := make([]int, total)
+	for i := range items {
+		items[i] = i + 1
+	}
+	return pagination.Handler(func(ctx context.Context, params pagination.Params) ([]int, error) {
+		return fetch(items, params), nil
+	}, pagination.WithPolicy(pagination.Policy{DefaultLimit: 3}))
+}
+
+// page function will fetch the page of the params plus the next item
+func page(items []int, params pagination.Params) []int {
+	from := min(int(params.Offset), len(items))
+	to := min(from+int(params.EffectiveLimit()), len(items))
+	return items[from:to]
+}
+
+func TestAssertCompleteTraversal(t *testing.T) {
+	rec := &recorder{}
+	pages := paginationtest.AssertCompleteTraversal(rec, users(10, page), "/users", 10)
+	assert.Empty(t, rec.failures)
+	assert.Len(t, pages, 4)
+
+	rec = &recorder{}
+	paginationtest.AssertCompleteTraversal(rec, users(10, page), "/users", 11)
+	assert.Equal(t, []string{"paginationtest: the 4 pages have 10 items instead of 11"}, rec.failures)
+
+	// The fetch ignoring the offset always answers back the first page
+	rec = &recorder{}
+	paginationtest.AssertCompleteTraversal(rec, users(10, func(items []int, params pagination.Params) []int {
+		params.Offset = 0
+		return page(items, params)
+	}), "/users", 10)
+	assert.Contains(t, rec.failures, "paginationtest: the item 1 of the page 2 was already on the page 1")
+}
+
+func TestAssertNoDuplicateItems(t *testing.T) {
+	rec := &recorder{}
+	ok := paginationtest.AssertNoDuplicateItems(rec, []pagination.Response{
+		{Data: []interface{}{map[string]any{"id": 1}, map[string]any{"id": 2}}},
+		{Data: []interface{}{map[string]any{"id": 2}, map[string]any{"id": 3}}},
+	})
+	assert.False(t, ok)
+	assert.Equal(t, []string{`paginationtest: the item {"id":2} of the page 2 was already on the page 1`}, rec.failures)
+}
+
+func TestAssertLinksWellFormed(t *testing.T) {
+	tests := []struct {
+		name  string
+		links pagination.Links
+		want  []string
+	}{
+		{
+			name:  "Well formed links",
+			links: pagination.Links{First: "/users?page[limit]=3&page[offset]=0", Next: "/users?page[limit]=3&page[offset]=3"},
+		},
+		{
+			name: "Missing first link",
+			want: []string{"paginationtest: the first link is missing"},
+		},
+		{
+			name:  "Link to another path",
+			links: pagination.Links{First: "/users?page[limit]=3&page[offset]=0", Next: "/posts?page[limit]=3&page[offset]=3"},
+			want:  []string{`paginationtest: the next link "/posts?page[limit]=3&page[offset]=3" doesn't point to /users`},
+		},
+		{
+			name:  "Wrong params",
+			links: pagination.Links{First: "/users?page[limit]=3&page[offset]=-3"},
+			want:  []string{`paginationtest: the params of the first link "/users?page[limit]=3&page[offset]=-3" can't be found: pagination: invalid page[offset]="-3": the value can't be negative`},
+		},
+		{
+			name:  "Same prev and next",
+			links: pagination.Links{First: "/users?page[limit]=3&page[offset]=0", Prev: "/users?page[limit]=3&page[offset]=3", Next: "/users?page[limit]=3&page[offset]=3"},
+			want:  []string{`paginationtest: the prev and next links point to the same page "/users?page[limit]=3&page[offset]=3"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recorder{}
+			ok := paginationtest.AssertLinksWellFormed(rec, pagination.Response{Links: tt.links})
+			assert.Equal(t, len(tt.want) == 0, ok)
+			assert.Equal(t, tt.want, rec.failures)
+		})
+	}
+}
@@ -0,0 +1,568 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Policy type encapsulates the rules applied when finding the pagination params
+// on a request, the zero value behaves like FindParams with zero defaults
+type Policy struct {
+	DefaultOffset uint
+	DefaultLimit  uint
+	// DefaultSort is applied when the request doesn't have any valid sort, a
+	// deterministic order is needed so offset pagination doesn't return
+	// duplicated items across pages. ParseSort can be used for building it
+	DefaultSort []Sort
+	// SortAliases maps the sort fields exposed to the clients into the SQL
+	// expressions used on the query, for example name could be sorted by
+	// lower(last_name) and lower(first_name). The links keep the alias
+	SortAliases map[string][]string
+	// SnakeCaseColumns converts the camelCase sort fields sent by the clients
+	// into snake_case columns on the query, for example createdAt is sorted by
+	// created_at. The links keep the casing used by the client
+	SnakeCaseColumns bool
+	// TieBreaker is a unique column, for example id, appended to the ORDER BY
+	// of the query when the sort doesn't include it, see Params.TieBreaker
+	TieBreaker string
+	// SortFormat is the format accepted on the sort param, by default all the
+	// formats are accepted, the pair one only when the sort param is missing
+	SortFormat SortFormat
+	// LinkSortFormat is the format used for the sort on the links, by default
+	// the links use the same format the client used. It allows migrating the
+	// clients between formats gradually
+	LinkSortFormat SortFormat
+	// SortCase is how the case of the sort sent by the client is handled on
+	// the query and on the links, by default it is kept as it is
+	SortCase SortCase
+	// AllowedSorts are the sort fields the clients can use, the rest of fields
+	// are dropped from the params. When empty every field is allowed
+	AllowedSorts []string
+	// JSONSortFields renders the nested sort fields, like metadata.priority,
+	// as a read of the priority key on the metadata JSON column using the
+	// dialect, for example metadata->>'priority' on PostgreSQL. As the path
+	// comes from the client this only applies to the fields on AllowedSorts
+	JSONSortFields bool
+	// StrictSortFields refuses the requests with sort fields that aren't safe
+	// identifiers, that is letters, digits and underscores with an optional
+	// single dot for the table prefix, no matter the AllowedSorts. It is a
+	// defense in depth against the ORDER BY injection, the error matches the
+	// ErrInvalidSortField. The nested JSONSortFields are limited to one level
+	StrictSortFields bool
+	// RandomSort enables the random sort, see SortRandom
+	RandomSort bool
+	// RandomSortColumn is the unique column used for the random sort, when
+	// empty the TieBreaker is used and then id
+	RandomSortColumn string
+	// Collations maps the sort fields into the collation used on the query for
+	// them, see Sort.Collation
+	Collations map[string]string
+	// Dialect is the SQL dialect used when building the query
+	Dialect Dialect
+	// RequireStableSort refuses offsets beyond MaxUnstableOffset when the sort
+	// can't guarantee a stable order, that is when there is no TieBreaker and
+	// none of the sort fields is one of the UniqueFields. An *UnstableSortError
+	// is returned so the client can be told to use cursor pagination
+	RequireStableSort bool
+	// MaxUnstableOffset is the deepest offset allowed with an unstable sort
+	MaxUnstableOffset uint
+	// OffsetAlignment is how the offsets that aren't a multiple of the limit
+	// are handled, by default they are accepted as they are
+	OffsetAlignment OffsetAlignment
+	// MaxResultWindow is the maximum number of items that can be paginated,
+	// like the max_result_window of Elasticsearch, the requests where the
+	// offset plus the limit goes beyond it are refused with a
+	// *ResultWindowError, so the scrapers can't walk entire tables. When zero
+	// there is no maximum
+	MaxResultWindow uint
+	// ExportURL is the API exporting the whole collection, it is suggested to
+	// the clients refused by the MaxResultWindow
+	ExportURL string
+	// UniqueFields are the sort fields, or columns, with unique values
+	UniqueFields []string
+	// FilterSchema declares the filterable fields, when set the filters of the
+	// request are validated against it and a *FilterError is returned for the
+	// wrong ones, when nil every filter is accepted as a string
+	FilterSchema FilterSchema
+	// FilterFormat is the format accepted for the filters, by default the
+	// brackets one, see FilterFormatRSQL
+	FilterFormat FilterFormat
+	// SearchParam is the name of the free-text search param used by
+	// FindListParams, by default q
+	SearchParam string
+	// Metrics is the hook called when finding the params and when serving the
+	// pages, see MetricsHook
+	Metrics MetricsHook
+	// MaxLimit is the biggest limit the clients can request, bigger limits are
+	// clamped to it. When zero there is no maximum
+	MaxLimit uint
+	// Logger records, with the values sent by the client, the requests with
+	// params that are rejected, clamped or dropped, which otherwise would go
+	// unnoticed. When nil nothing is logged
+	Logger *slog.Logger
+	// Redactions are the rules applied to the values of the filters and the
+	// params before they reach the Logger, the Metrics and the Debug meta, so
+	// the sensitive ones, like the emails, don't leak into the observability
+	// systems, see RedactFields and RedactPattern
+	Redactions []Redactor
+	// MaxOffsetDepth is the deepest offset served without warning, the deeper
+	// requests are logged, passed to OnDeepOffset and flagged on the params,
+	// see Params.DeepOffset. When zero there is no warning
+	MaxOffsetDepth uint
+	// OnDeepOffset is called for every request beyond the MaxOffsetDepth, so
+	// we can track the clients that should move to cursor pagination
+	OnDeepOffset func(req *http.Request, params Params)
+	// DeepOffsetHint adds the DeepOffsetHint to the meta of the responses
+	// beyond the MaxOffsetDepth
+	DeepOffsetHint bool
+	// DeepOffsetDeprecation is the date since the deep offsets are deprecated,
+	// when set SetDeepOffsetHeaders adds it as the Deprecation header
+	DeepOffsetDeprecation time.Time
+	// ExactCount is used when the total of items is known, like a counter
+	// cache, see Params.ExactCount. It avoids the extra item of the query,
+	// which defeats the query caches and some read replicas
+	ExactCount bool
+	// DisableOverFetch makes the Query fetch the limit without the extra item,
+	// see Params.EffectiveLimit
+	DisableOverFetch bool
+	// StableJSON makes the responses always encode the data and the four
+	// links, see Params.StableJSON
+	StableJSON bool
+	// Debug adds to the meta of the responses the effective params and the
+	// generated query, see Debug. It is meant for development and staging,
+	// never enable it on production
+	Debug bool
+	// Tokens enables the opaque page tokens, the links carry a page[token]
+	// param instead of the pagination params, see TokenCodec
+	Tokens *TokenCodec
+	// OpaqueOffsets writes the offsets of the links as opaque values, see
+	// OffsetCursor, instead of plain integers, and only accepts those values
+	// on the offset param, so the clients can't fabricate arbitrary deep
+	// offsets. The rest of params are kept as they are
+	OpaqueOffsets bool
+	// OffsetTokens signs the opaque offsets, otherwise they are just encoded
+	// and the clients could still build them
+	OffsetTokens *TokenCodec
+	// LinkSigner signs the query of the links, see LinkSigner, and refuses the
+	// requests beyond its MaxUnsignedOffset without a valid signature with
+	// ErrInvalidSignature
+	LinkSigner *LinkSigner
+	// AllowUnlimited answers back if the caller, resolved from the context of
+	// the request, is trusted to fetch the full result set with the LimitAll
+	// value on the limit param, like the internal batch jobs. When nil, or
+	// when it answers back false, the LimitAll is refused as any other wrong
+	// limit, see Params.Unlimited
+	AllowUnlimited func(ctx context.Context) bool
+	// OmitDefaultParams drops from the links the limit and the offset equal to
+	// the DefaultLimit and the DefaultOffset, like the page[offset]=0 of the
+	// First link, producing cleaner canonical URLs for the CDN caches and the
+	// analytics. With the page number profile the size is kept when the number
+	// is dropped
+	OmitDefaultParams bool
+	// DuplicateParams is how the pagination params sent more than once are
+	// handled, by default the first value is taken
+	DuplicateParams DuplicateParams
+	// Resource is the name of the resource paginated, like users, it is passed
+	// to the OnPaginate callback
+	Resource string
+	// OnPaginate is called after each response is built with the params found
+	// by the policy, so the audit or analytics pipelines can subscribe without
+	// wrapping every handler. Use PaginateContext for passing the context of
+	// the request to it
+	OnPaginate func(ctx context.Context, info PaginateInfo)
+	// Clients resolves the limits of the caller from the context of the
+	// request, like the tier of its API key, replacing the MaxLimit and the
+	// MaxOffsetDepth, see ForContext and TierLimits. When nil, or when it
+	// answers back false, every caller gets the limits of the policy
+	Clients func(ctx context.Context) (ClientLimits, bool)
+	// OnTamperedToken is called for every page token, or signed opaque
+	// offset, refused for having a wrong signature, with the key ID, the client IP and the age of the
+	// token, so the WAF or abuse pipelines can act on repeated attempts
+	OnTamperedToken func(ctx context.Context, report TamperReport)
+
+	// names are the param names found on the request, the default ones when
+	// it is empty, see New
+	names paramNames
+}
+
+// FindParams will find for the pagination params on the request applying the
+// policy rules
+func (p Policy) FindParams(req *http.Request) (Params, error) {
+	var params Params
+	err := p.FindParamsInto(req, &params)
+	return params, err
+}
+
+// FindParamsInto works like FindParams but fills the given params instead of
+// building a new one, the backing array of params.Sort is reused
+func (p Policy) FindParamsInto(req *http.Request, params *Params) error {
+	rawQuery, err := p.rawQuery(req)
+	if err == nil {
+		err = p.findParamsInto(req, rawQuery, params)
+	}
+	return p.found(req, params, err)
+}
+
+// found method will log and report to the metrics hook the result of finding
+// the given params, the given error is returned back with the sensitive values
+// redacted
+func (p Policy) found(req *http.Request, params *Params, err error) error {
+	redacted := redactError(p.Redactions, err, req.URL.RawQuery, params.Filters)
+	if err != nil {
+		p.log(req.Context(), "pagination: params rejected", "error", redacted, "raw_query", redactQuery(p.Redactions, req.URL.RawQuery))
+	}
+	params.metrics = p.Metrics
+	if p.Metrics != nil {
+		p.Metrics.OnParse(newParseEvent(*params, redacted))
+	}
+	return redacted
+}
+
+// findParamsInto method will fill the given params applying the policy rules
+func (p Policy) findParamsInto(req *http.Request, rawQuery string, params *Params) error {
+	p = p.ForContext(req.Context())
+	params.offsets = offsetEncoding{opaque: p.OpaqueOffsets, tokens: p.OffsetTokens}
+	if p.OpaqueOffsets {
+		var err error
+		if rawQuery, err = p.decodeOffset(req, rawQuery); err != nil {
+			return err
+		}
+	}
+	params.Limit = p.DefaultLimit
+	params.Offset = p.DefaultOffset
+	params.TieBreaker = p.TieBreaker
+	params.Dialect = p.Dialect
+	params.debug = p.Debug
+	params.ExactCount = p.ExactCount
+	params.StableJSON = p.StableJSON
+	params.DisableOverFetch = p.DisableOverFetch
+	params.redactions = p.Redactions
+	params.tokens = p.Tokens
+	params.onPaginate = p.OnPaginate
+	params.resource = p.Resource
+	params.correlationID = ""
+	if p.Tokens != nil && p.Tokens.CorrelationIDs {
+		params.correlationID = CorrelationID(req.Context())
+	}
+	params.scope = ""
+	if p.Tokens != nil {
+		params.scope = TokenScope(req.Context())
+	}
+	params.names = p.names
+	params.signer = p.LinkSigner
+	params.defaults = linkDefaults{omit: p.OmitDefaultParams, limit: p.DefaultLimit, offset: p.DefaultOffset}
+	params.unlimited = false
+	if p.AllowUnlimited != nil {
+		rawQuery, params.unlimited = p.unlimitedQuery(req, rawQuery)
+	}
+	if err := findParams(rawQuery, p.names.orDefault(), p.SortFormat, params); err != nil {
+		return err
+	}
+	if p.LinkSigner != nil {
+		if err := p.LinkSigner.verifyDepth(req, params); err != nil {
+			p.log(req.Context(), "pagination: unsigned link", "offset", params.Offset, "max_unsigned_offset", p.LinkSigner.MaxUnsignedOffset)
+			return err
+		}
+	}
+	if p.Logger != nil && params.sortValue == "" {
+		p.logMalformedSort(req.Context(), rawQuery)
+	}
+	if p.StrictSortFields {
+		if problems := strictSortFields(params.Sort); len(problems) > 0 {
+			return problems[0]
+		}
+	}
+	p.applyValidOrders(req.Context(), params)
+	if p.MaxLimit > 0 && params.Limit > p.MaxLimit {
+		p.log(req.Context(), "pagination: limit clamped", "raw_limit", params.Limit, "limit", p.MaxLimit)
+		params.Limit = p.MaxLimit
+	}
+	if err := p.applyOffsetAlignment(req.Context(), params); err != nil {
+		return err
+	}
+	if p.LinkSortFormat != SortFormatAuto {
+		params.SortFormat = p.LinkSortFormat
+	}
+	p.applySortCase(params)
+	p.applyAllowedSorts(req.Context(), params)
+	p.applyDefaultSort(params)
+	p.applySortAliases(params)
+	p.applyJSONSortFields(params)
+	p.applySnakeCaseColumns(params)
+	p.applyCollations(params)
+	params.FilterFormat = p.FilterFormat
+	if p.FilterFormat == FilterFormatRSQL {
+		filters, err := ParseRSQL(lookupRSQL(rawQuery))
+		if err != nil {
+			return err
+		}
+		params.Filters = filters
+	} else {
+		params.Filters = findFilters(rawQuery)
+	}
+	if p.FilterSchema != nil {
+		for i := range params.Filters {
+			if err := p.FilterSchema.coerce(&params.Filters[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if p.RandomSort {
+		if err := p.applyRandomSort(params); err != nil {
+			return err
+		}
+	} else {
+		params.Seed = ""
+	}
+	p.applyDeepOffset(req, params)
+	if err := p.checkResultWindow(*params); err != nil {
+		return err
+	}
+	if p.RequireStableSort && params.Offset > p.MaxUnstableOffset && !p.StableSort(params.Sort) {
+		return &UnstableSortError{
+			Offset:    params.Offset,
+			MaxOffset: p.MaxUnstableOffset,
+		}
+	}
+	return nil
+}
+
+// checkResultWindow method will refuse the params going beyond the
+// MaxResultWindow
+func (p Policy) checkResultWindow(params Params) error {
+	if p.MaxResultWindow == 0 || uint64(params.Offset)+uint64(params.Limit) <= uint64(p.MaxResultWindow) {
+		return nil
+	}
+	return &ResultWindowError{
+		Offset:          params.Offset,
+		Limit:           params.Limit,
+		MaxResultWindow: p.MaxResultWindow,
+		ExportURL:       p.ExportURL,
+	}
+}
+
+// StableSort will check if the given sort guarantees a stable order between
+// pages, that is when we have a tie breaker or one of the fields is unique
+func (p Policy) StableSort(sort []Sort) bool {
+	if p.TieBreaker != "" {
+		return true
+	}
+	for _, s := range sort {
+		for _, unique := range p.UniqueFields {
+			if s.Field == unique {
+				return true
+			}
+			for _, column := range s.Columns {
+				if column == unique {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// applyValidOrders method will drop the sort fields with an order that isn't
+// asc or desc, so they don't reach the links
+func (p Policy) applyValidOrders(ctx context.Context, params *Params) {
+	valid := params.Sort[:0]
+	for _, s := range params.Sort {
+		if s.validOrder() {
+			valid = append(valid, s)
+		} else {
+			p.log(ctx, "pagination: sort field dropped", "field", s.Field, "reason", "invalid order")
+		}
+	}
+	params.Sort = valid
+}
+
+// applyAllowedSorts method will drop the sort fields that aren't allowed
+func (p Policy) applyAllowedSorts(ctx context.Context, params *Params) {
+	if len(p.AllowedSorts) == 0 {
+		return
+	}
+	allowed := params.Sort[:0]
+	for _, s := range params.Sort {
+		if p.sortAllowed(s.Field) {
+			allowed = append(allowed, s)
+		} else {
+			p.log(ctx, "pagination: sort field dropped", "field", s.Field, "reason", "not allowed")
+		}
+	}
+	params.Sort = allowed
+}
+
+// sortAllowed method will check if the given field is on the allowed sorts
+func (p Policy) sortAllowed(field string) bool {
+	if p.RandomSort && field == SortRandom {
+		return true
+	}
+	for _, allowed := range p.AllowedSorts {
+		if field == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// applyDefaultSort method will copy the default sort into the params when they
+// don't have any sort, we copy it so changes on the params sort don't modify
+// the policy
+func (p Policy) applyDefaultSort(params *Params) {
+	if len(params.Sort) == 0 && len(p.DefaultSort) > 0 {
+		params.Sort = append(params.Sort[:0], p.DefaultSort...)
+	}
+}
+
+// applySortAliases method will set the SQL expressions of the sort fields that
+// are registered as aliases
+func (p Policy) applySortAliases(params *Params) {
+	for i, s := range params.Sort {
+		if columns, ok := p.SortAliases[s.Field]; ok {
+			params.Sort[i].Columns = columns
+		}
+	}
+}
+
+// applyJSONSortFields method will set the JSON path expression of the nested
+// sort fields that are allowed and aren't aliases
+func (p Policy) applyJSONSortFields(params *Params) {
+	if !p.JSONSortFields {
+		return
+	}
+	for i, s := range params.Sort {
+		if len(s.Columns) == 0 && strings.Contains(s.Field, ".") && p.sortAllowed(s.Field) {
+			params.Sort[i].Columns = []string{p.Dialect.jsonPath(s.Field)}
+		}
+	}
+}
+
+// applySnakeCaseColumns method will set the snake_case column of the sort
+// fields that aren't aliases and use camelCase
+func (p Policy) applySnakeCaseColumns(params *Params) {
+	if !p.SnakeCaseColumns {
+		return
+	}
+	for i, s := range params.Sort {
+		if len(s.Columns) > 0 {
+			continue
+		}
+		if column := SnakeCase(s.Field); column != s.Field {
+			params.Sort[i].Columns = []string{column}
+		}
+	}
+}
+
+// applyCollations method will set the collation of the sort fields that have
+// one configured
+func (p Policy) applyCollations(params *Params) {
+	for i, s := range params.Sort {
+		if collation, ok := p.Collations[s.Field]; ok {
+			params.Sort[i].Collation = collation
+		}
+	}
+}
+
+// ParseSort will parse a sort value with the same formats accepted on the sort
+// param, for example created_at.desc,id.desc, mallformed fields are ignored
+func ParseSort(sort string) []Sort {
+	parsed, _ := appendSort(nil, sort, SortFormatAuto)
+	return parsed
+}
+
+// SnakeCase will convert the given camelCase name into snake_case, acronyms
+// are kept together so userID is converted into user_id and HTTPStatus into
+// http_status
+func SnakeCase(name string) string {
+	if strings.IndexFunc(name, unicode.IsUpper) < 0 {
+		return name
+	}
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && runes[i-1] != '_' && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// log method will record the given message on the logger of the policy, when
+// there is one
+func (p Policy) log(ctx context.Context, msg string, args ...interface{}) {
+	if p.Logger == nil {
+		return
+	}
+	p.Logger.WarnContext(ctx, msg, args...)
+}
+
+// logMalformedSort method will record the sort sent by the client when some of
+// its fields were dropped for being malformed
+func (p Policy) logMalformedSort(ctx context.Context, rawQuery string) {
+	names := p.names.orDefault()
+	raw := lookupParams(rawQuery, names)
+	name, rawSort := names.sort, raw.sort
+	if p.SortFormat == SortFormatPair || p.SortFormat == SortFormatAuto && raw.sort == "" {
+		name, rawSort = names.orderBy, raw.orderBy
+	}
+	if rawSort != "" {
+		p.log(ctx, "pagination: sort field dropped", "raw_sort", redact(p.Redactions, name, rawSort), "reason", "malformed")
+	}
+}
+
+// preset method will answer back a copy of the policy for the presets that
+// translate their own params, like FindStripeParams, into the default names.
+// They handle the tokens, the offsets and the extra item on their own
+func (p Policy) preset() Policy {
+	p.Tokens = nil
+	p.ExactCount = false
+	p.DisableOverFetch = false
+	p.AllowUnlimited = nil
+	p.OpaqueOffsets = false
+	p.LinkSigner = nil
+	p.names = paramNames{}
+	return p
+}
+
+// rawQuery method will answer back the query the params are found on, that is
+// the query inside the page token when the policy uses tokens and the request
+// has one, otherwise the query of the request. The duplicated params are
+// handled before, see DuplicateParams
+func (p Policy) rawQuery(req *http.Request) (string, error) {
+	rawQuery, err := p.applyDuplicateParams(req.URL.RawQuery)
+	if err != nil || p.Tokens == nil {
+		return rawQuery, err
+	}
+	value := lookupParam(rawQuery, ParamPageToken)
+	if value == "" {
+		return rawQuery, nil
+	}
+	return p.decodeToken(req, value)
+}
+
+// decodeToken method will answer back the query kept inside the given token,
+// it must be bound to the scope of the request, see WithTokenScope. The
+// rejected tokens are logged and reported to the metrics hook
+func (p Policy) decodeToken(req *http.Request, value string) (string, error) {
+	token, err := p.Tokens.DecodeScoped(value, TokenScope(req.Context()))
+	if err != nil {
+		var tokenErr *TokenError
+		if errors.As(err, &tokenErr) {
+			p.log(req.Context(), "pagination: token rejected", "failure", tokenErr.Failure, "reason", tokenErr.Reason, "correlation_id", tokenErr.CorrelationID)
+			tokenRejected(p.Metrics, tokenErr.Failure)
+			p.reportTampering(req, p.Tokens, tokenErr)
+		}
+		return "", err
+	}
+	return token.Query, nil
+}
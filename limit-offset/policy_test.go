@@ -0,0 +1,574 @@
+package pagination_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	assert.Equal(t, []pagination.Sort{
+		{Field: "created_at", Order: "desc"},
+		{Field: "id", Order: "desc"},
+	}, pagination.ParseSort("created_at.desc,id.desc"))
+	assert.Equal(t, []pagination.Sort{{Field: "id", Order: "desc"}}, pagination.ParseSort("asc(name),id.desc"))
+	assert.Nil(t, pagination.ParseSort(""))
+}
+
+func TestPolicyDefaultSort(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		DefaultSort:  pagination.ParseSort("created_at.desc,id.desc"),
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		want      []pagination.Sort
+		wantLinks pagination.Links
+	}{
+		{
+			name: "Should apply the default sort",
+			url:  "app.quicka.co/api/sample",
+			want: []pagination.Sort{
+				{Field: "created_at", Order: "desc"},
+				{Field: "id", Order: "desc"},
+			},
+			wantLinks: pagination.Links{
+				First: "/sample?page[limit]=10&page[offset]=0&sort=created_at.desc,id.desc",
+			},
+		},
+		{
+			name: "Should apply the default sort when the sort is mallformed",
+			url:  "app.quicka.co/api/sample?sort=asc(name)",
+			want: []pagination.Sort{
+				{Field: "created_at", Order: "desc"},
+				{Field: "id", Order: "desc"},
+			},
+			wantLinks: pagination.Links{
+				First: "/sample?page[limit]=10&page[offset]=0&sort=created_at.desc,id.desc",
+			},
+		},
+		{
+			name: "Should keep the sort of the request",
+			url:  "app.quicka.co/api/sample?sort=name.asc",
+			want: []pagination.Sort{
+				{Field: "name", Order: "asc"},
+			},
+			wantLinks: pagination.Links{
+				First: "/sample?page[limit]=10&page[offset]=0&sort=name.asc",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, params.Sort)
+			assert.Equal(t, tt.wantLinks, pagination.Paginate(nil, "/sample", params).Links)
+		})
+	}
+}
+
+func TestPolicyDefaultSortIsCopied(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultSort: pagination.ParseSort("created_at.desc"),
+	}
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample", nil)
+	assert.Nil(t, err)
+
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	params.Sort[0].Order = "asc"
+	assert.Equal(t, "desc", policy.DefaultSort[0].Order)
+}
+
+func TestPolicySortAliases(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		DefaultSort:  pagination.ParseSort("name.asc"),
+		SortAliases: map[string][]string{
+			"name": {"lower(last_name)", "lower(first_name)"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		url       string
+		wantQuery string
+		wantFirst string
+	}{
+		{
+			name:      "Should expand the alias",
+			url:       "app.quicka.co/api/sample?sort=name.desc,created_at.asc",
+			wantQuery: " LIMIT 11 OFFSET 0 ORDER BY lower(last_name) desc,lower(first_name) desc,created_at asc",
+			wantFirst: "/sample?page[limit]=10&page[offset]=0&sort=name.desc,created_at.asc",
+		},
+		{
+			name:      "Should expand the alias of the default sort",
+			url:       "app.quicka.co/api/sample",
+			wantQuery: " LIMIT 11 OFFSET 0 ORDER BY lower(last_name) asc,lower(first_name) asc",
+			wantFirst: "/sample?page[limit]=10&page[offset]=0&sort=name.asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantQuery, params.Query())
+			assert.Equal(t, tt.wantFirst, pagination.Paginate(nil, "/sample", params).Links.First)
+		})
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "name", want: "name"},
+		{name: "created_at", want: "created_at"},
+		{name: "createdAt", want: "created_at"},
+		{name: "CreatedAt", want: "created_at"},
+		{name: "userID", want: "user_id"},
+		{name: "HTTPStatus", want: "http_status"},
+		{name: "address2Line", want: "address2_line"},
+		{name: "table_nameField", want: "table_name_field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.SnakeCase(tt.name))
+		})
+	}
+}
+
+func TestPolicySnakeCaseColumns(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit:     10,
+		SnakeCaseColumns: true,
+		SortAliases: map[string][]string{
+			"fullName": {"lower(last_name)"},
+		},
+	}
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=createdAt.desc,fullName.asc,id.asc", nil)
+	assert.Nil(t, err)
+
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, " LIMIT 11 OFFSET 0 ORDER BY created_at desc,lower(last_name) asc,id asc", params.Query())
+	assert.Equal(t,
+		"/sample?page[limit]=10&page[offset]=0&sort=createdAt.desc,fullName.asc,id.asc",
+		pagination.Paginate(nil, "/sample", params).Links.First,
+	)
+}
+
+func TestPolicyTieBreaker(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		TieBreaker:   "id",
+	}
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=name.asc", nil)
+	assert.Nil(t, err)
+
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, " LIMIT 11 OFFSET 0 ORDER BY name asc,id asc", params.Query())
+	assert.Equal(t, "/sample?page[limit]=10&page[offset]=0&sort=name.asc", pagination.Paginate(nil, "/sample", params).Links.First)
+}
+
+func TestPolicyRequireStableSort(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit:      10,
+		RequireStableSort: true,
+		MaxUnstableOffset: 100,
+		UniqueFields:      []string{"id", "email"},
+	}
+
+	tests := []struct {
+		name    string
+		policy  pagination.Policy
+		url     string
+		wantErr bool
+	}{
+		{
+			name:   "Unstable sort within the allowed depth",
+			policy: policy,
+			url:    "app.quicka.co/api/sample?page[offset]=100&sort=name.asc",
+		},
+		{
+			name:    "Unstable sort beyond the allowed depth",
+			policy:  policy,
+			url:     "app.quicka.co/api/sample?page[offset]=110&sort=name.asc",
+			wantErr: true,
+		},
+		{
+			name:    "Without sort beyond the allowed depth",
+			policy:  policy,
+			url:     "app.quicka.co/api/sample?page[offset]=110",
+			wantErr: true,
+		},
+		{
+			name:   "Unique sort beyond the allowed depth",
+			policy: policy,
+			url:    "app.quicka.co/api/sample?page[offset]=110&sort=name.asc,email.desc",
+		},
+		{
+			name: "Tie breaker beyond the allowed depth",
+			policy: pagination.Policy{
+				RequireStableSort: true,
+				TieBreaker:        "id",
+			},
+			url: "app.quicka.co/api/sample?page[offset]=110&sort=name.asc",
+		},
+		{
+			name: "Without requiring an stable sort",
+			policy: pagination.Policy{
+				MaxUnstableOffset: 100,
+			},
+			url: "app.quicka.co/api/sample?page[offset]=110&sort=name.asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			_, err = tt.policy.FindParams(req)
+			if !tt.wantErr {
+				assert.Nil(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, pagination.ErrUnstableSort))
+			var unstableErr *pagination.UnstableSortError
+			assert.True(t, errors.As(err, &unstableErr))
+			assert.Equal(t, uint(110), unstableErr.Offset)
+			assert.Equal(t, uint(100), unstableErr.MaxOffset)
+		})
+	}
+}
+
+func TestPolicyAllowedSorts(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		DefaultSort:  pagination.ParseSort("id.asc"),
+		AllowedSorts: []string{"name", "created_at"},
+	}
+
+	tests := []struct {
+		name string
+		url  string
+		want []pagination.Sort
+	}{
+		{
+			name: "Should keep the allowed fields",
+			url:  "app.quicka.co/api/sample?sort=name.asc,password.desc,created_at.desc",
+			want: []pagination.Sort{
+				{Field: "name", Order: "asc"},
+				{Field: "created_at", Order: "desc"},
+			},
+		},
+		{
+			name: "Should apply the default sort when nothing is allowed",
+			url:  "app.quicka.co/api/sample?sort=password.desc",
+			want: []pagination.Sort{
+				{Field: "id", Order: "asc"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, params.Sort)
+		})
+	}
+}
+
+func TestPolicyJSONSortFields(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect pagination.Dialect
+		url     string
+		want    string
+	}{
+		{
+			name:    "Postgres dialect",
+			dialect: pagination.DialectPostgres,
+			url:     "app.quicka.co/api/sample?sort=metadata.priority.desc,name.asc",
+			want:    " LIMIT 11 OFFSET 0 ORDER BY metadata->>'priority' desc,name asc",
+		},
+		{
+			name:    "Postgres dialect with deep nested field",
+			dialect: pagination.DialectPostgres,
+			url:     "app.quicka.co/api/sample?sort=metadata.owner.name.asc",
+			want:    " LIMIT 11 OFFSET 0 ORDER BY metadata->'owner'->>'name' asc",
+		},
+		{
+			name:    "MySQL dialect",
+			dialect: pagination.DialectMySQL,
+			url:     "app.quicka.co/api/sample?sort=metadata.priority.desc",
+			want:    " LIMIT 11 OFFSET 0 ORDER BY JSON_EXTRACT(metadata, '$.priority') desc",
+		},
+		{
+			name:    "SQLite dialect",
+			dialect: pagination.DialectSQLite,
+			url:     "app.quicka.co/api/sample?sort=metadata.priority.desc",
+			want:    " LIMIT 11 OFFSET 0 ORDER BY json_extract(metadata, '$.priority') desc",
+		},
+		{
+			name:    "Not allowed nested field",
+			dialect: pagination.DialectPostgres,
+			url:     "app.quicka.co/api/sample?sort=metadata.secret.desc",
+			want:    " LIMIT 11 OFFSET 0 ",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := pagination.Policy{
+				DefaultLimit:   10,
+				Dialect:        tt.dialect,
+				JSONSortFields: true,
+				AllowedSorts:   []string{"name", "metadata.priority", "metadata.owner.name"},
+			}
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, params.Query())
+		})
+	}
+}
+
+func TestPolicyFilterSchema(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		FilterSchema: pagination.FilterSchema{"age": {Type: pagination.FilterInt}},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?filter[age][gt]=30", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(30)}, params.Filters[0].Parsed)
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?filter[age][gt]=old", nil)
+	assert.Nil(t, err)
+	_, err = policy.FindParams(req)
+	assert.True(t, errors.Is(err, pagination.ErrInvalidFilter))
+}
+
+func TestPolicyMaxLimit(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, MaxLimit: 50}
+
+	tests := []struct {
+		name      string
+		url       string
+		wantLimit uint
+	}{
+		{
+			name:      "Default limit",
+			url:       "app.quicka.co/api/sample",
+			wantLimit: 10,
+		},
+		{
+			name:      "Limit under the maximum",
+			url:       "app.quicka.co/api/sample?page[limit]=50",
+			wantLimit: 50,
+		},
+		{
+			name:      "Limit clamped to the maximum",
+			url:       "app.quicka.co/api/sample?page[limit]=1000",
+			wantLimit: 50,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := policy.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, params.Limit)
+		})
+	}
+}
+
+func TestPolicyMaxResultWindow(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, MaxLimit: 100, MaxResultWindow: 1000, ExportURL: "/exports/users"}
+
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{
+			name: "Inside the window",
+			url:  "app.quicka.co/api/sample?page[limit]=100&page[offset]=900",
+		},
+		{
+			name:    "Last items beyond the window",
+			url:     "app.quicka.co/api/sample?page[limit]=100&page[offset]=901",
+			wantErr: true,
+		},
+		{
+			name:    "Clamped limit beyond the window",
+			url:     "app.quicka.co/api/sample?page[limit]=5000&page[offset]=950",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			_, err = policy.FindParams(req)
+			if !tt.wantErr {
+				assert.Nil(t, err)
+				return
+			}
+			assert.True(t, errors.Is(err, pagination.ErrResultWindowExceeded))
+			var windowErr *pagination.ResultWindowError
+			if assert.True(t, errors.As(err, &windowErr)) {
+				assert.Equal(t, uint(1000), windowErr.MaxResultWindow)
+				assert.Contains(t, windowErr.Error(), "use /exports/users for exporting the whole collection")
+			}
+		})
+	}
+
+	err := pagination.Params{Limit: 10, Offset: 995}.Validate(policy)
+	assert.True(t, errors.Is(err, pagination.ErrResultWindowExceeded))
+}
+
+func TestPolicyLogger(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		want []string
+	}{
+		{
+			name: "Valid params",
+			url:  "app.quicka.co/api/sample?page[limit]=10&sort=name.asc",
+		},
+		{
+			name: "Clamped limit",
+			url:  "app.quicka.co/api/sample?page[limit]=500",
+			want: []string{`level=WARN msg="pagination: limit clamped" raw_limit=500 limit=50`},
+		},
+		{
+			name: "Malformed and not allowed sort fields",
+			url:  "app.quicka.co/api/sample?sort=name.asc,asc(age),password.desc",
+			want: []string{
+				`level=WARN msg="pagination: sort field dropped" raw_sort=name.asc,asc(age),password.desc reason=malformed`,
+				`level=WARN msg="pagination: sort field dropped" field=password reason="not allowed"`,
+			},
+		},
+		{
+			name: "Rejected params",
+			url:  "app.quicka.co/api/sample?page[offset]=wrong",
+			want: []string{`level=WARN msg="pagination: params rejected" error="strconv.ParseUint: parsing \"wrong\": invalid syntax" raw_query="page[offset]=wrong"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&out, &slog.HandlerOptions{
+				ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+					if a.Key == slog.TimeKey {
+						return slog.Attr{}
+					}
+					return a
+				},
+			}))
+			policy := pagination.Policy{
+				DefaultLimit: 10,
+				MaxLimit:     50,
+				AllowedSorts: []string{"name", "age"},
+				Logger:       logger,
+			}
+
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			policy.FindParams(req)
+
+			var lines []string
+			if out.Len() > 0 {
+				lines = strings.Split(strings.TrimSpace(out.String()), "\n")
+			}
+			assert.Equal(t, tt.want, lines)
+		})
+	}
+}
+
+func TestPolicyOmitDefaultParams(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		data []interface{}
+		want pagination.Links
+	}{
+		{
+			name: "Defaults omitted",
+			url:  "/users",
+			data: []interface{}{1, 2, 3},
+			want: pagination.Links{
+				First: "/users",
+				Next:  "/users?page[offset]=2",
+			},
+		},
+		{
+			name: "Limit other than the default",
+			url:  "/users?page[limit]=1&page[offset]=1&sort=name.asc",
+			data: []interface{}{1, 2},
+			want: pagination.Links{
+				First: "/users?page[limit]=1&sort=name.asc",
+				Prev:  "/users?page[limit]=1&sort=name.asc",
+				Next:  "/users?page[limit]=1&page[offset]=2&sort=name.asc",
+			},
+		},
+		{
+			name: "Page number profile keeps the size",
+			url:  "/users?page[number]=2",
+			data: []interface{}{1},
+			want: pagination.Links{
+				First: "/users?page[size]=2",
+				Prev:  "/users?page[size]=2",
+			},
+		},
+	}
+
+	policy := pagination.Policy{DefaultLimit: 2, OmitDefaultParams: true}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			params, err := policy.FindParams(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, pagination.Paginate(tt.data, "/users", params).Links)
+		})
+	}
+
+	signed := policy
+	signed.LinkSigner = pagination.NewLinkSigner(tokenKey)
+	req, _ := http.NewRequest(http.MethodGet, "/users", nil)
+	params, err := signed.FindParams(req)
+	assert.NoError(t, err)
+	first := pagination.Paginate(nil, "/users", params).Links.First
+	assert.True(t, strings.HasPrefix(first, "/users?"+pagination.ParamSignature+"="))
+}
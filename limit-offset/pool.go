@@ -0,0 +1,59 @@
+package pagination
+
+import "sync"
+
+// maxPooledLinkBuffer is the biggest buffer capacity we keep on the pool, bigger
+// buffers are left to the garbage collector so a single huge link doesn't stay
+// retained forever
+const maxPooledLinkBuffer = 4 << 10
+
+var (
+	paramsPool = sync.Pool{
+		New: func() interface{} {
+			return new(Params)
+		},
+	}
+	linkBufferPool = sync.Pool{
+		New: func() interface{} {
+			buf := make([]byte, 0, 256)
+			return &buf
+		},
+	}
+)
+
+// AcquireParams will return an empty Params from the pool, it is meant to be
+// used together with FindParamsInto on very hot endpoints where we want to
+// avoid generating garbage per request.
+//
+// The returned Params, and the Sort slice it holds, belong to the caller until
+// ReleaseParams is called, after that they must not be used anymore. This also
+// means the Sort slice should not be retained or shared with other goroutines
+// that could outlive the request, copy it if you need it.
+func AcquireParams() *Params {
+	return paramsPool.Get().(*Params)
+}
+
+// ReleaseParams will reset the given Params and return it to the pool, the
+// Params can't be used after calling this function
+func ReleaseParams(params *Params) {
+	if params == nil {
+		return
+	}
+	*params = Params{Sort: params.Sort[:0]}
+	paramsPool.Put(params)
+}
+
+// acquireLinkBuffer function will return a buffer used for building links
+func acquireLinkBuffer() *[]byte {
+	return linkBufferPool.Get().(*[]byte)
+}
+
+// releaseLinkBuffer function will return the given buffer to the pool unless
+// it grew too much
+func releaseLinkBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledLinkBuffer {
+		return
+	}
+	*buf = (*buf)[:0]
+	linkBufferPool.Put(buf)
+}
@@ -0,0 +1,70 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAcquireAndReleaseParams(t *testing.T) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?page[limit]=5&page[offset]=10&sort=name.asc,created_at.desc",
+		nil,
+	)
+	assert.Nil(t, err)
+
+	params := pagination.AcquireParams()
+	assert.Nil(t, pagination.FindParamsInto(req, 0, 10, params))
+	assert.Equal(t, uint(5), params.Limit)
+	assert.Equal(t, uint(10), params.Offset)
+	assert.Equal(t, []pagination.Sort{
+		{Field: "name", Order: "asc"},
+		{Field: "created_at", Order: "desc"},
+	}, params.Sort)
+	pagination.ReleaseParams(params)
+
+	defaultReq, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample", nil)
+	assert.Nil(t, err)
+
+	params = pagination.AcquireParams()
+	assert.Nil(t, pagination.FindParamsInto(defaultReq, 2, 4, params))
+	assert.Equal(t, uint(4), params.Limit)
+	assert.Equal(t, uint(2), params.Offset)
+	assert.Equal(t, 0, len(params.Sort))
+	pagination.ReleaseParams(params)
+}
+
+func BenchmarkPaginate(b *testing.B) {
+	data := make([]interface{}, 11)
+	params := pagination.Params{
+		Limit:  10,
+		Offset: 20,
+		Sort:   []pagination.Sort{{Field: "name", Order: "asc"}},
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		pagination.Paginate(data, "/sample", params)
+	}
+}
+
+func BenchmarkFindParamsPooled(b *testing.B) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?filter=active&page[limit]=5&page[offset]=10&sort=name.asc,created_at.desc",
+		nil,
+	)
+	assert.Nil(b, err)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		params := pagination.AcquireParams()
+		if err := pagination.FindParamsInto(req, 0, 10, params); err != nil {
+			b.Fatal(err)
+		}
+		pagination.ReleaseParams(params)
+	}
+}
@@ -0,0 +1,127 @@
+// Package prompagination provides a Prometheus collector fed by the pagination
+// MetricsHook, so every service exports the same metrics and buckets
+package prompagination
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+var (
+	// LimitBuckets are the buckets of the requested limit histogram
+	LimitBuckets = []float64{1, 5, 10, 20, 25, 50, 100, 250, 500, 1000}
+	// DepthBuckets are the buckets of the page depth histogram
+	DepthBuckets = []float64{1, 2, 3, 5, 10, 25, 50, 100, 500, 1000}
+)
+
+// Collector type implements both the prometheus.Collector and the
+// pagination.MetricsHook, set it on the Metrics of the Policy and register it
+// on the Prometheus registry
+type Collector struct {
+	limit            prometheus.Histogram
+	depth            prometheus.Histogram
+	validationErrors prometheus.Counter
+	tokenTampering   prometheus.Counter
+	tokenRejections  *prometheus.CounterVec
+	pages            prometheus.Counter
+}
+
+var (
+	_ pagination.MetricsHook      = (*Collector)(nil)
+	_ pagination.TokenMetricsHook = (*Collector)(nil)
+	_ prometheus.Collector        = (*Collector)(nil)
+)
+
+// New will build a new collector, the metrics are named using the given
+// namespace, for example myapp_pagination_requested_limit
+func New(namespace string) *Collector {
+	return &Collector{
+		limit: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "requested_limit",
+			Help:      "Limit of the valid pagination requests.",
+			Buckets:   LimitBuckets,
+		}),
+		depth: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "page_depth",
+			Help:      "Page number, starting on 1, of the valid pagination requests.",
+			Buckets:   DepthBuckets,
+		}),
+		validationErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "validation_errors_total",
+			Help:      "Pagination requests refused because of invalid params.",
+		}),
+		tokenTampering: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "token_tampering_total",
+			Help:      "Page tokens refused because they were tampered.",
+		}),
+		tokenRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "token_rejections_total",
+			Help:      "Page tokens refused by failure.",
+		}, []string{"failure"}),
+		pages: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "pagination",
+			Name:      "pages_served_total",
+			Help:      "Pages served.",
+		}),
+	}
+}
+
+// OnParse will observe the limit and depth of the valid requests and count the
+// invalid ones
+func (c *Collector) OnParse(event pagination.ParseEvent) {
+	if event.Err != nil {
+		c.validationErrors.Inc()
+		return
+	}
+	c.limit.Observe(float64(event.Limit))
+	c.depth.Observe(float64(event.Depth))
+}
+
+// OnPageServed will count the page served
+func (c *Collector) OnPageServed(event pagination.PageEvent) {
+	c.pages.Inc()
+}
+
+// OnTokenRejected will count the page token refused by its failure, the
+// tampered ones are also counted on the tampering counter
+func (c *Collector) OnTokenRejected(failure pagination.TokenFailure) {
+	c.tokenRejections.WithLabelValues(string(failure)).Inc()
+	if failure == pagination.TokenTampered {
+		c.tokenTampering.Inc()
+	}
+}
+
+// TokenTampered will count a tampered page token
+func (c *Collector) TokenTampered() {
+	c.OnTokenRejected(pagination.TokenTampered)
+}
+
+// Describe will send the descriptors of all the metrics
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics() {
+		m.Describe(ch)
+	}
+}
+
+// Collect will send all the metrics
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	for _, m := range c.metrics() {
+		m.Collect(ch)
+	}
+}
+
+// metrics method will return all the metrics of the collector
+func (c *Collector) metrics() []prometheus.Collector {
+	return []prometheus.Collector{c.limit, c.depth, c.validationErrors, c.tokenTampering, c.tokenRejections, c.pages}
+}
@@ -0,0 +1,50 @@
+package prompagination_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/prompagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollector(t *testing.T) {
+	collector := prompagination.New("test")
+	registry := prometheus.NewRegistry()
+	assert.Nil(t, registry.Register(collector))
+	policy := pagination.Policy{DefaultLimit: 10, Metrics: collector}
+
+	for _, url := range []string{
+		"app.quicka.co/api/sample",
+		"app.quicka.co/api/sample?page[limit]=50&page[offset]=100",
+		"app.quicka.co/api/sample?page[limit]=wrong",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		if params, err := policy.FindParams(req); err == nil {
+			pagination.Paginate(nil, "/sample", params)
+		}
+	}
+	collector.TokenTampered()
+
+	err := testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP test_pagination_pages_served_total Pages served.
+# TYPE test_pagination_pages_served_total counter
+test_pagination_pages_served_total 2
+# HELP test_pagination_token_tampering_total Page tokens refused because they were tampered.
+# TYPE test_pagination_token_tampering_total counter
+test_pagination_token_tampering_total 1
+# HELP test_pagination_validation_errors_total Pagination requests refused because of invalid params.
+# TYPE test_pagination_validation_errors_total counter
+test_pagination_validation_errors_total 1
+`), "test_pagination_pages_served_total", "test_pagination_token_tampering_total", "test_pagination_validation_errors_total")
+	assert.Nil(t, err)
+
+	count, err := testutil.GatherAndCount(registry, "test_pagination_requested_limit", "test_pagination_page_depth")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, count)
+}
@@ -0,0 +1,49 @@
+package paginationv1
+
+import (
+	"encoding/base64"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"google.golang.org/protobuf/proto"
+)
+
+// EncodeProtoCursor will build the opaque cursor carrying the given message,
+// like the last values of the sort of the page, so the cursors are strongly
+// typed across the services. The message is marshaled and signed when a codec
+// is given, just encoded with base64 otherwise, see DecodeProtoCursor
+func EncodeProtoCursor(tokens *pagination.TokenCodec, msg proto.Message) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+	if tokens == nil {
+		return payload, nil
+	}
+	return tokens.Encode(pagination.PageToken{Query: payload}), nil
+}
+
+// DecodeProtoCursor will answer back the message carried by a cursor built
+// with EncodeProtoCursor, the same codec must be given. A
+// *pagination.TokenError is returned for the cursors that can't be accepted,
+// including the ones carrying another message
+func DecodeProtoCursor[T proto.Message](tokens *pagination.TokenCodec, cursor string) (T, error) {
+	var msg T
+	payload := cursor
+	if tokens != nil {
+		token, err := tokens.Decode(cursor)
+		if err != nil {
+			return msg, err
+		}
+		payload = token.Query
+	}
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return msg, &pagination.TokenError{Failure: pagination.TokenMalformed, Reason: "the cursor is malformed"}
+	}
+	decoded := msg.ProtoReflect().Type().New().Interface().(T)
+	if err := proto.Unmarshal(b, decoded); err != nil {
+		return msg, &pagination.TokenError{Failure: pagination.TokenMalformed, Reason: "the cursor is malformed"}
+	}
+	return decoded, nil
+}
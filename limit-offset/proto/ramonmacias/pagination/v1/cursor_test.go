@@ -0,0 +1,42 @@
+package paginationv1_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestProtoCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens *pagination.TokenCodec
+	}{
+		{name: "Encoded with base64"},
+		{name: "Signed", tokens: pagination.NewTokenCodec([]byte("secret"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			last := &paginationv1.Filter{Field: "created_at", Operator: "lt", Values: []string{"2024-01-01T00:00:00Z"}}
+			cursor, err := paginationv1.EncodeProtoCursor(tt.tokens, last)
+			assert.NoError(t, err)
+
+			decoded, err := paginationv1.DecodeProtoCursor[*paginationv1.Filter](tt.tokens, cursor)
+			assert.NoError(t, err)
+			assert.True(t, proto.Equal(last, decoded))
+
+			_, err = paginationv1.DecodeProtoCursor[*paginationv1.Filter](tt.tokens, "not a cursor!")
+			assert.ErrorIs(t, err, pagination.ErrTokenMalformed)
+		})
+	}
+}
+
+func TestProtoCursorTampered(t *testing.T) {
+	cursor, err := paginationv1.EncodeProtoCursor(nil, &paginationv1.Sort{Field: "id"})
+	assert.NoError(t, err)
+	_, err = paginationv1.DecodeProtoCursor[*paginationv1.Sort](pagination.NewTokenCodec([]byte("secret")), cursor)
+	assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+}
@@ -0,0 +1,85 @@
+package paginationv1
+
+import (
+	"strings"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// SortFromParams will convert the sort of the params into the proto sort
+func SortFromParams(sort []pagination.Sort) []*Sort {
+	if len(sort) == 0 {
+		return nil
+	}
+	converted := make([]*Sort, 0, len(sort))
+	for _, s := range sort {
+		order := SortOrder_SORT_ORDER_ASC
+		if strings.EqualFold(s.Order, "desc") {
+			order = SortOrder_SORT_ORDER_DESC
+		}
+		converted = append(converted, &Sort{Field: s.Field, Order: order})
+	}
+	return converted
+}
+
+// SortParams will convert the proto sort into the sort of the params, the
+// unspecified order is ascending
+func SortParams(sort []*Sort) []pagination.Sort {
+	if len(sort) == 0 {
+		return nil
+	}
+	converted := make([]pagination.Sort, 0, len(sort))
+	for _, s := range sort {
+		order := "asc"
+		if s.GetOrder() == SortOrder_SORT_ORDER_DESC {
+			order = "desc"
+		}
+		converted = append(converted, pagination.Sort{Field: s.GetField(), Order: order})
+	}
+	return converted
+}
+
+// FiltersFromParams will convert the filters of the params into the proto
+// filters
+func FiltersFromParams(filters pagination.Filters) []*Filter {
+	if len(filters) == 0 {
+		return nil
+	}
+	converted := make([]*Filter, 0, len(filters))
+	for _, f := range filters {
+		values := f.Values
+		if f.Operator != pagination.OpIn {
+			values = []string{f.Value}
+		}
+		converted = append(converted, &Filter{Field: f.Field, Operator: string(f.Operator), Values: values})
+	}
+	return converted
+}
+
+// FilterParams will convert the proto filters into the filters of the
+// params, the empty operator is eq
+func FilterParams(filters []*Filter) pagination.Filters {
+	if len(filters) == 0 {
+		return nil
+	}
+	converted := make(pagination.Filters, 0, len(filters))
+	for _, f := range filters {
+		filter := pagination.Filter{Field: f.GetField(), Operator: pagination.Operator(f.GetOperator())}
+		if filter.Operator == "" {
+			filter.Operator = pagination.OpEq
+		}
+		if filter.Operator == pagination.OpIn {
+			filter.Values = f.GetValues()
+		} else if len(f.GetValues()) > 0 {
+			filter.Value = f.GetValues()[0]
+		}
+		converted = append(converted, filter)
+	}
+	return converted
+}
+
+// LinksFromParams will convert the links of a paginated response into the
+// proto links
+func LinksFromParams(links pagination.Links) *Links {
+	return &Links{First: links.First, Prev: links.Prev, Next: links.Next, Last: links.Last}
+}
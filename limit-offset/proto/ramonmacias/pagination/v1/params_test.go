@@ -0,0 +1,44 @@
+package paginationv1_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestSort(t *testing.T) {
+	sort := []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "id", Order: "asc"}}
+	converted := paginationv1.SortFromParams(sort)
+	assert.Len(t, converted, 2)
+	assert.Equal(t, paginationv1.SortOrder_SORT_ORDER_DESC, converted[0].GetOrder())
+	assert.Equal(t, sort, paginationv1.SortParams(converted))
+	assert.Equal(t, []pagination.Sort{{Field: "id", Order: "asc"}}, paginationv1.SortParams([]*paginationv1.Sort{{Field: "id"}}))
+	assert.Nil(t, paginationv1.SortFromParams(nil))
+}
+
+func TestFilters(t *testing.T) {
+	filters := pagination.Filters{
+		{Field: "status", Operator: pagination.OpIn, Values: []string{"active", "pending"}},
+		{Field: "created_at", Operator: pagination.OpGte, Value: "2024-01-01"},
+	}
+	converted := paginationv1.FiltersFromParams(filters)
+	assert.Equal(t, []string{"2024-01-01"}, converted[1].GetValues())
+	assert.Equal(t, filters, paginationv1.FilterParams(converted))
+	assert.Equal(t, pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}}, paginationv1.FilterParams([]*paginationv1.Filter{{Field: "status", Values: []string{"active"}}}))
+}
+
+func TestWireFormat(t *testing.T) {
+	req := &paginationv1.PageRequest{PageSize: 20, PageToken: "abc", Sort: paginationv1.SortFromParams([]pagination.Sort{{Field: "id", Order: "asc"}})}
+	b, err := proto.Marshal(req)
+	assert.NoError(t, err)
+	decoded := &paginationv1.PageRequest{}
+	assert.NoError(t, proto.Unmarshal(b, decoded))
+	assert.True(t, proto.Equal(req, decoded))
+
+	res := &paginationv1.PageResponse{NextPageToken: "def", TotalSize: proto.Int64(213), Links: paginationv1.LinksFromParams(pagination.Links{First: "/users?page[limit]=20&page[offset]=0"})}
+	assert.Equal(t, int64(213), res.GetTotalSize())
+	assert.Equal(t, "/users?page[limit]=20&page[offset]=0", res.GetLinks().GetFirst())
+}
@@ -0,0 +1,124 @@
+package paginationv1
+
+import (
+	"errors"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ErrNegativePageSize is the error returned by NormalizeRequest for the
+// requests with a negative page_size
+var ErrNegativePageSize = errors.New("pagination: the page_size can't be negative")
+
+const (
+	// fieldPageSize is the name of the page size field of the requests
+	fieldPageSize = "page_size"
+	// fieldPageToken is the name of the page token field of the requests
+	fieldPageToken = "page_token"
+	// fieldNextPageToken is the name of the next page token field of the
+	// responses
+	fieldNextPageToken = "next_page_token"
+)
+
+// NormalizeRequest will apply the given policy to the page_size and
+// page_token fields of the given request, any message having them like the
+// PageRequest or the list requests following AIP-158. The zero page_size is
+// set to the DefaultLimit and the ones beyond the MaxLimit are lowered to it,
+// while the page_token must be a cursor built with pagination.OffsetCursor
+// and the Tokens of the policy, a *pagination.TokenError is returned for the
+// malformed, tampered or expired ones. The messages without the fields are
+// left as they are
+func NormalizeRequest(msg proto.Message, policy pagination.Policy) error {
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	if field := fields.ByName(fieldPageSize); field != nil {
+		size, ok := intValue(m.Get(field), field.Kind())
+		switch {
+		case !ok:
+		case size < 0:
+			return ErrNegativePageSize
+		case size == 0:
+			m.Set(field, fromInt(int64(policy.DefaultLimit), field.Kind()))
+		case policy.MaxLimit > 0 && uint64(size) > uint64(policy.MaxLimit):
+			m.Set(field, fromInt(int64(policy.MaxLimit), field.Kind()))
+		}
+	}
+	if field := fields.ByName(fieldPageToken); field != nil && field.Kind() == protoreflect.StringKind {
+		if token := m.Get(field).String(); token != "" {
+			if _, err := pagination.CursorOffset(policy.Tokens, token); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RequestParams will find the params of the given request after applying the
+// policy with NormalizeRequest, the page_size is the limit and the page_token
+// carries the offset. The sort and filters are also found on the PageRequest
+func RequestParams(msg proto.Message, policy pagination.Policy) (pagination.Params, error) {
+	if err := NormalizeRequest(msg, policy); err != nil {
+		return pagination.Params{}, err
+	}
+	params := pagination.Params{Limit: policy.DefaultLimit}
+	m := msg.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	if field := fields.ByName(fieldPageSize); field != nil {
+		if size, ok := intValue(m.Get(field), field.Kind()); ok {
+			params.Limit = uint(size)
+		}
+	}
+	if field := fields.ByName(fieldPageToken); field != nil && field.Kind() == protoreflect.StringKind {
+		if token := m.Get(field).String(); token != "" {
+			offset, err := pagination.CursorOffset(policy.Tokens, token)
+			if err != nil {
+				return pagination.Params{}, err
+			}
+			params.Offset = offset
+		}
+	}
+	if req, ok := msg.(*PageRequest); ok {
+		params.Sort = SortParams(req.GetSort())
+		params.Filters = FilterParams(req.GetFilters())
+	}
+	return params, nil
+}
+
+// SetNextPageToken will set the next_page_token field of the given response,
+// it answers back false when the message has no such field
+func SetNextPageToken(msg proto.Message, token string) bool {
+	m := msg.ProtoReflect()
+	field := m.Descriptor().Fields().ByName(fieldNextPageToken)
+	if field == nil || field.Kind() != protoreflect.StringKind {
+		return false
+	}
+	m.Set(field, protoreflect.ValueOfString(token))
+	return true
+}
+
+// intValue function will answer back the value of an integer field
+func intValue(v protoreflect.Value, kind protoreflect.Kind) (int64, bool) {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int(), true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return int64(v.Uint()), true
+	}
+	return 0, false
+}
+
+// fromInt function will build the value of an integer field
+func fromInt(n int64, kind protoreflect.Kind) protoreflect.Value {
+	switch kind {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return protoreflect.ValueOfInt32(int32(n))
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return protoreflect.ValueOfUint32(uint32(n))
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return protoreflect.ValueOfUint64(uint64(n))
+	}
+	return protoreflect.ValueOfInt64(n)
+}
@@ -0,0 +1,93 @@
+package paginationv1_test
+
+import (
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	paginationv1 "github.com/ramonmacias/go-pagination/limit-offset/proto/ramonmacias/pagination/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestNormalizeRequest(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 20, MaxLimit: 100}
+	tests := []struct {
+		name         string
+		req          *paginationv1.PageRequest
+		wantPageSize int32
+		wantErr      error
+	}{
+		{
+			name:         "Default page size",
+			req:          &paginationv1.PageRequest{},
+			wantPageSize: 20,
+		},
+		{
+			name:         "Page size beyond the max",
+			req:          &paginationv1.PageRequest{PageSize: 500},
+			wantPageSize: 100,
+		},
+		{
+			name:         "Valid page token",
+			req:          &paginationv1.PageRequest{PageSize: 10, PageToken: pagination.OffsetCursor(nil, 30)},
+			wantPageSize: 10,
+		},
+		{
+			name:         "Negative page size",
+			req:          &paginationv1.PageRequest{PageSize: -1},
+			wantPageSize: -1,
+			wantErr:      paginationv1.ErrNegativePageSize,
+		},
+		{
+			name:         "Malformed page token",
+			req:          &paginationv1.PageRequest{PageSize: 10, PageToken: "not a token!"},
+			wantPageSize: 10,
+			wantErr:      pagination.ErrTokenMalformed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := paginationv1.NormalizeRequest(tt.req, policy)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantPageSize, tt.req.GetPageSize())
+		})
+	}
+}
+
+func TestNormalizeRequestExpiredToken(t *testing.T) {
+	tokens := pagination.NewTokenCodec([]byte("secret"))
+	token := pagination.OffsetCursor(tokens, 10)
+	tokens.TTL = time.Nanosecond
+	time.Sleep(time.Millisecond)
+	err := paginationv1.NormalizeRequest(&paginationv1.PageRequest{PageToken: token}, pagination.Policy{Tokens: tokens})
+	assert.ErrorIs(t, err, pagination.ErrTokenExpired)
+}
+
+func TestNormalizeRequestOtherMessages(t *testing.T) {
+	msg := wrapperspb.String("no page fields")
+	assert.NoError(t, paginationv1.NormalizeRequest(msg, pagination.Policy{DefaultLimit: 20}))
+	assert.Equal(t, "no page fields", msg.GetValue())
+}
+
+func TestRequestParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 20, MaxLimit: 100}
+	req := &paginationv1.PageRequest{
+		PageToken: pagination.OffsetCursor(nil, 40),
+		Sort:      []*paginationv1.Sort{{Field: "id", Order: paginationv1.SortOrder_SORT_ORDER_DESC}},
+	}
+	params, err := paginationv1.RequestParams(req, policy)
+	assert.NoError(t, err)
+	assert.Equal(t, pagination.Params{Limit: 20, Offset: 40, Sort: []pagination.Sort{{Field: "id", Order: "desc"}}}, params)
+
+	_, err = paginationv1.RequestParams(&paginationv1.PageRequest{PageToken: "forged"}, policy)
+	assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+}
+
+func TestSetNextPageToken(t *testing.T) {
+	res := &paginationv1.PageResponse{}
+	assert.True(t, paginationv1.SetNextPageToken(res, "abc"))
+	assert.Equal(t, "abc", res.GetNextPageToken())
+	assert.False(t, paginationv1.SetNextPageToken(&paginationv1.PageRequest{}, "abc"))
+}
@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"strconv"
+)
+
+// SortRandom is the sort field for a shuffled order, when the policy enables
+// the random sort a seed is generated on the first page and kept on the links,
+// so the following pages use the same order without repeating items
+const SortRandom = "random"
+
+// NewSeed will generate a new seed for the random sort
+func NewSeed() string {
+	var b [4]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand never fails on the supported platforms, anyway a fixed
+		// seed is still a valid random order
+		return "1"
+	}
+	return strconv.FormatUint(uint64(binary.BigEndian.Uint32(b[:])), 10)
+}
+
+// random method will render the expression used for sorting the given unique
+// column in a shuffled but deterministic order for the given seed. The seed
+// is always a number so it is safe to write it into the query
+func (d Dialect) random(seed, column string) string {
+	switch d {
+	case DialectMySQL:
+		return "MD5(CONCAT('" + seed + "', " + column + "))"
+	case DialectSQLite:
+		// SQLite doesn't have any hash function built in
+		return "((" + column + " + " + seed + ") * 2654435761 % 4294967296)"
+	}
+	return "md5('" + seed + "' || " + column + ")"
+}
+
+// applyRandomSort method will set the random expression on the random sort
+// fields, the seed of the request is validated and a new one is generated
+// when the client didn't send any
+func (p Policy) applyRandomSort(params *Params) error {
+	random := false
+	for _, s := range params.Sort {
+		if s.Field == SortRandom {
+			random = true
+		}
+	}
+	if !random {
+		params.Seed = ""
+		return nil
+	}
+	if params.Seed == "" {
+		params.Seed = NewSeed()
+	} else if _, err := strconv.ParseUint(params.Seed, 10, 32); err != nil {
+		return err
+	}
+	column := p.RandomSortColumn
+	if column == "" {
+		column = p.TieBreaker
+	}
+	if column == "" {
+		column = "id"
+	}
+	for i, s := range params.Sort {
+		if s.Field == SortRandom {
+			params.Sort[i].Columns = []string{p.Dialect.random(params.Seed, column)}
+		}
+	}
+	return nil
+}
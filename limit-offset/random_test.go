@@ -0,0 +1,93 @@
+package pagination_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSeed(t *testing.T) {
+	seed := pagination.NewSeed()
+	_, err := strconv.ParseUint(seed, 10, 32)
+	assert.Nil(t, err)
+}
+
+func TestPolicyRandomSort(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    pagination.Policy
+		url       string
+		wantQuery string
+		wantNext  string
+		wantErr   bool
+	}{
+		{
+			name:      "Should use the seed of the request",
+			policy:    pagination.Policy{DefaultLimit: 2, RandomSort: true},
+			url:       "app.quicka.co/api/sample?sort=random&seed=1234&page[offset]=2",
+			wantQuery: " LIMIT 3 OFFSET 2 ORDER BY md5('1234' || id) asc",
+			wantNext:  "/sample?page[limit]=2&page[offset]=4&sort=random&seed=1234",
+		},
+		{
+			name:      "Should render the MySQL dialect",
+			policy:    pagination.Policy{DefaultLimit: 2, RandomSort: true, Dialect: pagination.DialectMySQL, RandomSortColumn: "uuid"},
+			url:       "app.quicka.co/api/sample?sort=random.asc&seed=1234",
+			wantQuery: " LIMIT 3 OFFSET 0 ORDER BY MD5(CONCAT('1234', uuid)) asc",
+			wantNext:  "/sample?page[limit]=2&page[offset]=2&sort=random.asc&seed=1234",
+		},
+		{
+			name:      "Should render the SQLite dialect",
+			policy:    pagination.Policy{DefaultLimit: 2, RandomSort: true, Dialect: pagination.DialectSQLite, TieBreaker: "rowid"},
+			url:       "app.quicka.co/api/sample?sort=random&seed=1234",
+			wantQuery: " LIMIT 3 OFFSET 0 ORDER BY ((rowid + 1234) * 2654435761 % 4294967296) asc,rowid asc",
+			wantNext:  "/sample?page[limit]=2&page[offset]=2&sort=random&seed=1234",
+		},
+		{
+			name:    "Should refuse a wrong seed",
+			policy:  pagination.Policy{DefaultLimit: 2, RandomSort: true},
+			url:     "app.quicka.co/api/sample?sort=random&seed=1'--",
+			wantErr: true,
+		},
+		{
+			name:      "Should drop the seed without random sort",
+			policy:    pagination.Policy{DefaultLimit: 2, RandomSort: true},
+			url:       "app.quicka.co/api/sample?sort=name.asc&seed=1234",
+			wantQuery: " LIMIT 3 OFFSET 0 ORDER BY name asc",
+			wantNext:  "/sample?page[limit]=2&page[offset]=2&sort=name.asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := tt.policy.FindParams(req)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantQuery, params.Query())
+			data := make([]interface{}, 3)
+			assert.Equal(t, tt.wantNext, pagination.Paginate(data, "/sample", params).Links.Next)
+		})
+	}
+}
+
+func TestPolicyRandomSortGeneratesSeed(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 2,
+		RandomSort:   true,
+		AllowedSorts: []string{"name"},
+	}
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=-random", nil)
+	assert.Nil(t, err)
+
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.NotEmpty(t, params.Seed)
+	assert.Equal(t, "sort=-random&seed="+params.Seed, params.SortURL())
+}
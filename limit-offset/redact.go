@@ -0,0 +1,146 @@
+package pagination
+
+import (
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Redacted is the value recorded instead of the redacted ones
+const Redacted = "[REDACTED]"
+
+// Redactor type defines a redaction rule, it answers back the value to be
+// recorded for the value of the given filter field or param name, like email
+// or page[token], the value itself when it isn't sensitive
+type Redactor func(name, value string) string
+
+// RedactFields will build a redaction rule replacing every value of the given
+// filter fields or params
+func RedactFields(names ...string) Redactor {
+	return func(name, value string) string {
+		if slices.Contains(names, name) {
+			return Redacted
+		}
+		return value
+	}
+}
+
+// RedactPattern will build a redaction rule replacing the matches of the
+// given pattern on any value, like the emails
+func RedactPattern(pattern *regexp.Regexp) Redactor {
+	return func(name, value string) string {
+		return pattern.ReplaceAllLiteralString(value, Redacted)
+	}
+}
+
+// redact function will apply all the given rules to the value
+func redact(redactions []Redactor, name, value string) string {
+	for _, redaction := range redactions {
+		value = redaction(name, value)
+	}
+	return value
+}
+
+// redactQuery function will apply the given rules to the values of the given
+// raw query, the filters are redacted by their field
+func redactQuery(redactions []Redactor, rawQuery string) string {
+	if len(redactions) == 0 || rawQuery == "" {
+		return rawQuery
+	}
+	pairs := strings.Split(rawQuery, "&")
+	for i, pair := range pairs {
+		key, value, found := strings.Cut(pair, "=")
+		name, ok := unescapeQuery(key)
+		if !ok || !found {
+			continue
+		}
+		if field, _, ok := filterKey(name); ok {
+			name = field
+		}
+		unescaped, ok := unescapeQuery(value)
+		if !ok {
+			unescaped = value
+		}
+		if redacted := redact(redactions, name, unescaped); redacted != unescaped {
+			pairs[i] = key + "=" + url.QueryEscape(redacted)
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+// redactFilters function will answer back a copy of the given filters with
+// the rules applied to their values
+func redactFilters(redactions []Redactor, filters Filters) Filters {
+	if len(redactions) == 0 || len(filters) == 0 {
+		return filters
+	}
+	redacted := make(Filters, len(filters))
+	for i, filter := range filters {
+		filter.Value = redact(redactions, filter.Field, filter.Value)
+		if len(filter.Values) > 0 {
+			values := make([]string, len(filter.Values))
+			for j, value := range filter.Values {
+				values[j] = redact(redactions, filter.Field, value)
+			}
+			filter.Values = values
+		}
+		filter.Parsed = nil
+		redacted[i] = filter
+	}
+	return redacted
+}
+
+// redactedError type hides the sensitive values on the message of an error,
+// errors.Is and errors.As still match the original one
+type redactedError struct {
+	err      error
+	replacer *strings.Replacer
+}
+
+// Error method will describe the error with the sensitive values redacted
+func (e *redactedError) Error() string {
+	return e.replacer.Replace(e.err.Error())
+}
+
+// Unwrap method will answer back the original error
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// redactError function will hide on the message of the given error the
+// sensitive values of the raw query and the filters
+func redactError(redactions []Redactor, err error, rawQuery string, filters Filters) error {
+	if err == nil || len(redactions) == 0 {
+		return err
+	}
+	var replacements []string
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		if redacted := redact(redactions, name, value); redacted != value {
+			replacements = append(replacements, value, redacted)
+		}
+	}
+	for _, pair := range strings.Split(rawQuery, "&") {
+		key, value, _ := strings.Cut(pair, "=")
+		name, _ := unescapeQuery(key)
+		if field, _, ok := filterKey(name); ok {
+			name = field
+		}
+		if unescaped, ok := unescapeQuery(value); ok {
+			add(name, unescaped)
+		}
+	}
+	for _, filter := range filters {
+		add(filter.Field, filter.Value)
+		for _, value := range filter.Values {
+			add(filter.Field, value)
+		}
+	}
+	if len(replacements) == 0 {
+		return err
+	}
+	return &redactedError{err: err, replacer: strings.NewReplacer(replacements...)}
+}
@@ -0,0 +1,91 @@
+package pagination_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+var emails = regexp.MustCompile(`[^@\s,]+@[^@\s,]+`)
+
+type parseRecordingHook struct {
+	pagination.NopMetricsHook
+	events []pagination.ParseEvent
+}
+
+func (h *parseRecordingHook) OnParse(event pagination.ParseEvent) {
+	h.events = append(h.events, event)
+}
+
+func TestRedactionsOnLogsAndMetrics(t *testing.T) {
+	var logs bytes.Buffer
+	hook := &parseRecordingHook{}
+	policy := pagination.Policy{
+		Logger:       slog.New(slog.NewTextHandler(&logs, nil)),
+		Metrics:      hook,
+		FilterSchema: pagination.FilterSchema{"email": {Type: pagination.FilterInt}, "user_id": {Type: pagination.FilterInt}},
+		Redactions: []pagination.Redactor{
+			pagination.RedactPattern(emails),
+			pagination.RedactFields("user_id"),
+		},
+	}
+
+	_, err := policy.FindParams(httptest.NewRequest("GET", "/users?filter[email]=jane%40example.com", nil))
+	assert.ErrorIs(t, err, pagination.ErrInvalidFilter)
+	assert.NotContains(t, err.Error(), "jane")
+	assert.NotContains(t, logs.String(), "jane")
+	assert.Contains(t, logs.String(), "[REDACTED]")
+	if assert.Len(t, hook.events, 1) {
+		assert.True(t, errors.Is(hook.events[0].Err, pagination.ErrInvalidFilter))
+		assert.NotContains(t, hook.events[0].Err.Error(), "jane")
+	}
+
+	logs.Reset()
+	_, err = policy.FindParams(httptest.NewRequest("GET", "/users?filter[user_id][gt]=secret-42", nil))
+	assert.Error(t, err)
+	assert.NotContains(t, logs.String(), "secret-42")
+}
+
+func TestRedactionsOnParamsLogs(t *testing.T) {
+	var logs bytes.Buffer
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		FilterSchema: pagination.FilterSchema{"age": {Type: pagination.FilterInt}, "email": {Type: pagination.FilterString}},
+		Redactions:   []pagination.Redactor{pagination.RedactFields("email"), pagination.RedactPattern(emails)},
+	}
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?filter[email]=jane%40example.com", nil))
+	assert.NoError(t, err)
+
+	slog.New(slog.NewTextHandler(&logs, nil)).Info("listing", "params", params)
+	assert.NotContains(t, logs.String(), "jane")
+	assert.NotContains(t, params.String(), "jane")
+	assert.Contains(t, params.String(), "filters=filter[email]=%5BREDACTED%5D")
+	assert.Equal(t, "jane@example.com", params.Filters[0].Value)
+
+	_, err = policy.FindParamsStrict(httptest.NewRequest("GET", "/users?filter[age]=jane%40example.com", nil))
+	assert.ErrorIs(t, err, pagination.ErrInvalidFilter)
+	assert.NotContains(t, err.Error(), "jane")
+	var paramsErr *pagination.ParamsError
+	if assert.ErrorAs(t, err, &paramsErr) {
+		assert.Len(t, paramsErr.Problems, 1)
+	}
+}
+
+func TestRedactionsOnDebug(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		Debug:        true,
+		Redactions:   []pagination.Redactor{pagination.RedactFields("email")},
+	}
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?filter[email]=in:a%40x.com,b%40x.com&filter[status]=active", nil))
+	assert.NoError(t, err)
+	debug := pagination.Paginate(nil, "/users", params).Meta["debug"].(*pagination.Debug)
+	assert.Equal(t, "filter[email][in]=%5BREDACTED%5D&filter[status]=active", debug.Filters)
+	assert.Equal(t, "a@x.com", params.Filters[0].Values[0])
+}
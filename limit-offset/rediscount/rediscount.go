@@ -0,0 +1,47 @@
+// Package rediscount provides a Redis implementation of the pagination
+// CountCache, so the totals can be shared between all the instances of a
+// service
+package rediscount
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache type implements the pagination.CountCache using Redis as storage
+type Cache struct {
+	client redis.UniversalClient
+	prefix string
+}
+
+var _ pagination.CountCache = (*Cache)(nil)
+
+// New will build a new Redis count cache, all the keys will be stored using the
+// given prefix, for example "pagination:count:"
+func New(client redis.UniversalClient, prefix string) *Cache {
+	return &Cache{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+// Get will return the cached total for the given key
+func (c *Cache) Get(ctx context.Context, key string) (uint, bool, error) {
+	total, err := c.client.Get(ctx, c.prefix+key).Uint64()
+	if errors.Is(err, redis.Nil) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return uint(total), true, nil
+}
+
+// Set will cache the total for the given key during the given ttl
+func (c *Cache) Set(ctx context.Context, key string, total uint, ttl time.Duration) error {
+	return c.client.Set(ctx, c.prefix+key, uint64(total), ttl).Err()
+}
@@ -0,0 +1,112 @@
+package rediscount_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/rediscount"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClient type implements the Get and Set commands of the Redis client over
+// a map, the entries expire following the now field
+type fakeClient struct {
+	redis.UniversalClient
+	values    map[string]string
+	expiresAt map[string]time.Time
+	now       time.Time
+	err       error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		values:    make(map[string]string),
+		expiresAt: make(map[string]time.Time),
+		now:       time.Now(),
+	}
+}
+
+func (c *fakeClient) Get(ctx context.Context, key string) *redis.StringCmd {
+	if c.err != nil {
+		return redis.NewStringResult("", c.err)
+	}
+	value, found := c.values[key]
+	if !found || !c.now.Before(c.expiresAt[key]) {
+		return redis.NewStringResult("", redis.Nil)
+	}
+	return redis.NewStringResult(value, nil)
+}
+
+func (c *fakeClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) *redis.StatusCmd {
+	if c.err != nil {
+		return redis.NewStatusResult("", c.err)
+	}
+	c.values[key] = strconv.FormatUint(value.(uint64), 10)
+	c.expiresAt[key] = c.now.Add(expiration)
+	return redis.NewStatusResult("OK", nil)
+}
+
+func TestCache(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	cache := rediscount.New(client, "pagination:count:")
+
+	// Miss
+	_, found, err := cache.Get(ctx, "users")
+	assert.Nil(t, err)
+	assert.False(t, found)
+
+	// Hit, stored with the prefix
+	assert.Nil(t, cache.Set(ctx, "users", 42, time.Minute))
+	assert.Equal(t, "42", client.values["pagination:count:users"])
+	total, found, err := cache.Get(ctx, "users")
+	assert.Nil(t, err)
+	assert.True(t, found)
+	assert.Equal(t, uint(42), total)
+
+	// Expired after the ttl
+	client.now = client.now.Add(2 * time.Minute)
+	_, found, err = cache.Get(ctx, "users")
+	assert.Nil(t, err)
+	assert.False(t, found)
+}
+
+func TestCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	client := newFakeClient()
+	cache := rediscount.New(client, "")
+
+	// A value that isn't a number is an error
+	client.values["users"] = "many"
+	client.expiresAt["users"] = client.now.Add(time.Minute)
+	_, _, err := cache.Get(ctx, "users")
+	assert.Error(t, err)
+
+	client.err = errors.New("connection refused")
+	_, found, err := cache.Get(ctx, "users")
+	assert.EqualError(t, err, "connection refused")
+	assert.False(t, found)
+	assert.EqualError(t, cache.Set(ctx, "users", 42, time.Minute), "connection refused")
+}
+
+func TestCachedCount(t *testing.T) {
+	ctx := context.Background()
+	cache := rediscount.New(newFakeClient(), "")
+	calls := 0
+	count := func(ctx context.Context) (uint, error) {
+		calls++
+		return 7, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		total, err := pagination.CachedCount(ctx, cache, "users", time.Minute, count)
+		assert.Nil(t, err)
+		assert.Equal(t, uint(7), total)
+	}
+	assert.Equal(t, 1, calls)
+}
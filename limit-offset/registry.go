@@ -0,0 +1,80 @@
+package pagination
+
+import (
+	"sort"
+	"sync"
+)
+
+// Registry type keeps the policies of the resources by name, so middlewares
+// and handlers resolve the right policy by the resource or route name and the
+// pagination rules can be governed from a single place. It is safe for
+// concurrent use
+type Registry struct {
+	mu       sync.RWMutex
+	policies map[string]Policy
+}
+
+// defaultRegistry is the registry used by Register and For
+var defaultRegistry Registry
+
+// Register will add the policy of the given resource to the default registry,
+// see Registry.Register
+func Register(resource string, policy Policy) {
+	defaultRegistry.Register(resource, policy)
+}
+
+// For will answer back the policy of the given resource on the default
+// registry, see Registry.For
+func For(resource string) (Policy, bool) {
+	return defaultRegistry.For(resource)
+}
+
+// Resources will answer back the names of the resources registered on the
+// default registry, sorted by name
+func Resources() []string {
+	return defaultRegistry.Resources()
+}
+
+// Register method will add the policy of the given resource, the Resource of
+// the policy is set to the given name when it is empty. Like http.Handle it
+// panics when the resource is empty or already registered, as it is a
+// programming error
+func (r *Registry) Register(resource string, policy Policy) {
+	if resource == "" {
+		panic("pagination: empty resource name")
+	}
+	if policy.Resource == "" {
+		policy.Resource = resource
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.policies[resource]; ok {
+		panic("pagination: policy registered twice for " + resource)
+	}
+	if r.policies == nil {
+		r.policies = make(map[string]Policy)
+	}
+	r.policies[resource] = policy
+}
+
+// For method will answer back the policy of the given resource, found is false
+// when it wasn't registered
+func (r *Registry) For(resource string) (policy Policy, found bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	policy, found = r.policies[resource]
+	return policy, found
+}
+
+// Resources method will answer back the names of the resources registered,
+// sorted by name
+func (r *Registry) Resources() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	resources := make([]string, 0, len(r.policies))
+	for resource := range r.policies {
+		resources = append(resources, resource)
+	}
+	sort.Strings(resources)
+	return resources
+}
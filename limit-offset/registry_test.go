@@ -0,0 +1,40 @@
+package pagination_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry(t *testing.T) {
+	var registry pagination.Registry
+	registry.Register("users", pagination.Policy{DefaultLimit: 10})
+	registry.Register("orders", pagination.Policy{DefaultLimit: 50, Resource: "customer_orders"})
+
+	policy, found := registry.For("users")
+	assert.True(t, found)
+	assert.Equal(t, uint(10), policy.DefaultLimit)
+	assert.Equal(t, "users", policy.Resource)
+
+	policy, found = registry.For("orders")
+	assert.True(t, found)
+	assert.Equal(t, "customer_orders", policy.Resource)
+
+	_, found = registry.For("invoices")
+	assert.False(t, found)
+
+	assert.Equal(t, []string{"orders", "users"}, registry.Resources())
+
+	assert.Panics(t, func() { registry.Register("users", pagination.Policy{}) })
+	assert.Panics(t, func() { registry.Register("", pagination.Policy{}) })
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	pagination.Register("registry_test_users", pagination.Policy{DefaultLimit: 25})
+
+	policy, found := pagination.For("registry_test_users")
+	assert.True(t, found)
+	assert.Equal(t, uint(25), policy.DefaultLimit)
+	assert.Contains(t, pagination.Resources(), "registry_test_users")
+}
@@ -0,0 +1,85 @@
+package relay
+
+import (
+	"strconv"
+	"strings"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// RowColumn is the column added by the query of the Batch to every row, with
+// its position on the items of its parent starting on 1
+const RowColumn = "pagination_row"
+
+// Batch type builds a single query fetching the pages of the connections of
+// several parents, like the comments of every post of a page, so the
+// paginated sub fields of a list don't run a query per parent. It is meant for
+// the dataloaders, which batch the fetches of the same field with the same
+// arguments for different parents
+type Batch struct {
+	// From is the table the items are selected from, or a subquery between
+	// parentheses
+	From string
+	// ParentColumn is the column referencing the parent of the items
+	ParentColumn string
+	// Columns maps the filter fields into the SQL columns, like Filters.Where
+	// does, the filters of the fields that aren't mapped are ignored
+	Columns map[string]string
+}
+
+// Query method will build the query fetching the page the given params are
+// pointing to for every given parent, plus the next item so we know if there
+// are more pages. The items are numbered per parent with the ROW_NUMBER
+// window function following the sort of the params, so a single query serves
+// all the parents, ordered by parent and position. The args are answered back
+// for the placeholders of the dialect of the params
+//
+//	query, args := batch.Query(params, postIDs)
+//	db.Select(&comments, query, args...)
+func (b Batch) Query(params pagination.Params, parents []interface{}) (string, []interface{}) {
+	placeholders := make([]string, 0, len(parents))
+	for i := range parents {
+		placeholders = append(placeholders, params.Dialect.Placeholder(i+1))
+	}
+	args := append([]interface{}{}, parents...)
+	condition := b.ParentColumn + " IN (" + strings.Join(placeholders, ", ") + ")"
+	if where, filterArgs := params.Filters.Where(b.Columns, params.Dialect, len(parents)+1); where != "" {
+		condition += " AND " + strings.TrimPrefix(where, " WHERE ")
+		args = append(args, filterArgs...)
+	}
+	window := "PARTITION BY " + b.ParentColumn
+	if orderBy := params.OrderBy(); orderBy != "" {
+		window += " ORDER BY " + orderBy
+	}
+
+	var query strings.Builder
+	query.WriteString("SELECT * FROM (SELECT pagination_items.*, ROW_NUMBER() OVER (")
+	query.WriteString(window)
+	query.WriteString(") AS " + RowColumn + " FROM ")
+	query.WriteString(b.From)
+	query.WriteString(" pagination_items WHERE ")
+	query.WriteString(condition)
+	query.WriteString(") pagination_batch WHERE " + RowColumn + " > ")
+	query.WriteString(strconv.FormatUint(uint64(params.Offset), 10))
+	query.WriteString(" AND " + RowColumn + " <= ")
+	query.WriteString(strconv.FormatUint(uint64(params.Offset+params.Limit+1), 10))
+	query.WriteString(" ORDER BY " + b.ParentColumn + ", " + RowColumn)
+	return query.String(), args
+}
+
+// Connections will group the rows fetched with the query of a Batch into the
+// connection of every given parent, answered back in the order of the parents
+// as the dataloaders expect. The parent function answers back the parent of a
+// row, the parents without rows get an empty connection
+func Connections[K comparable, T any](parents []K, rows []T, parent func(T) K, params pagination.Params, tokens *pagination.TokenCodec) []Connection[T] {
+	grouped := make(map[K][]T, len(parents))
+	for _, row := range rows {
+		key := parent(row)
+		grouped[key] = append(grouped[key], row)
+	}
+	connections := make([]Connection[T], 0, len(parents))
+	for _, key := range parents {
+		connections = append(connections, NewConnection(grouped[key], params, tokens))
+	}
+	return connections
+}
@@ -0,0 +1,61 @@
+package relay_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchQuery(t *testing.T) {
+	batch := relay.Batch{From: "comments", ParentColumn: "post_id", Columns: map[string]string{"status": "status"}}
+	tests := []struct {
+		name     string
+		params   pagination.Params
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "Sorted first pages",
+			params:   pagination.Params{Limit: 2, Sort: []pagination.Sort{{Field: "created_at", Order: "desc"}}},
+			wantSQL:  "SELECT * FROM (SELECT pagination_items.*, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY created_at desc) AS pagination_row FROM comments pagination_items WHERE post_id IN (?, ?)) pagination_batch WHERE pagination_row > 0 AND pagination_row <= 3 ORDER BY post_id, pagination_row",
+			wantArgs: []interface{}{1, 2},
+		},
+		{
+			name: "Filtered on PostgreSQL",
+			params: pagination.Params{
+				Limit:   10,
+				Offset:  10,
+				Dialect: pagination.DialectPostgres,
+				Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "approved"}, {Field: "secret", Operator: pagination.OpEq, Value: "x"}},
+			},
+			wantSQL:  "SELECT * FROM (SELECT pagination_items.*, ROW_NUMBER() OVER (PARTITION BY post_id) AS pagination_row FROM comments pagination_items WHERE post_id IN ($1, $2) AND status = $3) pagination_batch WHERE pagination_row > 10 AND pagination_row <= 21 ORDER BY post_id, pagination_row",
+			wantArgs: []interface{}{1, 2, "approved"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := batch.Query(tt.params, []interface{}{1, 2})
+			assert.Equal(t, tt.wantSQL, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+type comment struct {
+	PostID int
+	Body   string
+}
+
+func TestConnections(t *testing.T) {
+	rows := []comment{{PostID: 1, Body: "a"}, {PostID: 1, Body: "b"}, {PostID: 1, Body: "c"}, {PostID: 3, Body: "d"}}
+	connections := relay.Connections([]int{3, 2, 1}, rows, func(c comment) int { return c.PostID }, pagination.Params{Limit: 2}, nil)
+	assert.Len(t, connections, 3)
+	assert.Equal(t, "d", connections[0].Edges[0].Node.Body)
+	assert.False(t, connections[0].PageInfo.HasNextPage)
+	assert.Empty(t, connections[1].Edges)
+	assert.Len(t, connections[2].Edges, 2)
+	assert.True(t, connections[2].PageInfo.HasNextPage)
+}
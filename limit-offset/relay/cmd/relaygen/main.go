@@ -0,0 +1,60 @@
+// Command relaygen generates the Relay connection models, the GraphQL schema
+// and the resolver scaffolding of the given object types for gqlgen, see the
+// relaygen package. The resolvers file is only written when it doesn't exist,
+// as it is meant for being edited
+//
+//	relaygen -package graph -types User,Post
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ramonmacias/go-pagination/limit-offset/relay/relaygen"
+)
+
+func main() {
+	var (
+		config    relaygen.Config
+		types     string
+		models    string
+		schema    string
+		resolvers string
+	)
+	flag.StringVar(&config.Package, "package", "graph", "the Go package of the generated files")
+	flag.StringVar(&types, "types", "", "the comma separated GraphQL object types with a connection")
+	flag.StringVar(&config.Resolver, "resolver", "", "the type implementing the query resolvers, queryResolver by default")
+	flag.StringVar(&models, "models", "connections_gen.go", "the file of the models")
+	flag.StringVar(&schema, "schema", "connections.graphqls", "the file of the schema")
+	flag.StringVar(&resolvers, "resolvers", "connections.resolvers.go", "the file of the resolvers scaffolding")
+	flag.Parse()
+	if types == "" {
+		log.Fatal("relaygen: the -types flag is required")
+	}
+	config.Types = strings.Split(types, ",")
+
+	write(models, true, config, relaygen.Models)
+	write(schema, true, config, relaygen.Schema)
+	write(resolvers, false, config, relaygen.Resolvers)
+}
+
+// write function will generate the given file, the existing ones are only
+// overwritten when told so
+func write(name string, overwrite bool, config relaygen.Config, generate func(io.Writer, relaygen.Config) error) {
+	if _, err := os.Stat(name); !overwrite && !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	var b bytes.Buffer
+	if err := generate(&b, config); err != nil {
+		log.Fatalf("relaygen: %s: %v", name, err)
+	}
+	if err := os.WriteFile(name, b.Bytes(), 0o644); err != nil {
+		log.Fatalf("relaygen: %v", err)
+	}
+}
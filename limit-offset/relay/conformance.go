@@ -0,0 +1,115 @@
+package relay
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Conformance type encapsulates the checks of a connection field against the
+// Relay cursor connections specification, so the connections backed by the
+// databases can certify on their tests they answer back what the Relay
+// clients expect
+//
+//	err := relay.Conformance[User]{
+//	  Edges:   allUsers,
+//	  Resolve: resolver.Users,
+//	}.Check()
+type Conformance[T any] struct {
+	// Edges are all the edges of the connection, in order
+	Edges []Edge[T]
+	// Resolve resolves the connection field for the given arguments
+	Resolve func(args Args) (Connection[T], error)
+}
+
+// Check method will resolve the connection with the combinations of the
+// forward and backward arguments around every cursor, comparing the cursors
+// of the edges and the page info with the ones the Paginate algorithm answers
+// back, and the negative arguments must be refused. The error joins the
+// failure of every combination that didn't match
+func (c Conformance[T]) Check() error {
+	sizes := []int{0, 1, 2, len(c.Edges), len(c.Edges) + 1}
+	cursors := []*string{nil}
+	for i := range c.Edges {
+		cursors = append(cursors, &c.Edges[i].Cursor)
+	}
+	var errs []error
+	for _, size := range sizes {
+		for _, cursor := range cursors {
+			errs = append(errs,
+				c.check(Args{First: &size, After: cursor}),
+				c.check(Args{Last: &size, Before: cursor}),
+			)
+		}
+	}
+	negative := -1
+	for _, args := range []Args{{First: &negative}, {Last: &negative}} {
+		if _, err := c.Resolve(args); err == nil {
+			errs = append(errs, fmt.Errorf("relay: %s must be refused", describe(args)))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// check method will compare the connection resolved for the given arguments
+// with the expected one
+func (c Conformance[T]) check(args Args) error {
+	want, _ := Paginate(c.Edges, args)
+	got, err := c.Resolve(args)
+	if err != nil {
+		return fmt.Errorf("relay: %s: %w", describe(args), err)
+	}
+	if wantCursors, gotCursors := edgeCursors(want.Edges), edgeCursors(got.Edges); !slices.Equal(wantCursors, gotCursors) {
+		return fmt.Errorf("relay: %s answered back the edges %v, want %v", describe(args), gotCursors, wantCursors)
+	}
+	if got.PageInfo.HasNextPage != want.PageInfo.HasNextPage || got.PageInfo.HasPreviousPage != want.PageInfo.HasPreviousPage {
+		return fmt.Errorf(
+			"relay: %s answered back hasNextPage=%t and hasPreviousPage=%t, want %t and %t",
+			describe(args),
+			got.PageInfo.HasNextPage, got.PageInfo.HasPreviousPage,
+			want.PageInfo.HasNextPage, want.PageInfo.HasPreviousPage,
+		)
+	}
+	if cursor(got.PageInfo.StartCursor) != cursor(want.PageInfo.StartCursor) || cursor(got.PageInfo.EndCursor) != cursor(want.PageInfo.EndCursor) {
+		return fmt.Errorf("relay: %s answered back wrong start or end cursors", describe(args))
+	}
+	return nil
+}
+
+// edgeCursors function will answer back the cursors of the given edges
+func edgeCursors[T any](edges []Edge[T]) []string {
+	cursors := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		cursors = append(cursors, edge.Cursor)
+	}
+	return cursors
+}
+
+// cursor function will answer back the given cursor, empty when it is nil
+func cursor(c *string) string {
+	if c == nil {
+		return ""
+	}
+	return *c
+}
+
+// describe function will describe the given arguments for the failures
+func describe(args Args) string {
+	var s string
+	if args.First != nil {
+		s += fmt.Sprintf(" first=%d", *args.First)
+	}
+	if args.After != nil {
+		s += fmt.Sprintf(" after=%q", *args.After)
+	}
+	if args.Last != nil {
+		s += fmt.Sprintf(" last=%d", *args.Last)
+	}
+	if args.Before != nil {
+		s += fmt.Sprintf(" before=%q", *args.Before)
+	}
+	if s == "" {
+		return "no arguments"
+	}
+	return s[1:]
+}
@@ -0,0 +1,34 @@
+package relay_test
+
+import (
+	"testing"
+
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConformance(t *testing.T) {
+	err := relay.Conformance[string]{
+		Edges: letters,
+		Resolve: func(args relay.Args) (relay.Connection[string], error) {
+			return relay.Paginate(letters, args)
+		},
+	}.Check()
+	assert.NoError(t, err)
+}
+
+func TestConformanceFailures(t *testing.T) {
+	err := relay.Conformance[string]{
+		Edges: letters,
+		Resolve: func(args relay.Args) (relay.Connection[string], error) {
+			// ignores the backward arguments and never refuses them
+			args.Last, args.Before = nil, nil
+			if args.First != nil && *args.First < 0 {
+				args.First = nil
+			}
+			return relay.Paginate(letters, args)
+		},
+	}.Check()
+	assert.ErrorContains(t, err, "relay: last=1 answered back the edges [ca cb cc cd ce], want [ce]")
+	assert.ErrorContains(t, err, "relay: first=-1 must be refused")
+}
@@ -0,0 +1,94 @@
+// Package relay builds the Relay connections of the GraphQL APIs on top of the
+// pagination params, the cursors of the edges are the synthetic offset
+// cursors of the pagination package, see pagination.OffsetCursor
+package relay
+
+import (
+	"errors"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+var (
+	// ErrNegativeFirst is the error returned for a negative first argument
+	ErrNegativeFirst = errors.New("pagination: first can't be negative")
+	// ErrNegativeLast is the error returned for a negative last argument
+	ErrNegativeLast = errors.New("pagination: last can't be negative")
+)
+
+// Connection type encapsulates a page of a Relay connection, the TotalCount
+// is resolved apart as it is only counted when requested, see Totals
+type Connection[T any] struct {
+	Edges      []Edge[T]   `json:"edges"`
+	PageInfo   PageInfo    `json:"pageInfo"`
+	TotalCount *TotalCount `json:"-"`
+}
+
+// Edge type encapsulates an item of a Relay connection with its cursor
+type Edge[T any] struct {
+	Node   T      `json:"node"`
+	Cursor string `json:"cursor"`
+}
+
+// PageInfo type encapsulates the information about the page of a Relay
+// connection, the cursors are nil when the page is empty
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor"`
+	EndCursor       *string `json:"endCursor"`
+}
+
+// NewConnection will build the connection for the given items fetched with
+// the given params, that is the page plus the next item like Paginate
+// expects. The cursor of every edge carries its offset and is signed when a
+// codec is given
+func NewConnection[T any](items []T, params pagination.Params, tokens *pagination.TokenCodec) Connection[T] {
+	connection := Connection[T]{
+		Edges: make([]Edge[T], 0, min(uint(len(items)), params.Limit)),
+		PageInfo: PageInfo{
+			HasNextPage:     uint(len(items)) > params.Limit,
+			HasPreviousPage: params.Offset > 0,
+		},
+	}
+	for i, item := range items {
+		if uint(i) == params.Limit {
+			break
+		}
+		connection.Edges = append(connection.Edges, Edge[T]{
+			Node:   item,
+			Cursor: pagination.OffsetCursor(tokens, params.Offset+uint(i)),
+		})
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection
+}
+
+// Params will find the params of the forward pagination arguments of a
+// connection field, first and after, applying the given policy. The limit is
+// the DefaultLimit when first is nil, clamped to the MaxLimit, and the offset
+// follows the one of the after cursor, signed with the Tokens of the policy.
+// A *pagination.TokenError is returned for the cursors that can't be accepted
+func Params(first *int, after *string, policy pagination.Policy) (pagination.Params, error) {
+	params := pagination.Params{Limit: policy.DefaultLimit, Sort: policy.DefaultSort}
+	if first != nil {
+		if *first < 0 {
+			return params, ErrNegativeFirst
+		}
+		params.Limit = uint(*first)
+	}
+	if policy.MaxLimit > 0 && params.Limit > policy.MaxLimit {
+		params.Limit = policy.MaxLimit
+	}
+	if after != nil && *after != "" {
+		offset, err := pagination.CursorOffset(policy.Tokens, *after)
+		if err != nil {
+			return params, err
+		}
+		params.Offset = offset + 1
+	}
+	return params, nil
+}
@@ -0,0 +1,59 @@
+package relay_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConnection(t *testing.T) {
+	connection := relay.NewConnection([]string{"c", "d", "e"}, pagination.Params{Limit: 2, Offset: 2}, nil)
+	assert.Equal(t, []relay.Edge[string]{
+		{Node: "c", Cursor: pagination.OffsetCursor(nil, 2)},
+		{Node: "d", Cursor: pagination.OffsetCursor(nil, 3)},
+	}, connection.Edges)
+	assert.True(t, connection.PageInfo.HasNextPage)
+	assert.True(t, connection.PageInfo.HasPreviousPage)
+	assert.Equal(t, pagination.OffsetCursor(nil, 2), *connection.PageInfo.StartCursor)
+	assert.Equal(t, pagination.OffsetCursor(nil, 3), *connection.PageInfo.EndCursor)
+}
+
+func TestNewConnectionEmpty(t *testing.T) {
+	connection := relay.NewConnection[string](nil, pagination.Params{Limit: 2}, nil)
+	b, err := json.Marshal(connection)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"edges":[],"pageInfo":{"hasNextPage":false,"hasPreviousPage":false,"startCursor":null,"endCursor":null}}`, string(b))
+}
+
+func TestParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, MaxLimit: 50}
+	first, after := 20, pagination.OffsetCursor(nil, 19)
+	tests := []struct {
+		name    string
+		first   *int
+		after   *string
+		want    pagination.Params
+		wantErr error
+	}{
+		{name: "Defaults", want: pagination.Params{Limit: 10}},
+		{name: "After a cursor", first: &first, after: &after, want: pagination.Params{Limit: 20, Offset: 20}},
+		{name: "First beyond the max", first: ptr(500), want: pagination.Params{Limit: 50}},
+		{name: "Negative first", first: ptr(-1), want: pagination.Params{Limit: 10}, wantErr: relay.ErrNegativeFirst},
+		{name: "Malformed cursor", after: ptr("not a cursor!"), want: pagination.Params{Limit: 10}, wantErr: pagination.ErrTokenMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := relay.Params(tt.first, tt.after, policy)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, params)
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
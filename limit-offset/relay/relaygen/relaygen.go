@@ -0,0 +1,205 @@
+// Package relaygen generates the Relay connection models, the GraphQL schema
+// and the resolver scaffolding of the given object types for gqlgen, wired to
+// the relay package, instead of writing them by hand for every type. The
+// relaygen command runs it, usually from a go:generate directive
+//
+//	//go:generate go run github.com/ramonmacias/go-pagination/limit-offset/relay/cmd/relaygen -package graph -types User,Post
+package relaygen
+
+import (
+	"bytes"
+	"go/format"
+	"io"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Config type encapsulates what is generated
+type Config struct {
+	// Package is the Go package of the generated files
+	Package string
+	// Types are the GraphQL object types with a connection, their Go models
+	// must have the same name on the package
+	Types []string
+	// Resolver is the type implementing the query resolvers, the
+	// queryResolver of gqlgen when it is empty
+	Resolver string
+}
+
+// Models will generate the Go models of the connections and edges of the
+// types, with the constructor building them from the fetched items. The
+// totalCount of the connections is resolved by a method, so gqlgen only
+// counts when the field is requested
+func Models(w io.Writer, config Config) error {
+	return generateGo(w, modelsTemplate, config)
+}
+
+// Schema will generate the GraphQL schema of the connections and edges of the
+// types, with the PageInfo type and the connection fields of the Query. The
+// PageInfo must be mapped into the relay.PageInfo on the models of gqlgen.yml
+func Schema(w io.Writer, config Config) error {
+	return templates.ExecuteTemplate(w, schemaTemplate, config)
+}
+
+// Resolvers will generate the scaffolding of the query resolvers of the
+// connections, finding the params of the first and after arguments, meant for
+// being edited afterwards
+func Resolvers(w io.Writer, config Config) error {
+	if config.Resolver == "" {
+		config.Resolver = "queryResolver"
+	}
+	return generateGo(w, resolversTemplate, config)
+}
+
+// generateGo function will execute the given template formatting the result
+// as Go source
+func generateGo(w io.Writer, name string, config Config) error {
+	var b bytes.Buffer
+	if err := templates.ExecuteTemplate(&b, name, config); err != nil {
+		return err
+	}
+	source, err := format.Source(b.Bytes())
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(source)
+	return err
+}
+
+// plural function will answer back the plural of the given type name, like
+// Users or Categories
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	}
+	return name + "s"
+}
+
+// lower function will lower the first letter of the given name
+func lower(name string) string {
+	if name == "" {
+		return name
+	}
+	r := []rune(name)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+const (
+	modelsTemplate    = "models"
+	schemaTemplate    = "schema"
+	resolversTemplate = "resolvers"
+)
+
+// templates are the templates of the generated files
+var templates = template.Must(template.New("").Funcs(template.FuncMap{
+	"plural": plural,
+	"lower":  lower,
+}).Parse(`
+{{define "models"}}// Code generated by relaygen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	"context"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+)
+{{range .Types}}
+// {{.}}Connection is the Relay connection of the {{.}} type
+type {{.}}Connection struct {
+	Edges    []*{{.}}Edge ` + "`json:\"edges\"`" + `
+	PageInfo *relay.PageInfo ` + "`json:\"pageInfo\"`" + `
+	totalCount *relay.TotalCount
+}
+
+// TotalCount resolves the totalCount, only counted when it is requested
+func (c *{{.}}Connection) TotalCount(ctx context.Context) (*int, error) {
+	return c.totalCount.Resolve(ctx)
+}
+
+// WithTotalCount sets the lazy totalCount of the connection, see relay.Totals
+func (c *{{.}}Connection) WithTotalCount(totalCount *relay.TotalCount) *{{.}}Connection {
+	c.totalCount = totalCount
+	return c
+}
+
+// {{.}}Edge is an edge of the {{.}}Connection
+type {{.}}Edge struct {
+	Node   *{{.}} ` + "`json:\"node\"`" + `
+	Cursor string ` + "`json:\"cursor\"`" + `
+}
+
+// New{{.}}Connection builds the connection for the items fetched with the
+// params, the page plus the next item
+func New{{.}}Connection(items []*{{.}}, params pagination.Params, tokens *pagination.TokenCodec) *{{.}}Connection {
+	connection := relay.NewConnection(items, params, tokens)
+	edges := make([]*{{.}}Edge, 0, len(connection.Edges))
+	for _, edge := range connection.Edges {
+		edges = append(edges, &{{.}}Edge{Node: edge.Node, Cursor: edge.Cursor})
+	}
+	return &{{.}}Connection{Edges: edges, PageInfo: &connection.PageInfo}
+}
+{{end}}{{end}}
+
+{{define "schema"}}# Code generated by relaygen. DO NOT EDIT.
+
+type PageInfo {
+  hasNextPage: Boolean!
+  hasPreviousPage: Boolean!
+  startCursor: String
+  endCursor: String
+}
+{{range .Types}}
+type {{.}}Connection {
+  edges: [{{.}}Edge!]!
+  pageInfo: PageInfo!
+  totalCount: Int
+}
+
+type {{.}}Edge {
+  node: {{.}}!
+  cursor: String!
+}
+{{end}}
+extend type Query {
+{{- range .Types}}
+  {{lower (plural .)}}(first: Int, after: String): {{.}}Connection!
+{{- end}}
+}
+{{end}}
+
+{{define "resolvers"}}package {{.Package}}
+
+import (
+	"context"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+)
+
+// totals are the strategies of the totalCount of the connections
+var totals = relay.Totals{Default: relay.UnavailableCount{}}
+{{range .Types}}
+// {{lower .}}Policy is the policy of the {{lower (plural .)}} connection
+var {{lower .}}Policy = pagination.Policy{DefaultLimit: 10, MaxLimit: 100}
+
+// {{plural .}} is the resolver of the {{lower (plural .)}} connection
+func (r *{{$.Resolver}}) {{plural .}}(ctx context.Context, first *int, after *string) (*{{.}}Connection, error) {
+	params, err := relay.Params(first, after, {{lower .}}Policy)
+	if err != nil {
+		return nil, err
+	}
+	// fetch the page plus the next item, like the query of params.Query() does
+	var items []*{{.}}
+	// count the items with the query without the pagination part
+	count := relay.Count{}
+	return New{{.}}Connection(items, params, {{lower .}}Policy.Tokens).WithTotalCount(totals.For("{{.}}", count)), nil
+}
+{{end}}{{end}}
+`))
@@ -0,0 +1,40 @@
+package relaygen_test
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/ramonmacias/go-pagination/limit-offset/relay/relaygen"
+	"github.com/stretchr/testify/assert"
+)
+
+var config = relaygen.Config{Package: "graph", Types: []string{"User", "Category"}}
+
+func TestModels(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, relaygen.Models(&b, config))
+	_, err := parser.ParseFile(token.NewFileSet(), "models.go", b.Bytes(), 0)
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), "type UserConnection struct {")
+	assert.Contains(t, b.String(), "func (c *UserConnection) TotalCount(ctx context.Context) (*int, error) {")
+	assert.Contains(t, b.String(), "func NewCategoryConnection(items []*Category, params pagination.Params, tokens *pagination.TokenCodec) *CategoryConnection {")
+}
+
+func TestSchema(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, relaygen.Schema(&b, config))
+	assert.Contains(t, b.String(), "type UserConnection {\n  edges: [UserEdge!]!\n  pageInfo: PageInfo!\n  totalCount: Int\n}")
+	assert.Contains(t, b.String(), "type UserEdge {\n  node: User!\n  cursor: String!\n}")
+	assert.Contains(t, b.String(), "extend type Query {\n  users(first: Int, after: String): UserConnection!\n  categories(first: Int, after: String): CategoryConnection!\n}")
+}
+
+func TestResolvers(t *testing.T) {
+	var b bytes.Buffer
+	assert.NoError(t, relaygen.Resolvers(&b, config))
+	_, err := parser.ParseFile(token.NewFileSet(), "resolvers.go", b.Bytes(), 0)
+	assert.NoError(t, err)
+	assert.Contains(t, b.String(), `WithTotalCount(totals.For("Category", count))`)
+	assert.Contains(t, b.String(), "func (r *queryResolver) Categories(ctx context.Context, first *int, after *string) (*CategoryConnection, error) {")
+}
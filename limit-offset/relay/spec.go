@@ -0,0 +1,70 @@
+package relay
+
+import "slices"
+
+// Args type encapsulates the pagination arguments of a connection field, the
+// nil ones weren't given
+type Args struct {
+	First  *int
+	After  *string
+	Last   *int
+	Before *string
+}
+
+// Paginate will apply the given arguments to all the edges of a connection
+// following the algorithm of the Relay cursor connections specification:
+// the edges up to the after cursor and from the before cursor are removed,
+// then the first ones are kept and then the last ones. As all the edges are
+// known the page has a previous page when the last argument removed edges or
+// the after cursor was found, and a next page when the first argument removed
+// edges or the before cursor was found. The cursors that aren't found are
+// ignored, like the specification says
+func Paginate[T any](edges []Edge[T], args Args) (Connection[T], error) {
+	if args.First != nil && *args.First < 0 {
+		return Connection[T]{}, ErrNegativeFirst
+	}
+	if args.Last != nil && *args.Last < 0 {
+		return Connection[T]{}, ErrNegativeLast
+	}
+	page, afterFound, beforeFound := applyCursors(edges, args.After, args.Before)
+	var info PageInfo
+	if args.First != nil && len(page) > *args.First {
+		page = page[:*args.First]
+		info.HasNextPage = true
+	}
+	if args.Last != nil && len(page) > *args.Last {
+		page = page[len(page)-*args.Last:]
+		info.HasPreviousPage = true
+	}
+	info.HasPreviousPage = info.HasPreviousPage || afterFound
+	info.HasNextPage = info.HasNextPage || beforeFound
+
+	connection := Connection[T]{Edges: slices.Clone(page), PageInfo: info}
+	if connection.Edges == nil {
+		connection.Edges = []Edge[T]{}
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection, nil
+}
+
+// applyCursors function will remove the edges up to the after cursor,
+// included, and from the before cursor, answering back if they were found
+func applyCursors[T any](edges []Edge[T], after, before *string) (page []Edge[T], afterFound, beforeFound bool) {
+	page = edges
+	if after != nil {
+		if i := slices.IndexFunc(page, func(e Edge[T]) bool { return e.Cursor == *after }); i >= 0 {
+			page = page[i+1:]
+			afterFound = true
+		}
+	}
+	if before != nil {
+		if i := slices.IndexFunc(page, func(e Edge[T]) bool { return e.Cursor == *before }); i >= 0 {
+			page = page[:i]
+			beforeFound = true
+		}
+	}
+	return page, afterFound, beforeFound
+}
@@ -0,0 +1,102 @@
+package relay_test
+
+import (
+	"testing"
+
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+	"github.com/stretchr/testify/assert"
+)
+
+var letters = []relay.Edge[string]{
+	{Node: "a", Cursor: "ca"},
+	{Node: "b", Cursor: "cb"},
+	{Node: "c", Cursor: "cc"},
+	{Node: "d", Cursor: "cd"},
+	{Node: "e", Cursor: "ce"},
+}
+
+func TestPaginate(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             relay.Args
+		wantNodes        []string
+		wantNextPage     bool
+		wantPreviousPage bool
+	}{
+		{
+			name:      "Without arguments",
+			wantNodes: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:         "First",
+			args:         relay.Args{First: ptr(2)},
+			wantNodes:    []string{"a", "b"},
+			wantNextPage: true,
+		},
+		{
+			name:             "First after",
+			args:             relay.Args{First: ptr(2), After: ptr("cb")},
+			wantNodes:        []string{"c", "d"},
+			wantNextPage:     true,
+			wantPreviousPage: true,
+		},
+		{
+			name:             "First after up to the end",
+			args:             relay.Args{First: ptr(10), After: ptr("cc")},
+			wantNodes:        []string{"d", "e"},
+			wantPreviousPage: true,
+		},
+		{
+			name:             "Last",
+			args:             relay.Args{Last: ptr(2)},
+			wantNodes:        []string{"d", "e"},
+			wantPreviousPage: true,
+		},
+		{
+			name:             "Last before",
+			args:             relay.Args{Last: ptr(2), Before: ptr("cd")},
+			wantNodes:        []string{"b", "c"},
+			wantNextPage:     true,
+			wantPreviousPage: true,
+		},
+		{
+			name:             "After and before",
+			args:             relay.Args{After: ptr("ca"), Before: ptr("ce")},
+			wantNodes:        []string{"b", "c", "d"},
+			wantNextPage:     true,
+			wantPreviousPage: true,
+		},
+		{
+			name:      "Unknown cursors are ignored",
+			args:      relay.Args{After: ptr("unknown")},
+			wantNodes: []string{"a", "b", "c", "d", "e"},
+		},
+		{
+			name:         "Zero first",
+			args:         relay.Args{First: ptr(0)},
+			wantNodes:    []string{},
+			wantNextPage: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connection, err := relay.Paginate(letters, tt.args)
+			assert.NoError(t, err)
+			nodes := []string{}
+			for _, edge := range connection.Edges {
+				nodes = append(nodes, edge.Node)
+			}
+			assert.Equal(t, tt.wantNodes, nodes)
+			assert.Equal(t, tt.wantNextPage, connection.PageInfo.HasNextPage)
+			assert.Equal(t, tt.wantPreviousPage, connection.PageInfo.HasPreviousPage)
+		})
+	}
+}
+
+func TestPaginateNegativeArguments(t *testing.T) {
+	_, err := relay.Paginate(letters, relay.Args{First: ptr(-1)})
+	assert.ErrorIs(t, err, relay.ErrNegativeFirst)
+	_, err = relay.Paginate(letters, relay.Args{Last: ptr(-1)})
+	assert.ErrorIs(t, err, relay.ErrNegativeLast)
+}
@@ -0,0 +1,132 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+)
+
+// Count type encapsulates the ways of counting all the items of a connection,
+// the strategies pick the one they need
+type Count struct {
+	// Key is the key of the cached totals, see pagination.QueryFingerprint
+	Key string
+	// Exact counts the items with the count query
+	Exact func(ctx context.Context) (uint, error)
+	// Estimate estimates the number of items, like the reltuples of the
+	// pg_class of PostgreSQL, it is optional
+	Estimate func(ctx context.Context) (uint, error)
+}
+
+// CountStrategy interface defines how the totalCount of a connection is
+// resolved, a nil total means the total is unavailable and the field is null
+type CountStrategy interface {
+	Total(ctx context.Context, count Count) (*int, error)
+}
+
+// ExactCount type is the strategy running the exact count query every time
+type ExactCount struct{}
+
+// Total method will run the exact count
+func (ExactCount) Total(ctx context.Context, count Count) (*int, error) {
+	if count.Exact == nil {
+		return nil, nil
+	}
+	return total(count.Exact(ctx))
+}
+
+// CachedCount type is the strategy caching the exact count during the TTL,
+// see pagination.CachedCount
+type CachedCount struct {
+	Cache pagination.CountCache
+	TTL   time.Duration
+}
+
+// Total method will answer back the cached total, running the exact count
+// when it isn't cached
+func (s CachedCount) Total(ctx context.Context, count Count) (*int, error) {
+	if count.Exact == nil {
+		return nil, nil
+	}
+	return total(pagination.CachedCount(ctx, s.Cache, count.Key, s.TTL, count.Exact))
+}
+
+// EstimatedCount type is the strategy answering back the estimated total,
+// the total is unavailable when the count can't be estimated
+type EstimatedCount struct{}
+
+// Total method will run the estimate
+func (EstimatedCount) Total(ctx context.Context, count Count) (*int, error) {
+	if count.Estimate == nil {
+		return nil, nil
+	}
+	return total(count.Estimate(ctx))
+}
+
+// UnavailableCount type is the strategy of the connections without a total,
+// the totalCount is always null
+type UnavailableCount struct{}
+
+// Total method will answer back the unavailable total
+func (UnavailableCount) Total(ctx context.Context, count Count) (*int, error) {
+	return nil, nil
+}
+
+// Totals type encapsulates the strategy of the totalCount of every type of
+// connection, as the exact counts are too expensive for being run on every
+// connection
+//
+//	totals := relay.Totals{
+//	  Default: relay.UnavailableCount{},
+//	  Types: map[string]relay.CountStrategy{
+//	    "User": relay.CachedCount{Cache: cache, TTL: time.Minute},
+//	    "Post": relay.EstimatedCount{},
+//	  },
+//	}
+type Totals struct {
+	// Default is the strategy of the types without one, UnavailableCount when
+	// it is nil
+	Default CountStrategy
+	// Types are the strategies by the name of the type of the nodes
+	Types map[string]CountStrategy
+}
+
+// For method will build the lazy total count of a connection of the given
+// type, nothing is counted until it is resolved
+func (t Totals) For(typeName string, count Count) *TotalCount {
+	strategy, ok := t.Types[typeName]
+	if !ok {
+		strategy = t.Default
+	}
+	if strategy == nil {
+		strategy = UnavailableCount{}
+	}
+	return &TotalCount{strategy: strategy, count: count}
+}
+
+// TotalCount type encapsulates the lazy totalCount of a connection, the
+// resolvers of the field call Resolve so the count only runs when the field
+// is requested. The nil ones are unavailable
+type TotalCount struct {
+	strategy CountStrategy
+	count    Count
+}
+
+// Resolve method will resolve the total with the strategy of the type of the
+// connection
+func (t *TotalCount) Resolve(ctx context.Context) (*int, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return t.strategy.Total(ctx, t.count)
+}
+
+// total function will convert a count into the total of the field
+func total(count uint, err error) (*int, error) {
+	if err != nil {
+		return nil, err
+	}
+	n := int(count)
+	return &n, nil
+}
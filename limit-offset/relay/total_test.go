@@ -0,0 +1,78 @@
+package relay_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/ramonmacias/go-pagination/limit-offset/relay"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTotals(t *testing.T) {
+	var exactCalls int
+	count := relay.Count{
+		Key: "users",
+		Exact: func(ctx context.Context) (uint, error) {
+			exactCalls++
+			return 213, nil
+		},
+		Estimate: func(ctx context.Context) (uint, error) {
+			return 200, nil
+		},
+	}
+	totals := relay.Totals{
+		Types: map[string]relay.CountStrategy{
+			"User":     relay.ExactCount{},
+			"Cached":   relay.CachedCount{Cache: pagination.NewMemoryCountCache(), TTL: time.Minute},
+			"Estimate": relay.EstimatedCount{},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		typeName  string
+		want      *int
+		wantCalls int
+	}{
+		{name: "Exact", typeName: "User", want: ptr(213), wantCalls: 2},
+		{name: "Cached", typeName: "Cached", want: ptr(213), wantCalls: 1},
+		{name: "Estimate", typeName: "Estimate", want: ptr(200)},
+		{name: "Unavailable by default", typeName: "Post"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exactCalls = 0
+			totalCount := totals.For(tt.typeName, count)
+			assert.Equal(t, 0, exactCalls)
+			for range 2 {
+				total, err := totalCount.Resolve(context.Background())
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, total)
+			}
+			assert.Equal(t, tt.wantCalls, exactCalls)
+		})
+	}
+}
+
+func TestTotalCountResolve(t *testing.T) {
+	var totalCount *relay.TotalCount
+	total, err := totalCount.Resolve(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, total)
+
+	failure := errors.New("count failed")
+	totalCount = relay.Totals{Default: relay.ExactCount{}}.For("User", relay.Count{
+		Exact: func(ctx context.Context) (uint, error) { return 0, failure },
+	})
+	_, err = totalCount.Resolve(context.Background())
+	assert.ErrorIs(t, err, failure)
+
+	totalCount = relay.Totals{Default: relay.EstimatedCount{}}.For("User", relay.Count{})
+	total, err = totalCount.Resolve(context.Background())
+	assert.NoError(t, err)
+	assert.Nil(t, total)
+}
@@ -0,0 +1,251 @@
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// FilterFormat type defines how the filters are written on the http request
+type FilterFormat int
+
+const (
+	// FilterFormatBrackets is the default format, each filter uses its own
+	// param, for example filter[status]=active&filter[age][gt]=30
+	FilterFormatBrackets FilterFormat = iota
+	// FilterFormatRSQL uses a single filter param with a RSQL expression, for
+	// example filter=status==active;age=gt=30
+	FilterFormatRSQL
+)
+
+// rsqlOperators maps the RSQL comparison operators, including the FIQL short
+// ones, into the filter operators
+var rsqlOperators = map[string]Operator{
+	"==":     OpEq,
+	"!=":     OpNe,
+	"=gt=":   OpGt,
+	">":      OpGt,
+	"=ge=":   OpGte,
+	">=":     OpGte,
+	"=lt=":   OpLt,
+	"<":      OpLt,
+	"=le=":   OpLte,
+	"<=":     OpLte,
+	"=in=":   OpIn,
+	"=like=": OpLike,
+}
+
+// rsqlReserved are the characters that can't be used on an unquoted RSQL value
+const rsqlReserved = `"'();,=!~<> `
+
+// FindRSQLFilters will find the filters on the RSQL expression of the filter
+// param of the request, for example filter=status==active;age=gt=30
+func FindRSQLFilters(req *http.Request) (Filters, error) {
+	return ParseRSQL(lookupRSQL(req.URL.RawQuery))
+}
+
+// ParseRSQL will parse the given RSQL expression into filters, the comparisons
+// are joined with ; as the filters are always combined with AND, the OR
+// operator and the groups aren't supported. The == comparisons with a *
+// wildcard on the value are converted into the like operator. A *FilterError
+// is returned for the malformed expressions
+func ParseRSQL(expr string) (Filters, error) {
+	p := rsqlParser{expr: expr}
+	var filters Filters
+	for p.pos < len(p.expr) {
+		filter, err := p.comparison()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+		if p.pos < len(p.expr) {
+			if p.expr[p.pos] != ';' {
+				return nil, p.errorf("only the ; operator is supported for joining comparisons")
+			}
+			p.pos++
+		}
+	}
+	return filters, nil
+}
+
+// rsqlParser type keeps the state while parsing a RSQL expression
+type rsqlParser struct {
+	expr string
+	pos  int
+}
+
+// comparison method will parse a single selector=operator=arguments comparison
+func (p *rsqlParser) comparison() (Filter, error) {
+	start := p.pos
+	for p.pos < len(p.expr) && !strings.ContainsRune(rsqlReserved, rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	field := p.expr[start:p.pos]
+	if field == "" {
+		return Filter{}, p.errorf("a selector was expected")
+	}
+	op, err := p.operator()
+	if err != nil {
+		return Filter{}, err
+	}
+	if p.pos < len(p.expr) && p.expr[p.pos] == '(' {
+		if op != OpIn {
+			return Filter{}, p.errorf("groups of values are only supported by =in=")
+		}
+		p.pos++
+		var values []string
+		for {
+			value, _, err := p.value()
+			if err != nil {
+				return Filter{}, err
+			}
+			values = append(values, value)
+			if p.pos >= len(p.expr) {
+				return Filter{}, p.errorf("the group of values is not closed")
+			}
+			if p.expr[p.pos] == ')' {
+				p.pos++
+				break
+			}
+			if p.expr[p.pos] != ',' {
+				return Filter{}, p.errorf("a , or ) was expected")
+			}
+			p.pos++
+		}
+		return Filter{Field: field, Operator: OpIn, Value: strings.Join(values, ","), Values: values}, nil
+	}
+	value, quoted, err := p.value()
+	if err != nil {
+		return Filter{}, err
+	}
+	if op == OpIn {
+		return Filter{Field: field, Operator: OpIn, Value: value, Values: []string{value}}, nil
+	}
+	if op == OpEq && !quoted && strings.Contains(value, "*") {
+		op, value = OpLike, strings.ReplaceAll(value, "*", "%")
+	}
+	return Filter{Field: field, Operator: op, Value: value}, nil
+}
+
+// operator method will parse the comparison operator
+func (p *rsqlParser) operator() (Operator, error) {
+	rest := p.expr[p.pos:]
+	candidates := []string{"==", "!=", ">=", "<=", ">", "<"}
+	if strings.HasPrefix(rest, "=") && !strings.HasPrefix(rest, "==") {
+		if end := strings.IndexByte(rest[1:], '='); end >= 0 {
+			candidates = []string{rest[:end+2]}
+		}
+	}
+	for _, candidate := range candidates {
+		if strings.HasPrefix(rest, candidate) {
+			op, ok := rsqlOperators[candidate]
+			if !ok {
+				return "", p.errorf("the operator " + candidate + " is not supported")
+			}
+			p.pos += len(candidate)
+			return op, nil
+		}
+	}
+	return "", p.errorf("an operator was expected")
+}
+
+// value method will parse a quoted or unquoted argument
+func (p *rsqlParser) value() (value string, quoted bool, err error) {
+	if p.pos < len(p.expr) && (p.expr[p.pos] == '"' || p.expr[p.pos] == '\'') {
+		quote := p.expr[p.pos]
+		var b strings.Builder
+		for p.pos++; p.pos < len(p.expr); p.pos++ {
+			c := p.expr[p.pos]
+			switch {
+			case c == '\\' && p.pos+1 < len(p.expr):
+				p.pos++
+				b.WriteByte(p.expr[p.pos])
+			case c == quote:
+				p.pos++
+				return b.String(), true, nil
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", true, p.errorf("the quoted value is not closed")
+	}
+	start := p.pos
+	for p.pos < len(p.expr) && !strings.ContainsRune(rsqlReserved, rune(p.expr[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return "", false, p.errorf("a value was expected")
+	}
+	return p.expr[start:p.pos], false, nil
+}
+
+// errorf method will build the error for the current position
+func (p *rsqlParser) errorf(reason string) error {
+	return &FilterError{Field: ParamFilter, Value: p.expr, Reason: reason + " at position " + strconv.Itoa(p.pos)}
+}
+
+// EncodeRSQL will serialize the filters as the RSQL expression of the filter
+// param of the links, the comparisons are sorted like Encode does
+func (f Filters) EncodeRSQL() string {
+	if len(f) == 0 {
+		return ""
+	}
+	sorted := f.sorted()
+	comparisons := make([]string, 0, len(sorted))
+	for _, filter := range sorted {
+		switch filter.Operator {
+		case OpIn:
+			values := make([]string, 0, len(filter.Values))
+			for _, value := range filter.Values {
+				values = append(values, rsqlValue(value))
+			}
+			comparisons = append(comparisons, filter.Field+"=in=("+strings.Join(values, ",")+")")
+		case OpLike:
+			comparisons = append(comparisons, filter.Field+"=like="+rsqlValue(filter.Value))
+		default:
+			comparisons = append(comparisons, filter.Field+rsqlOperator(filter.Operator)+rsqlValue(filter.Value))
+		}
+	}
+	return ParamFilter + "=" + rsqlEscaper.Replace(url.QueryEscape(strings.Join(comparisons, ";")))
+}
+
+// rsqlEscaper keeps readable the characters of the RSQL syntax that are safe on
+// a query value, the ; is kept escaped as url.ParseQuery refuses it
+var rsqlEscaper = strings.NewReplacer("%3D", "=", "%2C", ",", "%28", "(", "%29", ")", "%21", "!", "%2A", "*")
+
+// rsqlOperator function will return the RSQL operator for the given one
+func rsqlOperator(op Operator) string {
+	for _, candidate := range []string{"==", "!=", "=gt=", "=ge=", "=lt=", "=le="} {
+		if rsqlOperators[candidate] == op {
+			return candidate
+		}
+	}
+	return "=="
+}
+
+// rsqlValue function will quote the given value when it has reserved chars or
+// when it could be read as a like pattern
+func rsqlValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, rsqlReserved+`*\`) {
+		return value
+	}
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// lookupRSQL function will look for the filter param on the raw query, unlike
+// lookupParams it accepts the ; on the value as it is the AND of RSQL
+func lookupRSQL(rawQuery string) string {
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		key, value, _ := strings.Cut(pair, "=")
+		if key != ParamFilter {
+			continue
+		}
+		if value, ok := unescapeQuery(value); ok {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,134 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRSQL(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		want    pagination.Filters
+		wantErr bool
+	}{
+		{
+			name: "Empty expression",
+			expr: "",
+		},
+		{
+			name: "RSQL operators",
+			expr: "status==active;age=gt=30;age=le=65;role!=admin;team=in=(a,b);name=like=jo%",
+			want: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+				{Field: "age", Operator: pagination.OpGt, Value: "30"},
+				{Field: "age", Operator: pagination.OpLte, Value: "65"},
+				{Field: "role", Operator: pagination.OpNe, Value: "admin"},
+				{Field: "team", Operator: pagination.OpIn, Value: "a,b", Values: []string{"a", "b"}},
+				{Field: "name", Operator: pagination.OpLike, Value: "jo%"},
+			},
+		},
+		{
+			name: "FIQL short operators",
+			expr: "age>=18;age<65",
+			want: pagination.Filters{
+				{Field: "age", Operator: pagination.OpGte, Value: "18"},
+				{Field: "age", Operator: pagination.OpLt, Value: "65"},
+			},
+		},
+		{
+			name: "Wildcards and quoted values",
+			expr: `name==jo*;title=="hello; \"world\"";code=='a*b'`,
+			want: pagination.Filters{
+				{Field: "name", Operator: pagination.OpLike, Value: "jo%"},
+				{Field: "title", Operator: pagination.OpEq, Value: `hello; "world"`},
+				{Field: "code", Operator: pagination.OpEq, Value: "a*b"},
+			},
+		},
+		{
+			name:    "OR is not supported",
+			expr:    "status==active,status==pending",
+			wantErr: true,
+		},
+		{
+			name:    "Groups are not supported",
+			expr:    "(status==active)",
+			wantErr: true,
+		},
+		{
+			name:    "Unknown operator",
+			expr:    "status=out=(a,b)",
+			wantErr: true,
+		},
+		{
+			name:    "Missing value",
+			expr:    "status==",
+			wantErr: true,
+		},
+		{
+			name:    "Unclosed quote",
+			expr:    `status=="active`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filters, err := pagination.ParseRSQL(tt.expr)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, pagination.ErrInvalidFilter))
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, filters)
+		})
+	}
+}
+
+func TestFiltersEncodeRSQL(t *testing.T) {
+	filters := pagination.Filters{
+		{Field: "status", Operator: pagination.OpEq, Value: "active"},
+		{Field: "age", Operator: pagination.OpGt, Value: "30"},
+		{Field: "team", Operator: pagination.OpIn, Value: "a,b", Values: []string{"a", "b"}},
+		{Field: "title", Operator: pagination.OpEq, Value: "a*b c"},
+	}
+
+	encoded := filters.EncodeRSQL()
+	assert.Equal(t, `filter=age=gt=30%3Bstatus==active%3Bteam=in=(a,b)%3Btitle==%22a*b+c%22`, encoded)
+	assert.Equal(t, "", pagination.Filters{}.EncodeRSQL())
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?"+encoded, nil)
+	assert.Nil(t, err)
+	parsed, err := pagination.FindRSQLFilters(req)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, filters, parsed)
+}
+
+func TestPolicyRSQLFilters(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 2,
+		FilterFormat: pagination.FilterFormatRSQL,
+		FilterSchema: pagination.FilterSchema{
+			"status": {Type: pagination.FilterString},
+			"age":    {Type: pagination.FilterInt},
+		},
+	}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?filter=status==active;age=gt=30", nil)
+	assert.Nil(t, err)
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{int64(30)}, params.Filters[1].Parsed)
+
+	links := pagination.Paginate(make([]interface{}, 3), "/sample", params).Links
+	assert.Equal(t, "/sample?page[limit]=2&page[offset]=2&filter=age=gt=30%3Bstatus==active", links.Next)
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?filter=age=gt=old", nil)
+	assert.Nil(t, err)
+	_, err = policy.FindParams(req)
+	assert.True(t, errors.Is(err, pagination.ErrInvalidFilter))
+}
@@ -0,0 +1,187 @@
+package pagination
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FilterType type defines the type of the values of a filterable field
+type FilterType int
+
+const (
+	// FilterString accepts any value, it is the default type
+	FilterString FilterType = iota
+	// FilterInt accepts integer values, coerced into int64
+	FilterInt
+	// FilterBool accepts the values accepted by strconv.ParseBool, coerced
+	// into bool
+	FilterBool
+	// FilterTime accepts RFC 3339 timestamps and 2006-01-02 dates, coerced
+	// into time.Time
+	FilterTime
+	// FilterUUID accepts UUIDs, coerced into their lowercase string
+	FilterUUID
+	// FilterEnum accepts only the values of the Enum of the field
+	FilterEnum
+)
+
+// FilterField type encapsulates how a filterable field is validated and which
+// column is used for it on the query
+type FilterField struct {
+	Type FilterType
+	// Column is the SQL column of the field, when empty the field name is used
+	Column string
+	// Enum are the values accepted by the FilterEnum type
+	Enum []string
+	// Operators are the operators accepted for the field, when empty all the
+	// operators that make sense for the type are accepted
+	Operators []Operator
+}
+
+// FilterSchema type declares the filterable fields of a resource, the filters
+// of the request are validated against it and their values coerced into the
+// type of the field
+type FilterSchema map[string]FilterField
+
+// FindFilters will find the filters on the request validating them against the
+// schema, a *FilterError is returned for the first filter that isn't valid so
+// it can be answered back as a 400
+func (s FilterSchema) FindFilters(req *http.Request) (Filters, error) {
+	filters := FindFilters(req)
+	for i := range filters {
+		if err := s.coerce(&filters[i]); err != nil {
+			return nil, err
+		}
+	}
+	return filters, nil
+}
+
+// Columns will answer back the columns of the fields of the schema, ready for
+// being used on Filters.Where
+func (s FilterSchema) Columns() map[string]string {
+	columns := make(map[string]string, len(s))
+	for name, field := range s {
+		columns[name] = field.Column
+		if field.Column == "" {
+			columns[name] = name
+		}
+	}
+	return columns
+}
+
+// coerce method will validate the given filter and set its parsed values
+func (s FilterSchema) coerce(filter *Filter) error {
+	field, ok := s[filter.Field]
+	if !ok {
+		return &FilterError{Field: filter.Field, Value: filter.Value, Reason: "the field is not filterable"}
+	}
+	if !field.accepts(filter.Operator) {
+		return &FilterError{Field: filter.Field, Value: filter.Value, Reason: "the operator " + string(filter.Operator) + " is not allowed"}
+	}
+	values := []string{filter.Value}
+	if filter.Operator == OpIn {
+		values = filter.Values
+	}
+	filter.Parsed = make([]interface{}, 0, len(values))
+	for _, value := range values {
+		parsed, err := field.parse(value)
+		if err != nil {
+			return &FilterError{Field: filter.Field, Value: value, Reason: err.Error()}
+		}
+		filter.Parsed = append(filter.Parsed, parsed)
+	}
+	return nil
+}
+
+// accepts method will check if the given operator is accepted by the field
+func (f FilterField) accepts(op Operator) bool {
+	allowed := f.Operators
+	if len(allowed) == 0 {
+		switch f.Type {
+		case FilterString:
+			allowed = operators
+		case FilterInt, FilterTime:
+			allowed = []Operator{OpEq, OpNe, OpGt, OpGte, OpLt, OpLte, OpIn}
+		default:
+			allowed = []Operator{OpEq, OpNe, OpIn}
+		}
+	}
+	for _, a := range allowed {
+		if a == op {
+			return true
+		}
+	}
+	return false
+}
+
+// parse method will coerce the given value into the type of the field
+func (f FilterField) parse(value string) (interface{}, error) {
+	switch f.Type {
+	case FilterInt:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, errReason("the value is not an integer")
+		}
+		return n, nil
+	case FilterBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, errReason("the value is not a boolean")
+		}
+		return b, nil
+	case FilterTime:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		t, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, errReason("the value is not a RFC 3339 time or a date")
+		}
+		return t, nil
+	case FilterUUID:
+		if !isUUID(value) {
+			return nil, errReason("the value is not an UUID")
+		}
+		return strings.ToLower(value), nil
+	case FilterEnum:
+		for _, e := range f.Enum {
+			if e == value {
+				return value, nil
+			}
+		}
+		return nil, errReason("the value must be one of " + strings.Join(f.Enum, ", "))
+	}
+	return value, nil
+}
+
+// errReason type is used for describing why a value couldn't be coerced
+type errReason string
+
+// Error will answer back the reason
+func (e errReason) Error() string {
+	return string(e)
+}
+
+// isUUID function will check if the given value has the UUID format, for
+// example 123e4567-e89b-12d3-a456-426614174000
+func isUUID(value string) bool {
+	if len(value) != 36 {
+		return false
+	}
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
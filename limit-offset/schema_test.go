@@ -0,0 +1,123 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+var usersSchema = pagination.FilterSchema{
+	"name":       {Type: pagination.FilterString},
+	"age":        {Type: pagination.FilterInt},
+	"active":     {Type: pagination.FilterBool},
+	"created_at": {Type: pagination.FilterTime, Column: "users.created_at"},
+	"team_id":    {Type: pagination.FilterUUID},
+	"status":     {Type: pagination.FilterEnum, Enum: []string{"active", "pending"}},
+	"email":      {Type: pagination.FilterString, Operators: []pagination.Operator{pagination.OpEq}},
+}
+
+func TestFilterSchemaFindFilters(t *testing.T) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?filter[name][like]=jo%25&filter[age][gt]=30&filter[active]=true&filter[created_at][gte]=2024-01-01&filter[team_id]=123E4567-E89B-12D3-A456-426614174000&filter[status]=in:active,pending",
+		nil,
+	)
+	assert.Nil(t, err)
+
+	filters, err := usersSchema.FindFilters(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 6, len(filters))
+	assert.Equal(t, []interface{}{"jo%"}, filters[0].Parsed)
+	assert.Equal(t, []interface{}{int64(30)}, filters[1].Parsed)
+	assert.Equal(t, []interface{}{true}, filters[2].Parsed)
+	assert.Equal(t, []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, filters[3].Parsed)
+	assert.Equal(t, []interface{}{"123e4567-e89b-12d3-a456-426614174000"}, filters[4].Parsed)
+	assert.Equal(t, []interface{}{"active", "pending"}, filters[5].Parsed)
+
+	where, args := filters.Where(usersSchema.Columns(), pagination.DialectPostgres, 1)
+	assert.Equal(t, " WHERE name LIKE $1 AND age > $2 AND active = $3 AND users.created_at >= $4 AND team_id = $5 AND status IN ($6, $7)", where)
+	assert.Equal(t, []interface{}{
+		"jo%",
+		int64(30),
+		true,
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		"123e4567-e89b-12d3-a456-426614174000",
+		"active",
+		"pending",
+	}, args)
+}
+
+func TestFilterSchemaValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		url       string
+		wantField string
+		wantValue string
+	}{
+		{
+			name:      "Not filterable field",
+			url:       "app.quicka.co/api/sample?filter[password]=secret",
+			wantField: "password",
+			wantValue: "secret",
+		},
+		{
+			name:      "Wrong integer",
+			url:       "app.quicka.co/api/sample?filter[age]=old",
+			wantField: "age",
+			wantValue: "old",
+		},
+		{
+			name:      "Wrong boolean",
+			url:       "app.quicka.co/api/sample?filter[active]=maybe",
+			wantField: "active",
+			wantValue: "maybe",
+		},
+		{
+			name:      "Wrong time",
+			url:       "app.quicka.co/api/sample?filter[created_at][gte]=yesterday",
+			wantField: "created_at",
+			wantValue: "yesterday",
+		},
+		{
+			name:      "Wrong UUID",
+			url:       "app.quicka.co/api/sample?filter[team_id]=123",
+			wantField: "team_id",
+			wantValue: "123",
+		},
+		{
+			name:      "Wrong enum value",
+			url:       "app.quicka.co/api/sample?filter[status]=in:active,deleted",
+			wantField: "status",
+			wantValue: "deleted",
+		},
+		{
+			name:      "Operator not allowed for the type",
+			url:       "app.quicka.co/api/sample?filter[active][gt]=true",
+			wantField: "active",
+			wantValue: "true",
+		},
+		{
+			name:      "Operator not allowed for the field",
+			url:       "app.quicka.co/api/sample?filter[email][like]=%25@quicka.co",
+			wantField: "email",
+			wantValue: "%@quicka.co",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			_, err = usersSchema.FindFilters(req)
+			assert.True(t, errors.Is(err, pagination.ErrInvalidFilter))
+			var filterErr *pagination.FilterError
+			assert.True(t, errors.As(err, &filterErr))
+			assert.Equal(t, tt.wantField, filterErr.Field)
+			assert.Equal(t, tt.wantValue, filterErr.Value)
+		})
+	}
+}
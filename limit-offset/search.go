@@ -0,0 +1,59 @@
+package pagination
+
+import (
+	"strings"
+)
+
+// likeEscaper escapes the wildcards of the LIKE patterns so the search term is
+// matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchWhere will build the SQL condition matching the search term as a
+// substring of any of the given columns ignoring the case, ILIKE is used on
+// PostgreSQL and LOWER(column) LIKE LOWER(?) on the rest of dialects. The term
+// is answered back as the args of the query and an empty condition when there
+// is no search term. It answers back the condition alone, so it can be joined
+// with the filters
+//
+//	where, args := list.Filters.Where(columns, list.Dialect, 1)
+//	search, searchArgs := list.SearchWhere([]string{"name", "email"}, len(args)+1)
+//
+// The first placeholder is the position used for the first arg, useful when
+// the parent query already has args on PostgreSQL
+func (l ListParams) SearchWhere(columns []string, firstPlaceholder int) (string, []interface{}) {
+	if l.Search == "" || len(columns) == 0 {
+		return "", nil
+	}
+	pattern := "%" + likeEscaper.Replace(l.Search) + "%"
+	conditions := make([]string, 0, len(columns))
+	args := make([]interface{}, 0, len(columns))
+	for i, column := range columns {
+		placeholder := l.Dialect.Placeholder(firstPlaceholder + i)
+		switch l.Dialect {
+		case DialectPostgres:
+			conditions = append(conditions, column+" ILIKE "+placeholder)
+		case DialectSQLite:
+			conditions = append(conditions, "LOWER("+column+") LIKE LOWER("+placeholder+`) ESCAPE '\'`)
+		default:
+			conditions = append(conditions, "LOWER("+column+") LIKE LOWER("+placeholder+")")
+		}
+		args = append(args, pattern)
+	}
+	return "(" + strings.Join(conditions, " OR ") + ")", args
+}
+
+// SearchTSQuery will build the PostgreSQL full-text condition matching the
+// search term against the given columns using the given text search config,
+// for example english. The term is parsed with plainto_tsquery so the clients
+// can't send tsquery operators, and it is answered back as the only arg
+func (l ListParams) SearchTSQuery(config string, columns []string, firstPlaceholder int) (string, []interface{}) {
+	if l.Search == "" || len(columns) == 0 {
+		return "", nil
+	}
+	document := make([]string, 0, len(columns))
+	for _, column := range columns {
+		document = append(document, "coalesce("+column+", '')")
+	}
+	config = quoteString(config)
+	return "to_tsvector(" + config + ", " + strings.Join(document, " || ' ' || ") + ") @@ plainto_tsquery(" + config + ", " + DialectPostgres.Placeholder(firstPlaceholder) + ")", []interface{}{l.Search}
+}
@@ -0,0 +1,72 @@
+package pagination_test
+
+import (
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchWhere(t *testing.T) {
+	tests := []struct {
+		name     string
+		list     pagination.ListParams
+		columns  []string
+		want     string
+		wantArgs []interface{}
+	}{
+		{
+			name:    "Without search term",
+			list:    pagination.ListParams{},
+			columns: []string{"name"},
+		},
+		{
+			name:     "PostgreSQL",
+			list:     pagination.ListParams{Params: pagination.Params{Dialect: pagination.DialectPostgres}, Search: "john"},
+			columns:  []string{"name", "email"},
+			want:     "(name ILIKE $3 OR email ILIKE $4)",
+			wantArgs: []interface{}{"%john%", "%john%"},
+		},
+		{
+			name:     "MySQL",
+			list:     pagination.ListParams{Params: pagination.Params{Dialect: pagination.DialectMySQL}, Search: "john"},
+			columns:  []string{"name"},
+			want:     "(LOWER(name) LIKE LOWER(?))",
+			wantArgs: []interface{}{"%john%"},
+		},
+		{
+			name:     "SQLite",
+			list:     pagination.ListParams{Params: pagination.Params{Dialect: pagination.DialectSQLite}, Search: "john"},
+			columns:  []string{"name"},
+			want:     `(LOWER(name) LIKE LOWER(?) ESCAPE '\')`,
+			wantArgs: []interface{}{"%john%"},
+		},
+		{
+			name:     "Wildcards matched literally",
+			list:     pagination.ListParams{Params: pagination.Params{Dialect: pagination.DialectPostgres}, Search: `50%_off\`},
+			columns:  []string{"name"},
+			want:     "(name ILIKE $3)",
+			wantArgs: []interface{}{`%50\%\_off\\%`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := tt.list.SearchWhere(tt.columns, 3)
+			assert.Equal(t, tt.want, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestSearchTSQuery(t *testing.T) {
+	list := pagination.ListParams{Search: "john doe"}
+
+	where, args := list.SearchTSQuery("english", []string{"name", "bio"}, 1)
+	assert.Equal(t, "to_tsvector('english', coalesce(name, '') || ' ' || coalesce(bio, '')) @@ plainto_tsquery('english', $1)", where)
+	assert.Equal(t, []interface{}{"john doe"}, args)
+
+	where, args = pagination.ListParams{}.SearchTSQuery("english", []string{"name"}, 1)
+	assert.Equal(t, "", where)
+	assert.Nil(t, args)
+}
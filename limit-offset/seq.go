@@ -0,0 +1,33 @@
+package pagination
+
+import "iter"
+
+// PaginateSeq will skip the offset items of the given sequence, take the page
+// plus the next item, so we know if there are more pages, and build the
+// paginated response like Paginate does. The sequence is stopped once the
+// page is taken, so the rest of the items are never produced, which keeps the
+// sources exposing iterators, like database cursors or streamed files, from
+// being loaded in memory
+func PaginateSeq[T any](seq iter.Seq[T], baseURL string, params Params) Response {
+	return Paginate(take(seq, params), baseURL, params)
+}
+
+// take function will take the items of the page the params are pointing to,
+// plus the next one
+func take[T any](seq iter.Seq[T], params Params) []interface{} {
+	var (
+		data    []interface{}
+		skipped uint
+	)
+	for item := range seq {
+		if skipped < params.Offset {
+			skipped++
+			continue
+		}
+		data = append(data, item)
+		if uint(len(data)) > params.Limit {
+			break
+		}
+	}
+	return data
+}
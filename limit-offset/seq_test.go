@@ -0,0 +1,78 @@
+package pagination_test
+
+import (
+	"iter"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+// numbers answers back a sequence of the numbers from 0 to n, counting the
+// numbers produced
+func numbers(n int, produced *int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			*produced++
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+func TestPaginateSeq(t *testing.T) {
+	tests := []struct {
+		name         string
+		size         int
+		params       pagination.Params
+		wantData     []interface{}
+		wantLinks    pagination.Links
+		wantProduced int
+	}{
+		{
+			name:     "First page",
+			size:     100,
+			params:   pagination.Params{Limit: 2},
+			wantData: []interface{}{0, 1},
+			wantLinks: pagination.Links{
+				First: "/numbers?page[limit]=2&page[offset]=0",
+				Next:  "/numbers?page[limit]=2&page[offset]=2",
+			},
+			wantProduced: 3,
+		},
+		{
+			name:     "Middle page",
+			size:     100,
+			params:   pagination.Params{Limit: 2, Offset: 4},
+			wantData: []interface{}{4, 5},
+			wantLinks: pagination.Links{
+				First: "/numbers?page[limit]=2&page[offset]=0",
+				Next:  "/numbers?page[limit]=2&page[offset]=6",
+				Prev:  "/numbers?page[limit]=2&page[offset]=2",
+			},
+			wantProduced: 7,
+		},
+		{
+			name:     "Last page",
+			size:     5,
+			params:   pagination.Params{Limit: 2, Offset: 4},
+			wantData: []interface{}{4},
+			wantLinks: pagination.Links{
+				First: "/numbers?page[limit]=2&page[offset]=0",
+				Prev:  "/numbers?page[limit]=2&page[offset]=2",
+			},
+			wantProduced: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			produced := 0
+			response := pagination.PaginateSeq(numbers(tt.size, &produced), "/numbers", tt.params)
+			assert.Equal(t, tt.wantData, response.Data)
+			assert.Equal(t, tt.wantLinks, response.Links)
+			assert.Equal(t, tt.wantProduced, produced)
+		})
+	}
+}
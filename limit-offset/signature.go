@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ParamSignature is the value for the signature parameter appended to the
+// signed links, see LinkSigner
+const ParamSignature = "page[sig]"
+
+// ErrInvalidSignature is the error returned for the requests beyond the
+// MaxUnsignedOffset of a LinkSigner without a valid signature
+var ErrInvalidSignature = errors.New("pagination: invalid link signature")
+
+// LinkSigner type signs the whole query of the generated links with HMAC,
+// appending it as the page[sig] param, so only the links we issued can
+// paginate beyond the MaxUnsignedOffset. It is meant for the unauthenticated
+// public endpoints, where the clients could build deep requests by hand. It is
+// safe for concurrent use
+type LinkSigner struct {
+	key []byte
+	// MaxUnsignedOffset is the deepest offset served without a signature, the
+	// deeper requests must carry a valid one
+	MaxUnsignedOffset uint
+}
+
+// NewLinkSigner will build a new signer with the given key, it should be a
+// random secret of at least 32 bytes shared by all the instances of the
+// service
+func NewLinkSigner(key []byte) *LinkSigner {
+	return &LinkSigner{key: key}
+}
+
+// Sign method will answer back the given link with the signature of its query
+// appended
+func (s *LinkSigner) Sign(link string) string {
+	_, query, found := strings.Cut(link, "?")
+	if !found {
+		return link + "?" + ParamSignature + "=" + s.signature(query)
+	}
+	return link + "&" + ParamSignature + "=" + s.signature(query)
+}
+
+// Verify method will check the signature of the query of the given request,
+// the signature param can be anywhere on the query. ErrInvalidSignature is
+// returned when it is missing or wrong
+func (s *LinkSigner) Verify(req *http.Request) error {
+	signature := lookupParam(req.URL.RawQuery, ParamSignature)
+	if signature == "" || !hmac.Equal([]byte(signature), []byte(s.signature(req.URL.RawQuery))) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// verifyDepth method will check the signature of the requests beyond the
+// MaxUnsignedOffset
+func (s *LinkSigner) verifyDepth(req *http.Request, params *Params) error {
+	if params.Offset <= s.MaxUnsignedOffset {
+		return nil
+	}
+	return s.Verify(req)
+}
+
+// signature method will compute the signature of the given raw query, every
+// param but the signature is signed in order. The keys and values are signed
+// unescaped, so the clients escaping the brackets don't break the signature,
+// each of them prefixed with its length, so an escaped value can't be split
+// into other params keeping the signature
+func (s *LinkSigner) signature(rawQuery string) string {
+	h := hmac.New(sha256.New, s.key)
+	var length [binary.MaxVarintLen64]byte
+	write := func(value string) {
+		h.Write(binary.AppendUvarint(length[:0], uint64(len(value))))
+		h.Write([]byte(value))
+	}
+	for rawQuery != "" {
+		var pair string
+		pair, rawQuery, _ = strings.Cut(rawQuery, "&")
+		key, value, _ := strings.Cut(pair, "=")
+		key, _ = unescapeQuery(key)
+		if pair == "" || key == ParamSignature {
+			continue
+		}
+		value, _ = unescapeQuery(value)
+		write(key)
+		write(value)
+	}
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)[:tokenMACSize])
+}
@@ -0,0 +1,72 @@
+package pagination_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkSigner(t *testing.T) {
+	signer := pagination.NewLinkSigner(tokenKey)
+	signer.MaxUnsignedOffset = 20
+	policy := pagination.Policy{DefaultLimit: 20, LinkSigner: signer}
+
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?page[offset]=20&sort=name.asc&filter[status]=active", nil))
+	assert.NoError(t, err)
+	links := pagination.Paginate(make([]interface{}, 21), "/users", params).Links
+	assert.True(t, strings.HasPrefix(links.Next, "/users?page[limit]=20&page[offset]=40&sort=name.asc&filter[status]=active&page[sig]="))
+
+	tests := []struct {
+		name       string
+		link       string
+		wantOffset uint
+		wantErr    bool
+	}{
+		{name: "Signed link", link: links.Next, wantOffset: 40},
+		{name: "Escaped brackets", link: strings.NewReplacer("[", "%5B", "]", "%5D").Replace(links.Next), wantOffset: 40},
+		{name: "Shallow without signature", link: "/users?page[offset]=20", wantOffset: 20},
+		{name: "Deep without signature", link: "/users?page[offset]=40", wantErr: true},
+		{name: "Modified offset", link: strings.Replace(links.Next, "page[offset]=40", "page[offset]=4000", 1), wantErr: true},
+		{name: "Removed filter", link: strings.Replace(links.Next, "&filter[status]=active", "", 1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := policy.FindParams(httptest.NewRequest("GET", tt.link, nil))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, pagination.ErrInvalidSignature)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOffset, params.Offset)
+		})
+	}
+}
+
+func TestLinkSignerForgery(t *testing.T) {
+	signer := pagination.NewLinkSigner(tokenKey)
+	signed := signer.Sign("/users?filter[name]=x%00page%5Boffset%5D%3D99999")
+	_, signature, _ := strings.Cut(signed, "&page[sig]=")
+	assert.NoError(t, signer.Verify(httptest.NewRequest("GET", signed, nil)))
+
+	// The escaped value re-split into other params keeps the same bytes once
+	// unescaped, the signature must not follow them
+	for _, forged := range []string{
+		"/users?filter[name]=x&page[offset]=99999&page[sig]=" + signature,
+		"/users?filter[name]%3Dx%00page[offset]=99999&page[sig]=" + signature,
+	} {
+		assert.ErrorIs(t, signer.Verify(httptest.NewRequest("GET", forged, nil)), pagination.ErrInvalidSignature, forged)
+	}
+}
+
+func TestLinkSignerVerify(t *testing.T) {
+	signer := pagination.NewLinkSigner(tokenKey)
+	link := signer.Sign("https://api.example.com/users?page[limit]=10&page[offset]=500")
+	assert.NoError(t, signer.Verify(httptest.NewRequest("GET", link, nil)))
+
+	other := pagination.NewLinkSigner([]byte("another key of 32 bytes for test"))
+	assert.ErrorIs(t, other.Verify(httptest.NewRequest("GET", link, nil)), pagination.ErrInvalidSignature)
+}
@@ -0,0 +1,134 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	// SlackParamLimit is the page limit param used by the Slack preset
+	SlackParamLimit = "limit"
+	// SlackParamCursor is the param carrying the next_cursor of the previous
+	// page with the Slack preset
+	SlackParamCursor = "cursor"
+)
+
+// SlackParams type encapsulates the params found on a request using the Slack
+// conventions, the page is located by the opaque cursor answered back on the
+// response_metadata of the previous page
+type SlackParams struct {
+	Params
+
+	// cursors is the codec signing the cursors, when there is none they are
+	// just encoded with base64
+	cursors *TokenCodec
+}
+
+// SlackPage type encapsulates a paginated response with the shape of the
+// Slack API, the items are answered back under the given Key, like members
+type SlackPage struct {
+	Key              string
+	Items            []interface{}
+	ResponseMetadata SlackResponseMetadata
+}
+
+// SlackResponseMetadata type encapsulates the response_metadata of the Slack
+// API, an empty NextCursor means there are no more pages
+type SlackResponseMetadata struct {
+	NextCursor string `json:"next_cursor"`
+}
+
+// FindSlackParams will find the params on the request using the Slack
+// conventions, limit and cursor, applying the rules of the given policy. When
+// the policy has Tokens the cursors are signed with them. The pagination params
+// sent by the client, like page[offset], are ignored, the page is only located
+// by the cursor
+func FindSlackParams(req *http.Request, policy Policy) (SlackParams, error) {
+	slack := SlackParams{cursors: policy.Tokens}
+	// The cursors are decoded with the Tokens of the given policy, the preset
+	// one doesn't have them
+	preset := policy.preset()
+	rawQuery, err := preset.applyDuplicateParams(req.URL.RawQuery, SlackParamLimit, SlackParamCursor)
+	rawQuery = withoutPageParams(rawQuery)
+	if cursor := lookupParam(rawQuery, SlackParamCursor); err == nil && cursor != "" {
+		var query string
+		if query, err = policy.decodeCursor(req, cursor); err == nil {
+			rawQuery = query + "&" + rawQuery
+		}
+	}
+	if err == nil {
+		err = preset.findParamsInto(req, aliasQuery(rawQuery, ParamPageLimit, SlackParamLimit), &slack.Params)
+	}
+	if slack.cursors != nil && slack.cursors.CorrelationIDs {
+		slack.correlationID = CorrelationID(req.Context())
+	}
+	if slack.cursors != nil {
+		slack.scope = TokenScope(req.Context())
+	}
+	return slack, preset.found(req, &slack.Params, err)
+}
+
+// Paginate method will build the Slack page for the given data, the items are
+// answered back under the given key
+func (s SlackParams) Paginate(key string, data []interface{}) SlackPage {
+	s.pageServed(len(data))
+	page := SlackPage{Key: key, Items: buildData(data, s.Params)}
+	if page.Items == nil {
+		page.Items = []interface{}{}
+	}
+	if next := buildLinks("", s.Params, len(data)).Next; next != "" {
+		page.ResponseMetadata.NextCursor = encodeCursor(s.cursors, next[1:], s.correlationID, s.scope)
+	}
+	s.paginated(context.Background(), Response{Data: page.Items, Links: Links{Next: page.ResponseMetadata.NextCursor}})
+	return page
+}
+
+// MarshalJSON method will encode the page with the items under its key
+func (p SlackPage) MarshalJSON() ([]byte, error) {
+	key, err := json.Marshal(p.Key)
+	if err != nil {
+		return nil, err
+	}
+	items, err := json.Marshal(p.Items)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := json.Marshal(p.ResponseMetadata)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	b.WriteString(`{"ok":true,`)
+	b.Write(key)
+	b.WriteByte(':')
+	b.Write(items)
+	b.WriteString(`,"response_metadata":`)
+	b.Write(metadata)
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// encodeCursor function will build the opaque cursor for the given query, it
+// is signed when there is a codec, bound to the given scope, and just encoded
+// with base64 otherwise
+func encodeCursor(cursors *TokenCodec, query, correlationID, scope string) string {
+	if cursors != nil {
+		return cursors.Encode(PageToken{Query: query, CorrelationID: correlationID, Scope: scope})
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(query))
+}
+
+// decodeCursor method will answer back the query kept inside the given cursor
+func (p Policy) decodeCursor(req *http.Request, cursor string) (string, error) {
+	if p.Tokens != nil {
+		return p.decodeToken(req, cursor)
+	}
+	query, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", &TokenError{Failure: TokenMalformed, Reason: "the cursor is malformed"}
+	}
+	return string(query), nil
+}
@@ -0,0 +1,112 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlackPaginate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy pagination.Policy
+	}{
+		{
+			name:   "Base64 cursors",
+			policy: pagination.Policy{DefaultLimit: 10},
+		},
+		{
+			name:   "Signed cursors",
+			policy: pagination.Policy{DefaultLimit: 10, Tokens: pagination.NewTokenCodec([]byte("0123456789abcdef0123456789abcdef"))},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?limit=2&sort=name.asc", nil)
+			assert.Nil(t, err)
+			slack, err := pagination.FindSlackParams(req, tt.policy)
+			assert.Nil(t, err)
+			assert.Equal(t, uint(2), slack.Limit)
+
+			page := slack.Paginate("members", []interface{}{"a", "b", "c"})
+			assert.Equal(t, []interface{}{"a", "b"}, page.Items)
+			cursor := page.ResponseMetadata.NextCursor
+			assert.NotEmpty(t, cursor)
+
+			req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?cursor="+url.QueryEscape(cursor), nil)
+			assert.Nil(t, err)
+			slack, err = pagination.FindSlackParams(req, tt.policy)
+			assert.Nil(t, err)
+			assert.Equal(t, uint(2), slack.Limit)
+			assert.Equal(t, uint(2), slack.Offset)
+			assert.Equal(t, []pagination.Sort{{Field: "name", Order: "asc"}}, slack.Sort)
+
+			page = slack.Paginate("members", []interface{}{"c"})
+			assert.Equal(t, "", page.ResponseMetadata.NextCursor)
+		})
+	}
+}
+
+func TestFindSlackParamsIgnoresPageParams(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?limit=2", nil)
+	assert.Nil(t, err)
+	slack, err := pagination.FindSlackParams(req, pagination.Policy{DefaultLimit: 10, MaxLimit: 100})
+	assert.Nil(t, err)
+	cursor := slack.Paginate("members", []interface{}{1, 2, 3}).ResponseMetadata.NextCursor
+
+	tests := map[string]struct {
+		query      string
+		wantOffset uint
+		wantLimit  uint
+	}{
+		"offset without cursor": {
+			query:      "page[offset]=5000&page[limit]=100",
+			wantOffset: 0,
+			wantLimit:  10,
+		},
+		"escaped offset without cursor": {
+			query:      "page%5Boffset%5D=5000&page[number]=40",
+			wantOffset: 0,
+			wantLimit:  10,
+		},
+		"offset with cursor": {
+			query:      "page[offset]=5000&cursor=" + url.QueryEscape(cursor),
+			wantOffset: 2,
+			wantLimit:  2,
+		},
+		"limit with cursor": {
+			query:      "limit=3&page[limit]=100&cursor=" + url.QueryEscape(cursor),
+			wantOffset: 2,
+			wantLimit:  3,
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?"+tt.query, nil)
+			assert.Nil(t, err)
+			slack, err := pagination.FindSlackParams(req, pagination.Policy{DefaultLimit: 10, MaxLimit: 100})
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantOffset, slack.Offset)
+			assert.Equal(t, tt.wantLimit, slack.Limit)
+		})
+	}
+}
+
+func TestFindSlackParamsMalformedCursor(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?cursor=not*base64", nil)
+	assert.Nil(t, err)
+	_, err = pagination.FindSlackParams(req, pagination.Policy{DefaultLimit: 10})
+	assert.True(t, errors.Is(err, pagination.ErrTokenMalformed))
+}
+
+func TestSlackPageMarshalJSON(t *testing.T) {
+	body, err := json.Marshal(pagination.SlackPage{Key: "members", Items: []interface{}{"a"}})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"ok": true, "members": ["a"], "response_metadata": {"next_cursor": ""}}`, string(body))
+}
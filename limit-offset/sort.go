@@ -0,0 +1,263 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// OrderAsc is the value for sorting a field in ascending order
+	OrderAsc = "asc"
+	// OrderDesc is the value for sorting a field in descending order
+	OrderDesc = "desc"
+)
+
+// SortFormat type defines the format of the sort param
+type SortFormat int
+
+const (
+	// SortFormatAuto accepts both the dot suffix format and the prefix format,
+	// on the links the dot suffix format is used unless the client used the
+	// prefix one
+	SortFormatAuto SortFormat = iota
+	// SortFormatDot is the field_name.order format, for example
+	// created_at.desc,name.asc
+	SortFormatDot
+	// SortFormatPrefix is the JSON:API format, where a minus prefix means a
+	// descending order and no prefix (or a plus) an ascending one, for example
+	// -created_at,name
+	SortFormatPrefix
+	// SortFormatPair is the SQL like format used on the order_by param, where
+	// each field is followed by its order separated with a space, for example
+	// order_by=created_at desc,name asc. A field without order is ascending
+	SortFormatPair
+)
+
+// Sort type encapsulates the information needed for order and sort a query, the
+// field will have the name column to be sorted and the order will have the value
+// of asc or desc
+type Sort struct {
+	Field string
+	Order string
+	// Columns keeps the SQL expressions used on the query for sorting by this
+	// field, when empty the field name is used. The links always use the field
+	Columns []string
+	// Collation is the collation used on the query for sorting by this field,
+	// for example und-x-icu or utf8mb4_unicode_ci, so alphabetical sorts
+	// behave correctly for non-English locales. It is rendered using the
+	// dialect of the params and never added to the links
+	Collation string
+}
+
+// direction method will answer back the order written on the query, always
+// one of the OrderAsc and OrderDesc constants no matter the case of the order,
+// the unknown orders are ascending
+func (s Sort) direction() string {
+	if strings.EqualFold(s.Order, OrderDesc) {
+		return OrderDesc
+	}
+	return OrderAsc
+}
+
+// validOrder method will check if the order is asc or desc, in any case
+func (s Sort) validOrder() bool {
+	return strings.EqualFold(s.Order, OrderAsc) || strings.EqualFold(s.Order, OrderDesc)
+}
+
+// columns method will return the SQL expressions used for sorting by the field
+func (s Sort) columns() []string {
+	if len(s.Columns) > 0 {
+		return s.Columns
+	}
+	return []string{s.Field}
+}
+
+// appendSort function will parse the given sort value and append the sort
+// fields found into the given slice, mallformed fields are ignored. It also
+// answers back the format used, that is the prefix one as soon as one of the
+// fields uses it
+func appendSort(dst []Sort, sort string, format SortFormat) ([]Sort, SortFormat) {
+	found := SortFormatAuto
+	sortFields := strings.Count(sort, ",") + 1
+	for sort != "" {
+		var field string
+		field, sort, _ = strings.Cut(sort, ",")
+		s, fieldFormat, ok := parseSortField(field, format)
+		if !ok {
+			continue
+		}
+		if len(dst) == 0 && cap(dst) < sortFields {
+			dst = make([]Sort, 0, sortFields)
+		}
+		dst = append(dst, s)
+		if found != SortFormatPrefix {
+			found = fieldFormat
+		}
+	}
+	return dst, found
+}
+
+// parseSortField function will parse a single sort field accepting the given
+// format
+func parseSortField(field string, format SortFormat) (Sort, SortFormat, bool) {
+	if format != SortFormatDot && field != "" {
+		// The plus sign arrives as a space when it isn't escaped on the url
+		switch field[0] {
+		case '-':
+			return Sort{Field: field[1:], Order: OrderDesc}, SortFormatPrefix, len(field) > 1
+		case '+', ' ':
+			return Sort{Field: field[1:], Order: OrderAsc}, SortFormatPrefix, len(field) > 1
+		}
+		// A field without prefix is ascending, for avoiding confusing it with a
+		// mallformed value like asc(name) we only accept plain names, and with
+		// the auto format they can't have dots for not confusing them with the
+		// dot suffix format
+		if isPlainName(field, format == SortFormatPrefix) {
+			return Sort{Field: field, Order: OrderAsc}, SortFormatPrefix, true
+		}
+		if format == SortFormatPrefix {
+			return Sort{}, SortFormatPrefix, false
+		}
+	}
+	// The format of sort and order values shoulde be something
+	// like this name.asc or name.desc, the order is after the last dot so
+	// nested fields like metadata.priority.desc are also allowed
+	i := strings.LastIndexByte(field, '.')
+	if i <= 0 {
+		return Sort{}, SortFormatDot, false
+	}
+	return Sort{Field: field[:i], Order: field[i+1:]}, SortFormatDot, true
+}
+
+// isPlainName function will check if the given value only contains letters,
+// digits, underscores and, when allowed, dots
+func isPlainName(value string, allowDots bool) bool {
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if !(c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || allowDots && c == '.') {
+			return false
+		}
+	}
+	return value != ""
+}
+
+// safeIdentifier function will check if the given sort field is a safe SQL
+// identifier, that is letters, digits and underscores not starting with a
+// digit, with an optional single dot for the table prefix
+func safeIdentifier(field string) bool {
+	table, column, prefixed := strings.Cut(field, ".")
+	if !prefixed {
+		return safeName(table)
+	}
+	return safeName(table) && safeName(column)
+}
+
+// safeName function will check if the given name is made of letters, digits
+// and underscores not starting with a digit
+func safeName(name string) bool {
+	return isPlainName(name, false) && !('0' <= name[0] && name[0] <= '9')
+}
+
+// strictSortFields function will refuse the sort fields that aren't safe
+// identifiers and the orders that aren't asc or desc, answering back a problem
+// for each of them
+func strictSortFields(sort []Sort) (problems []error) {
+	for _, s := range sort {
+		if s.Field != SortRandom && !safeIdentifier(s.Field) {
+			problems = append(problems, fmt.Errorf("%w %q, only letters, digits, underscores and a table prefix are allowed", ErrInvalidSortField, s.Field))
+		}
+		if !s.validOrder() {
+			problems = append(problems, &SortOrderError{Field: s.Field, Order: s.Order})
+		}
+	}
+	return problems
+}
+
+// appendOrderBy function will parse the given order_by value and append the
+// sort fields found into the given slice, empty fields are ignored
+func appendOrderBy(dst []Sort, orderBy string) ([]Sort, SortFormat) {
+	found := SortFormatAuto
+	sortFields := strings.Count(orderBy, ",") + 1
+	for orderBy != "" {
+		var field string
+		field, orderBy, _ = strings.Cut(orderBy, ",")
+		name, order, _ := strings.Cut(strings.TrimSpace(field), " ")
+		if name == "" {
+			continue
+		}
+		if order = strings.TrimSpace(order); order == "" {
+			order = OrderAsc
+		}
+		if len(dst) == 0 && cap(dst) < sortFields {
+			dst = make([]Sort, 0, sortFields)
+		}
+		dst = append(dst, Sort{Field: name, Order: order})
+		found = SortFormatPair
+	}
+	return dst, found
+}
+
+// appendSortValue function will append the serialized sort slice into the
+// given buffer using the given format
+func appendSortValue(b []byte, sort []Sort, format SortFormat) []byte {
+	for i, s := range sort {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		switch format {
+		case SortFormatPrefix:
+			if strings.EqualFold(s.Order, OrderDesc) {
+				b = append(b, '-')
+			}
+			b = append(b, s.Field...)
+		case SortFormatPair:
+			b = append(b, s.Field...)
+			b = append(b, ' ')
+			b = append(b, s.Order...)
+		default:
+			b = append(b, s.Field...)
+			b = append(b, '.')
+			b = append(b, s.Order...)
+		}
+	}
+	return b
+}
+
+// sortMatches function will check, without allocating, if the given value is
+// the serialized form of the sort slice on the given format
+func sortMatches(sort []Sort, value string, format SortFormat) bool {
+	for i, s := range sort {
+		if i > 0 {
+			if !strings.HasPrefix(value, ",") {
+				return false
+			}
+			value = value[1:]
+		}
+		if format == SortFormatPrefix && strings.EqualFold(s.Order, OrderDesc) {
+			if !strings.HasPrefix(value, "-") {
+				return false
+			}
+			value = value[1:]
+		}
+		if !strings.HasPrefix(value, s.Field) {
+			return false
+		}
+		value = value[len(s.Field):]
+		if format == SortFormatPrefix {
+			continue
+		}
+		separator := "."
+		if format == SortFormatPair {
+			separator = " "
+		}
+		if !strings.HasPrefix(value, separator) {
+			return false
+		}
+		value = value[1:]
+		if !strings.HasPrefix(value, s.Order) {
+			return false
+		}
+		value = value[len(s.Order):]
+	}
+	return value == ""
+}
@@ -0,0 +1,328 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindSortFormats(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     pagination.SortFormat
+		url        string
+		want       []pagination.Sort
+		wantFormat pagination.SortFormat
+		wantFirst  string
+	}{
+		{
+			name:       "Auto format with dot suffix",
+			url:        "app.quicka.co/api/sample?sort=created_at.desc,name.asc",
+			want:       []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "name", Order: "asc"}},
+			wantFormat: pagination.SortFormatDot,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=created_at.desc,name.asc",
+		},
+		{
+			name:       "Auto format with prefix",
+			url:        "app.quicka.co/api/sample?sort=-created_at,+name",
+			want:       []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "name", Order: "asc"}},
+			wantFormat: pagination.SortFormatPrefix,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=-created_at,name",
+		},
+		{
+			name:       "Auto format with escaped plus prefix",
+			url:        "app.quicka.co/api/sample?sort=%2Bname",
+			want:       []pagination.Sort{{Field: "name", Order: "asc"}},
+			wantFormat: pagination.SortFormatPrefix,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=name",
+		},
+		{
+			name:       "Auto format with plain names",
+			url:        "app.quicka.co/api/sample?sort=-created_at,name,asc(muz)",
+			want:       []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "name", Order: "asc"}},
+			wantFormat: pagination.SortFormatPrefix,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=-created_at,name",
+		},
+		{
+			name:       "Dot format ignoring the prefix",
+			format:     pagination.SortFormatDot,
+			url:        "app.quicka.co/api/sample?sort=-created_at,name,id.asc",
+			want:       []pagination.Sort{{Field: "id", Order: "asc"}},
+			wantFormat: pagination.SortFormatDot,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=id.asc",
+		},
+		{
+			name:       "Prefix format ignoring mallformed fields",
+			format:     pagination.SortFormatPrefix,
+			url:        "app.quicka.co/api/sample?sort=-created_at,asc(name),id",
+			want:       []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "id", Order: "asc"}},
+			wantFormat: pagination.SortFormatPrefix,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=-created_at,id",
+		},
+		{
+			name:       "Dot format with nested fields",
+			url:        "app.quicka.co/api/sample?sort=metadata.priority.desc",
+			want:       []pagination.Sort{{Field: "metadata.priority", Order: "desc"}},
+			wantFormat: pagination.SortFormatDot,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=metadata.priority.desc",
+		},
+		{
+			name:       "Prefix format with nested fields",
+			format:     pagination.SortFormatPrefix,
+			url:        "app.quicka.co/api/sample?sort=-metadata.priority,metadata.owner",
+			want:       []pagination.Sort{{Field: "metadata.priority", Order: "desc"}, {Field: "metadata.owner", Order: "asc"}},
+			wantFormat: pagination.SortFormatPrefix,
+			wantFirst:  "/sample?page[limit]=5&page[offset]=0&sort=-metadata.priority,metadata.owner",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := pagination.Policy{DefaultLimit: 5, SortFormat: tt.format}.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, params.Sort)
+			assert.Equal(t, tt.wantFormat, params.SortFormat)
+			assert.Equal(t, tt.wantFirst, pagination.Paginate(nil, "/sample", params).Links.First)
+		})
+	}
+}
+
+func TestSortValueWithPrefixFormat(t *testing.T) {
+	params := pagination.Params{
+		SortFormat: pagination.SortFormatPrefix,
+		Sort: []pagination.Sort{
+			{Field: "created_at", Order: "desc"},
+			{Field: "name", Order: "asc"},
+		},
+	}
+	assert.Equal(t, "-created_at,name", params.SortValue())
+	assert.Equal(t, "sort=-created_at,name", params.SortURL())
+}
+
+func TestFindOrderByPairFormat(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    pagination.SortFormat
+		url       string
+		want      []pagination.Sort
+		wantFirst string
+	}{
+		{
+			name:      "Auto format with order_by",
+			url:       "app.quicka.co/api/sample?order_by=created_at+desc,+name",
+			want:      []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "name", Order: "asc"}},
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&order_by=created_at+desc,name+asc",
+		},
+		{
+			name:      "Auto format prefers the sort param",
+			url:       "app.quicka.co/api/sample?order_by=created_at+desc&sort=name.asc",
+			want:      []pagination.Sort{{Field: "name", Order: "asc"}},
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&sort=name.asc",
+		},
+		{
+			name:      "Pair format ignoring the sort param",
+			format:    pagination.SortFormatPair,
+			url:       "app.quicka.co/api/sample?order_by=created_at%20desc&sort=name.asc",
+			want:      []pagination.Sort{{Field: "created_at", Order: "desc"}},
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&order_by=created_at+desc",
+		},
+		{
+			name:   "Dot format ignoring the order_by param",
+			format: pagination.SortFormatDot,
+			url:    "app.quicka.co/api/sample?order_by=created_at+desc",
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+			params, err := pagination.Policy{DefaultLimit: 5, SortFormat: tt.format}.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, params.Sort)
+			if tt.wantFirst != "" {
+				assert.Equal(t, tt.wantFirst, pagination.Paginate(nil, "/sample", params).Links.First)
+			}
+		})
+	}
+}
+
+func TestLinkSortFormat(t *testing.T) {
+	sort := "app.quicka.co/api/sample?sort=-created_at,name"
+	tests := []struct {
+		name      string
+		format    pagination.SortFormat
+		wantFirst string
+	}{
+		{
+			name:      "Same format used by the client",
+			format:    pagination.SortFormatAuto,
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&sort=-created_at,name",
+		},
+		{
+			name:      "Dot format",
+			format:    pagination.SortFormatDot,
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&sort=created_at.desc,name.asc",
+		},
+		{
+			name:      "Pair format",
+			format:    pagination.SortFormatPair,
+			wantFirst: "/sample?page[limit]=5&page[offset]=0&order_by=created_at+desc,name+asc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, sort, nil)
+			assert.Nil(t, err)
+			params, err := pagination.Policy{DefaultLimit: 5, LinkSortFormat: tt.format}.FindParams(req)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantFirst, pagination.Paginate(nil, "/sample", params).Links.First)
+		})
+	}
+}
+
+func TestStrictSortFields(t *testing.T) {
+	policy := pagination.Policy{StrictSortFields: true}
+
+	tests := []struct {
+		name     string
+		query    string
+		wantSort []pagination.Sort
+		wantErr  bool
+	}{
+		{
+			name:     "Plain names",
+			query:    "sort=-created_at,name2",
+			wantSort: []pagination.Sort{{Field: "created_at", Order: "desc"}, {Field: "name2", Order: "asc"}},
+		},
+		{
+			name:     "Table prefix",
+			query:    "sort=users.name.desc",
+			wantSort: []pagination.Sort{{Field: "users.name", Order: "desc"}},
+		},
+		{
+			name:    "Injection on the pair format",
+			query:   "order_by=" + url.QueryEscape("name;DROP TABLE users asc"),
+			wantErr: true,
+		},
+		{
+			name:    "Function call",
+			query:   "sort=" + url.QueryEscape("lower(name).asc"),
+			wantErr: true,
+		},
+		{
+			name:    "Two dots",
+			query:   "sort=public.users.name.asc",
+			wantErr: true,
+		},
+		{
+			name:    "Starting with a digit",
+			query:   "sort=1name.asc",
+			wantErr: true,
+		},
+		{
+			name:    "Empty table prefix",
+			query:   "order_by=.name",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := policy.FindParams(httptest.NewRequest("GET", "/users?"+tt.query, nil))
+			if tt.wantErr {
+				assert.ErrorIs(t, err, pagination.ErrInvalidSortField)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSort, params.Sort)
+		})
+	}
+}
+
+func TestStrictSortFieldsRandomSort(t *testing.T) {
+	policy := pagination.Policy{StrictSortFields: true, RandomSort: true}
+	params, err := policy.FindParams(httptest.NewRequest("GET", "/users?sort=random.asc&seed=42", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, "random", params.Sort[0].Field)
+}
+
+func TestStrictSortFieldsReportsEveryField(t *testing.T) {
+	policy := pagination.Policy{StrictSortFields: true}
+	_, err := policy.FindParamsStrict(httptest.NewRequest("GET", "/users?order_by="+url.QueryEscape("a-b,name,c d e"), nil))
+	var paramsErr *pagination.ParamsError
+	if assert.ErrorAs(t, err, &paramsErr) {
+		assert.Len(t, paramsErr.Problems, 2)
+	}
+	assert.ErrorIs(t, err, pagination.ErrInvalidSortField)
+	assert.ErrorIs(t, err, pagination.ErrInvalidSortOrder)
+
+	params := pagination.Params{Sort: []pagination.Sort{{Field: "x;y", Order: "asc"}, {Field: "ok", Order: "asc"}, {Field: "1", Order: "asc"}}}
+	err = params.Validate(policy)
+	if assert.ErrorAs(t, err, &paramsErr) {
+		assert.Len(t, paramsErr.Problems, 2)
+	}
+}
+
+func TestSortOrders(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      pagination.Policy
+		target      string
+		wantErr     error
+		wantSort    []pagination.Sort
+		wantOrderBy string
+	}{
+		{
+			name:    "Strict refuses an injected order",
+			policy:  pagination.Policy{StrictSortFields: true, AllowedSorts: []string{"name"}},
+			target:  "/users?sort=name.asc%3BDROP%20TABLE%20users--",
+			wantErr: pagination.ErrInvalidSortOrder,
+		},
+		{
+			name:    "Strict refuses an injected order on the pair format",
+			policy:  pagination.Policy{StrictSortFields: true, AllowedSorts: []string{"name"}, SortFormat: pagination.SortFormatPair},
+			target:  "/users?order_by=" + url.QueryEscape("name asc;DROP TABLE users--"),
+			wantErr: pagination.ErrInvalidSortOrder,
+		},
+		{
+			name:        "Lenient drops an injected order",
+			policy:      pagination.Policy{AllowedSorts: []string{"name", "age"}},
+			target:      "/users?sort=name.asc%3BDROP%20TABLE%20users--,age.desc",
+			wantSort:    []pagination.Sort{{Field: "age", Order: "desc"}},
+			wantOrderBy: "age desc",
+		},
+		{
+			name:        "Orders in any case are written as the constants",
+			policy:      pagination.Policy{StrictSortFields: true},
+			target:      "/users?sort=name.ASC,age.Desc",
+			wantSort:    []pagination.Sort{{Field: "name", Order: "ASC"}, {Field: "age", Order: "Desc"}},
+			wantOrderBy: "name asc,age desc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.policy.FindParams(httptest.NewRequest("GET", tt.target, nil))
+			if tt.wantErr != nil {
+				var orderErr *pagination.SortOrderError
+				assert.ErrorIs(t, err, tt.wantErr)
+				if assert.ErrorAs(t, err, &orderErr) {
+					assert.Equal(t, "name", orderErr.Field)
+				}
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSort, params.Sort)
+			assert.Equal(t, tt.wantOrderBy, params.OrderBy())
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package pagination
+
+import "strings"
+
+// SortCase type defines how the case of the sort sent by the client, like
+// Name.ASC, is handled on the query and on the links
+type SortCase int
+
+const (
+	// SortCasePreserve keeps the case sent by the client on both the query
+	// and the links
+	SortCasePreserve SortCase = iota
+	// SortCaseLowerOrders turns the orders into lower case, like Name.asc for
+	// Name.ASC, the fields keep their case for the SnakeCaseColumns
+	SortCaseLowerOrders
+	// SortCaseLower turns the fields and the orders into lower case, like
+	// name.asc for Name.ASC, for the services with case insensitive fields
+	SortCaseLower
+)
+
+// applySortCase method will change the case of the sort fields and orders
+// following the SortCase of the policy, the AllowedSorts, the aliases and the
+// links then use the new case
+func (p Policy) applySortCase(params *Params) {
+	if p.SortCase == SortCasePreserve {
+		return
+	}
+	for i, s := range params.Sort {
+		params.Sort[i].Order = strings.ToLower(s.Order)
+		if p.SortCase == SortCaseLower {
+			params.Sort[i].Field = strings.ToLower(s.Field)
+		}
+	}
+}
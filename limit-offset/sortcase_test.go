@@ -0,0 +1,58 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicySortCase(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      pagination.Policy
+		url         string
+		wantOrderBy string
+		wantFirst   string
+	}{
+		{
+			name:        "Case preserved",
+			policy:      pagination.Policy{DefaultLimit: 10},
+			url:         "/users?sort=Name.ASC",
+			wantOrderBy: "Name asc",
+			wantFirst:   "/users?page[limit]=10&page[offset]=0&sort=Name.ASC",
+		},
+		{
+			name:        "Lower case orders",
+			policy:      pagination.Policy{DefaultLimit: 10, SortCase: pagination.SortCaseLowerOrders},
+			url:         "/users?sort=Name.ASC,createdAt.Desc",
+			wantOrderBy: "Name asc,createdAt desc",
+			wantFirst:   "/users?page[limit]=10&page[offset]=0&sort=Name.asc,createdAt.desc",
+		},
+		{
+			name:        "Lower case fields and orders",
+			policy:      pagination.Policy{DefaultLimit: 10, SortCase: pagination.SortCaseLower, AllowedSorts: []string{"name"}},
+			url:         "/users?sort=Name.ASC",
+			wantOrderBy: "name asc",
+			wantFirst:   "/users?page[limit]=10&page[offset]=0&sort=name.asc",
+		},
+		{
+			name:        "Prefix format",
+			policy:      pagination.Policy{DefaultLimit: 10, SortCase: pagination.SortCaseLower},
+			url:         "/users?sort=-Name",
+			wantOrderBy: "name desc",
+			wantFirst:   "/users?page[limit]=10&page[offset]=0&sort=-name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.url, nil)
+			params, err := tt.policy.FindParams(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantOrderBy, params.OrderBy())
+			assert.Equal(t, tt.wantFirst, pagination.Paginate(nil, "/users", params).Links.First)
+		})
+	}
+}
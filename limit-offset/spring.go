@@ -0,0 +1,42 @@
+package pagination
+
+// SpringPage type encapsulates a paginated response with the shape of the
+// Spring Data Page, for the clients that were built against Java services
+type SpringPage struct {
+	Content          []interface{} `json:"content"`
+	TotalElements    uint          `json:"totalElements"`
+	TotalPages       uint          `json:"totalPages"`
+	Number           uint          `json:"number"`
+	Size             uint          `json:"size"`
+	NumberOfElements int           `json:"numberOfElements"`
+	First            bool          `json:"first"`
+	Last             bool          `json:"last"`
+	Empty            bool          `json:"empty"`
+}
+
+// PaginateSpring will build a new paginated response with the Spring Data Page
+// shape, the total is the number of items of the whole query, which can be
+// found with CachedCount. Like Spring the page number starts on 0 and a zero
+// size means a single page
+func PaginateSpring(data []interface{}, params Params, total uint) SpringPage {
+	content := buildData(data, params)
+	if content == nil {
+		content = []interface{}{}
+	}
+	totalPages := uint(1)
+	if params.Limit > 0 {
+		totalPages = (total + params.Limit - 1) / params.Limit
+	}
+	number := pageNumber(params) - 1
+	return SpringPage{
+		Content:          content,
+		TotalElements:    total,
+		TotalPages:       totalPages,
+		Number:           number,
+		Size:             params.Limit,
+		NumberOfElements: len(content),
+		First:            number == 0,
+		Last:             number+1 >= totalPages,
+		Empty:            len(content) == 0,
+	}
+}
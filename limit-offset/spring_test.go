@@ -0,0 +1,83 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginateSpring(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []interface{}
+		params pagination.Params
+		total  uint
+		want   pagination.SpringPage
+	}{
+		{
+			name:   "First page",
+			data:   []interface{}{1, 2, 3},
+			params: pagination.Params{Limit: 2},
+			total:  5,
+			want: pagination.SpringPage{
+				Content:          []interface{}{1, 2},
+				TotalElements:    5,
+				TotalPages:       3,
+				Number:           0,
+				Size:             2,
+				NumberOfElements: 2,
+				First:            true,
+			},
+		},
+		{
+			name:   "Last page",
+			data:   []interface{}{5},
+			params: pagination.Params{Limit: 2, Offset: 4},
+			total:  5,
+			want: pagination.SpringPage{
+				Content:          []interface{}{5},
+				TotalElements:    5,
+				TotalPages:       3,
+				Number:           2,
+				Size:             2,
+				NumberOfElements: 1,
+				Last:             true,
+			},
+		},
+		{
+			name:   "Empty result",
+			params: pagination.Params{Limit: 10},
+			want: pagination.SpringPage{
+				Content: []interface{}{},
+				Size:    10,
+				First:   true,
+				Last:    true,
+				Empty:   true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pagination.PaginateSpring(tt.data, tt.params, tt.total))
+		})
+	}
+}
+
+func TestPaginateSpringJSON(t *testing.T) {
+	body, err := json.Marshal(pagination.PaginateSpring([]interface{}{"a"}, pagination.Params{Limit: 10}, 1))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"content": ["a"],
+		"totalElements": 1,
+		"totalPages": 1,
+		"number": 0,
+		"size": 10,
+		"numberOfElements": 1,
+		"first": true,
+		"last": true,
+		"empty": false
+	}`, string(body))
+}
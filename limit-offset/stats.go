@@ -0,0 +1,140 @@
+package pagination
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// UsageStats type is a MetricsHook aggregating in-process the combinations of
+// limit, page depth and sort fields requested by the clients, so the real
+// pagination patterns can be seen without a metrics backend. It is safe for
+// concurrent use and it can be mounted as an http.Handler serving the
+// snapshot as JSON
+type UsageStats struct {
+	NopMetricsHook
+
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[usageKey]uint64
+	other      uint64
+}
+
+// usageKey type is the combination counted by the UsageStats
+type usageKey struct {
+	limit uint
+	depth string
+	sort  string
+}
+
+// UsageEntry type encapsulates how many times a combination was requested
+type UsageEntry struct {
+	Limit uint `json:"limit"`
+	// Depth is the bucket of page depths, for example 2-10
+	Depth string `json:"depth"`
+	// Sort are the sort fields joined with commas
+	Sort  string `json:"sort"`
+	Count uint64 `json:"count"`
+}
+
+// UsageSnapshot type encapsulates the combinations counted by the UsageStats,
+// sorted by count. Other counts the requests that didn't fit on the maximum
+// number of combinations
+type UsageSnapshot struct {
+	Entries []UsageEntry `json:"entries"`
+	Other   uint64       `json:"other"`
+}
+
+// NewUsageStats will build a new aggregator keeping up to the given number of
+// combinations, the sort fields come from the clients so the number has to be
+// bounded, the requests for new combinations beyond it are counted as other
+func NewUsageStats(maxEntries int) *UsageStats {
+	return &UsageStats{
+		maxEntries: maxEntries,
+		entries:    make(map[usageKey]uint64),
+	}
+}
+
+// OnParse will count the combination of the valid requests
+func (s *UsageStats) OnParse(event ParseEvent) {
+	if event.Err != nil {
+		return
+	}
+	key := usageKey{
+		limit: event.Limit,
+		depth: depthBucket(event.Depth),
+		sort:  strings.Join(event.Sort, ","),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; !ok && len(s.entries) >= s.maxEntries {
+		s.other++
+		return
+	}
+	s.entries[key]++
+}
+
+// Snapshot will answer back the combinations counted so far
+func (s *UsageStats) Snapshot() UsageSnapshot {
+	s.mu.Lock()
+	snapshot := UsageSnapshot{
+		Entries: make([]UsageEntry, 0, len(s.entries)),
+		Other:   s.other,
+	}
+	for key, count := range s.entries {
+		snapshot.Entries = append(snapshot.Entries, UsageEntry{
+			Limit: key.limit,
+			Depth: key.depth,
+			Sort:  key.sort,
+			Count: count,
+		})
+	}
+	s.mu.Unlock()
+
+	sort.Slice(snapshot.Entries, func(i, j int) bool {
+		a, b := snapshot.Entries[i], snapshot.Entries[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Limit != b.Limit {
+			return a.Limit < b.Limit
+		}
+		if a.Depth != b.Depth {
+			return a.Depth < b.Depth
+		}
+		return a.Sort < b.Sort
+	})
+	return snapshot
+}
+
+// Reset will forget all the combinations counted so far
+func (s *UsageStats) Reset() {
+	s.mu.Lock()
+	s.entries = make(map[usageKey]uint64)
+	s.other = 0
+	s.mu.Unlock()
+}
+
+// WriteJSON will write the snapshot as JSON into the given writer
+func (s *UsageStats) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(s.Snapshot())
+}
+
+// ServeHTTP will answer back the snapshot as JSON
+func (s *UsageStats) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
+	wr.Header().Set("Content-Type", "application/json")
+	s.WriteJSON(wr)
+}
+
+// depthBucket function will return the name of the bucket for the given depth
+func depthBucket(depth uint) string {
+	for _, b := range depthBuckets {
+		if depth <= b.max {
+			return b.name
+		}
+	}
+	return "deeper"
+}
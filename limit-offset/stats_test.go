@@ -0,0 +1,50 @@
+package pagination_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsageStats(t *testing.T) {
+	stats := pagination.NewUsageStats(2)
+	policy := pagination.Policy{DefaultLimit: 10, Metrics: stats}
+
+	for _, url := range []string{
+		"app.quicka.co/api/sample",
+		"app.quicka.co/api/sample?page[offset]=10",
+		"app.quicka.co/api/sample?page[offset]=50&sort=name.asc",
+		"app.quicka.co/api/sample?page[limit]=100&sort=created_at.desc",
+		"app.quicka.co/api/sample?page[offset]=wrong",
+	} {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		assert.Nil(t, err)
+		policy.FindParams(req)
+	}
+
+	snapshot := stats.Snapshot()
+	assert.Equal(t, pagination.UsageSnapshot{
+		Entries: []pagination.UsageEntry{
+			{Limit: 10, Depth: "1", Count: 1},
+			{Limit: 10, Depth: "2-10", Count: 1},
+		},
+		Other: 2,
+	}, snapshot)
+
+	wr := httptest.NewRecorder()
+	stats.ServeHTTP(wr, httptest.NewRequest(http.MethodGet, "/debug/pagination", nil))
+	assert.Equal(t, "application/json", wr.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{
+		"entries": [
+			{"limit": 10, "depth": "1", "sort": "", "count": 1},
+			{"limit": 10, "depth": "2-10", "sort": "", "count": 1}
+		],
+		"other": 2
+	}`, wr.Body.String())
+
+	stats.Reset()
+	assert.Equal(t, pagination.UsageSnapshot{Entries: []pagination.UsageEntry{}}, stats.Snapshot())
+}
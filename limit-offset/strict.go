@@ -0,0 +1,201 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MustFindParams works like FindParams but panics when the params can't be
+// found, it is meant for tests and internal tools where the request is known
+func MustFindParams(req *http.Request, defaultOffset, defaultLimit uint) Params {
+	params, err := FindParams(req, defaultOffset, defaultLimit)
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// MustFindParams method works like FindParams but panics when the params can't
+// be found, it is meant for tests and internal tools where the request is known
+func (p Policy) MustFindParams(req *http.Request) Params {
+	params, err := p.FindParams(req)
+	if err != nil {
+		panic(err)
+	}
+	return params
+}
+
+// FindParamsStrict method works like FindParams but instead of failing on the
+// first problem all of them are reported, like a wrong limit and every filter
+// that doesn't follow the schema, so the clients can fix the request at once.
+// The error returned is a *ParamsError, errors.Is still matches each problem
+// and the error FindParams failed with
+func (p Policy) FindParamsStrict(req *http.Request) (Params, error) {
+	params, err := p.FindParams(req)
+	if err == nil {
+		return params, nil
+	}
+	if errors.Is(err, ErrInvalidToken) {
+		return params, &ParamsError{Problems: []error{err}}
+	}
+	rawQuery, _ := p.rawQuery(req)
+	problems := p.problems(rawQuery)
+	for i, problem := range problems {
+		problems[i] = redactError(p.Redactions, problem, req.URL.RawQuery, params.Filters)
+	}
+	if !reported(problems, err) {
+		problems = append(problems, err)
+	}
+	return params, &ParamsError{Problems: problems}
+}
+
+// pageProblems method will check the page found on the given raw query against
+// the OffsetAlignment and the MaxResultWindow
+func (p Policy) pageProblems(rawQuery string) (problems []error) {
+	params := Params{Limit: p.DefaultLimit, Offset: p.DefaultOffset}
+	if err := findParams(rawQuery, p.names.orDefault(), p.SortFormat, &params); err != nil {
+		return nil
+	}
+	if p.MaxLimit > 0 && params.Limit > p.MaxLimit {
+		params.Limit = p.MaxLimit
+	}
+	if p.OffsetAlignment != OffsetAlignmentAny && params.Limit > 0 && params.Offset%params.Limit != 0 {
+		if p.OffsetAlignment == OffsetAlignmentReject {
+			problems = append(problems, unalignedOffset(params))
+		}
+		params.Offset = params.Offset / params.Limit * params.Limit
+	}
+	if err := p.checkResultWindow(params); err != nil {
+		problems = append(problems, err)
+	}
+	return problems
+}
+
+// reported function will check if the given error is already one of the
+// problems, even wrapped with the name of the param. Some rules, like the
+// stable sort, are only checked by FindParams
+func reported(problems []error, err error) bool {
+	for _, problem := range problems {
+		if errors.Is(problem, err) || strings.Contains(problem.Error(), err.Error()) {
+			return true
+		}
+	}
+	return false
+}
+
+// problems method will validate every pagination param and filter of the
+// given raw query, answering back all the problems found
+func (p Policy) problems(rawQuery string) (problems []error) {
+	names := p.names.orDefault()
+	raw := lookupParams(rawQuery, names)
+	type param struct {
+		name, value string
+	}
+	numbers := []param{{names.limit, raw.limit}}
+	if p.OpaqueOffsets {
+		if _, err := CursorOffset(p.OffsetTokens, raw.offset); raw.offset != "" && err != nil {
+			problems = append(problems, err)
+		}
+	} else {
+		numbers = append(numbers, param{names.offset, raw.offset})
+	}
+	// The page number and size are ignored when the limit or offset are used
+	if raw.limit == "" && raw.offset == "" {
+		numbers = append(numbers, param{names.number, raw.number}, param{names.size, raw.size})
+	}
+	for _, number := range numbers {
+		if number.value == "" {
+			continue
+		}
+		value, err := parseNumber(number.name, number.value)
+		if err == nil && number.name == names.number && value == 0 {
+			err = errPageNumberZero
+		}
+		var numberErr *NumberError
+		if errors.As(err, &numberErr) {
+			problems = append(problems, err)
+		} else if err != nil {
+			problems = append(problems, fmt.Errorf("pagination: invalid %s=%q: %w", number.name, number.value, err))
+		}
+	}
+	if len(problems) == 0 {
+		problems = append(problems, p.pageProblems(rawQuery)...)
+	}
+
+	if p.StrictSortFields {
+		var sort []Sort
+		if p.SortFormat == SortFormatPair || p.SortFormat == SortFormatAuto && raw.sort == "" {
+			sort, _ = appendOrderBy(nil, raw.orderBy)
+		} else {
+			sort, _ = appendSort(nil, raw.sort, p.SortFormat)
+		}
+		problems = append(problems, strictSortFields(sort)...)
+	}
+
+	filters := findFilters(rawQuery)
+	if p.FilterFormat == FilterFormatRSQL {
+		var err error
+		if filters, err = ParseRSQL(lookupRSQL(rawQuery)); err != nil {
+			return append(problems, err)
+		}
+	}
+	if p.FilterSchema != nil {
+		for i := range filters {
+			if err := p.FilterSchema.coerce(&filters[i]); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+	return problems
+}
+
+// Validate method will check the params against the rules of the given policy
+// reporting all the violations found, useful when the params are built by
+// hand instead of found on a request. The rules checked are the MaxLimit, the
+// StrictSortFields, the AllowedSorts, the MaxOffsetDepth, the OffsetAlignment,
+// the MaxResultWindow, the stable sort and the FilterSchema. When there are
+// violations the error is a *ParamsError, each of them matching
+// ErrPolicyViolation, or ErrInvalidSortField, ErrUnalignedOffset,
+// ErrResultWindowExceeded, ErrUnstableSort and ErrInvalidFilter for those
+// rules
+func (p Params) Validate(policy Policy) error {
+	var problems []error
+	if policy.MaxLimit > 0 && p.Limit > policy.MaxLimit {
+		problems = append(problems, fmt.Errorf("%w: the limit %d is bigger than the maximum %d", ErrPolicyViolation, p.Limit, policy.MaxLimit))
+	}
+	if policy.StrictSortFields {
+		problems = append(problems, strictSortFields(p.Sort)...)
+	}
+	if len(policy.AllowedSorts) > 0 {
+		for _, s := range p.Sort {
+			if !policy.sortAllowed(s.Field) {
+				problems = append(problems, fmt.Errorf("%w: the sort field %q is not allowed", ErrPolicyViolation, s.Field))
+			}
+		}
+	}
+	if policy.MaxOffsetDepth > 0 && p.Offset > policy.MaxOffsetDepth {
+		problems = append(problems, fmt.Errorf("%w: the offset %d is deeper than the maximum %d", ErrPolicyViolation, p.Offset, policy.MaxOffsetDepth))
+	}
+	if policy.OffsetAlignment == OffsetAlignmentReject && p.Limit > 0 && p.Offset%p.Limit != 0 {
+		problems = append(problems, unalignedOffset(p))
+	}
+	if err := policy.checkResultWindow(p); err != nil {
+		problems = append(problems, err)
+	}
+	if policy.RequireStableSort && p.Offset > policy.MaxUnstableOffset && !policy.StableSort(p.Sort) {
+		problems = append(problems, &UnstableSortError{Offset: p.Offset, MaxOffset: policy.MaxUnstableOffset})
+	}
+	if policy.FilterSchema != nil {
+		for _, filter := range p.Filters {
+			if err := policy.FilterSchema.coerce(&filter); err != nil {
+				problems = append(problems, err)
+			}
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ParamsError{Problems: problems}
+}
@@ -0,0 +1,180 @@
+package pagination_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMustFindParams(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[limit]=5", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(5), pagination.MustFindParams(req, 0, 10).Limit)
+	assert.Equal(t, uint(5), pagination.Policy{DefaultLimit: 10}.MustFindParams(req).Limit)
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[limit]=many", nil)
+	assert.Nil(t, err)
+	assert.Panics(t, func() { pagination.MustFindParams(req, 0, 10) })
+	assert.Panics(t, func() { pagination.Policy{DefaultLimit: 10}.MustFindParams(req) })
+}
+
+func TestFindParamsStrict(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		FilterSchema: pagination.FilterSchema{
+			"age":    {Type: pagination.FilterInt},
+			"status": {Type: pagination.FilterString},
+		},
+		RequireStableSort: true,
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		wantProblems int
+		wantIs       []error
+	}{
+		{
+			name: "Valid request",
+			url:  "app.quicka.co/api/sample?page[limit]=5&filter[age]=30",
+		},
+		{
+			name:         "Every problem reported",
+			url:          "app.quicka.co/api/sample?page[limit]=many&page[offset]=-1&filter[age]=old&filter[password]=secret&filter[status]=active",
+			wantProblems: 4,
+			wantIs:       []error{pagination.ErrInvalidFilter, pagination.ErrNegativeValue},
+		},
+		{
+			name:         "Page number zero",
+			url:          "app.quicka.co/api/sample?page[number]=0",
+			wantProblems: 1,
+		},
+		{
+			name:         "Problems found after the params",
+			url:          "app.quicka.co/api/sample?page[offset]=500",
+			wantProblems: 1,
+			wantIs:       []error{pagination.ErrUnstableSort},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			_, err = policy.FindParamsStrict(req)
+			if tt.wantProblems == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			var paramsErr *pagination.ParamsError
+			assert.True(t, errors.As(err, &paramsErr))
+			assert.Len(t, paramsErr.Problems, tt.wantProblems)
+			for _, target := range tt.wantIs {
+				assert.True(t, errors.Is(err, target))
+			}
+		})
+	}
+}
+
+func TestFindParamsStrictKeepsTheError(t *testing.T) {
+	schema := pagination.FilterSchema{"age": {Type: pagination.FilterInt}}
+	tests := []struct {
+		name   string
+		policy pagination.Policy
+		target string
+		wantIs []error
+	}{
+		{
+			name:   "Unaligned offset with a bad filter",
+			policy: pagination.Policy{DefaultLimit: 10, OffsetAlignment: pagination.OffsetAlignmentReject, FilterSchema: schema},
+			target: "/users?page[offset]=5&filter[age]=old",
+			wantIs: []error{pagination.ErrUnalignedOffset, pagination.ErrInvalidFilter},
+		},
+		{
+			name:   "Result window exceeded with a bad filter",
+			policy: pagination.Policy{DefaultLimit: 10, MaxResultWindow: 100, FilterSchema: schema},
+			target: "/users?page[offset]=200&filter[age]=old",
+			wantIs: []error{pagination.ErrResultWindowExceeded, pagination.ErrInvalidFilter},
+		},
+		{
+			name:   "Result window exceeded",
+			policy: pagination.Policy{DefaultLimit: 10, MaxResultWindow: 100},
+			target: "/users?page[offset]=200",
+			wantIs: []error{pagination.ErrResultWindowExceeded},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.policy.FindParamsStrict(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			var paramsErr *pagination.ParamsError
+			if assert.ErrorAs(t, err, &paramsErr) {
+				assert.Len(t, paramsErr.Problems, len(tt.wantIs))
+			}
+			for _, target := range tt.wantIs {
+				assert.ErrorIs(t, err, target)
+			}
+		})
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	policy := pagination.Policy{
+		MaxLimit:          100,
+		AllowedSorts:      []string{"name", "created_at"},
+		MaxOffsetDepth:    1000,
+		RequireStableSort: true,
+		MaxUnstableOffset: 500,
+		UniqueFields:      []string{"id"},
+		FilterSchema:      pagination.FilterSchema{"age": {Type: pagination.FilterInt}},
+	}
+
+	tests := []struct {
+		name         string
+		params       pagination.Params
+		wantProblems int
+		wantIs       []error
+	}{
+		{
+			name: "Valid params",
+			params: pagination.Params{
+				Limit:   20,
+				Offset:  40,
+				Sort:    []pagination.Sort{{Field: "name", Order: "asc"}},
+				Filters: pagination.Filters{{Field: "age", Operator: pagination.OpGt, Value: "30"}},
+			},
+		},
+		{
+			name: "Every violation reported",
+			params: pagination.Params{
+				Limit:   500,
+				Offset:  2000,
+				Sort:    []pagination.Sort{{Field: "password", Order: "asc"}, {Field: "email", Order: "desc"}},
+				Filters: pagination.Filters{{Field: "age", Operator: pagination.OpEq, Value: "old"}},
+			},
+			wantProblems: 6,
+			wantIs:       []error{pagination.ErrPolicyViolation, pagination.ErrUnstableSort, pagination.ErrInvalidFilter},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate(policy)
+			if tt.wantProblems == 0 {
+				assert.Nil(t, err)
+				return
+			}
+			var paramsErr *pagination.ParamsError
+			assert.True(t, errors.As(err, &paramsErr))
+			assert.Len(t, paramsErr.Problems, tt.wantProblems)
+			for _, target := range tt.wantIs {
+				assert.True(t, errors.Is(err, target))
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+const (
+	// StripeParamLimit is the page limit param used by the Stripe preset
+	StripeParamLimit = "limit"
+	// StripeParamStartingAfter is the param with the ID of the item after
+	// which the page starts, used for moving forward
+	StripeParamStartingAfter = "starting_after"
+	// StripeParamEndingBefore is the param with the ID of the item before
+	// which the page ends, used for moving backward
+	StripeParamEndingBefore = "ending_before"
+)
+
+// errConflictingCursors is the error returned when both starting_after and
+// ending_before are found on the request, like Stripe we refuse it
+var errConflictingCursors = errors.New("pagination: starting_after and ending_before can't be used together")
+
+// StripeParams type encapsulates the params found on a request using the
+// Stripe conventions, the page is located by the ID of the item next to it
+// instead of an offset, see Keyset
+type StripeParams struct {
+	Params
+	// StartingAfter is the ID of the last item of the previous page
+	StartingAfter string
+	// EndingBefore is the ID of the first item of the next page
+	EndingBefore string
+}
+
+// StripeList type encapsulates a paginated response with the shape of the
+// Stripe list objects
+type StripeList struct {
+	Object  string        `json:"object"`
+	Data    []interface{} `json:"data"`
+	HasMore bool          `json:"has_more"`
+	URL     string        `json:"url"`
+}
+
+// FindStripeParams will find the params on the request using the Stripe
+// conventions, limit, starting_after and ending_before, applying the rules of
+// the given policy. The offset is always 0, the page is located by the keyset
+func FindStripeParams(req *http.Request, policy Policy) (StripeParams, error) {
+	var stripe StripeParams
+	policy = policy.preset()
+	rawQuery, err := policy.applyDuplicateParams(req.URL.RawQuery, StripeParamLimit, StripeParamStartingAfter, StripeParamEndingBefore)
+	if err == nil {
+		err = policy.findParamsInto(req, aliasQuery(rawQuery, ParamPageLimit, StripeParamLimit), &stripe.Params)
+	}
+	stripe.Offset = 0
+	if err == nil {
+		stripe.StartingAfter = lookupParam(rawQuery, StripeParamStartingAfter)
+		stripe.EndingBefore = lookupParam(rawQuery, StripeParamEndingBefore)
+		if stripe.StartingAfter != "" && stripe.EndingBefore != "" {
+			err = errConflictingCursors
+		}
+	}
+	return stripe, policy.found(req, &stripe.Params, err)
+}
+
+// Keyset method will build the SQL condition and the ORDER BY locating the
+// page on the given column, which must be unique, like the ID. The condition
+// is empty on the first page and the ID is answered back as the only arg,
+// the limit of the query is still the one of the Query method
+//
+//	where, orderBy, args := stripe.Keyset("id", 1)
+//
+// When moving backward the items are sorted in descending order, Paginate
+// puts them back in ascending order
+func (s StripeParams) Keyset(column string, firstPlaceholder int) (where, orderBy string, args []interface{}) {
+	placeholder := s.Dialect.Placeholder(firstPlaceholder)
+	switch {
+	case s.StartingAfter != "":
+		return column + " > " + placeholder, " ORDER BY " + column + " ASC", []interface{}{s.StartingAfter}
+	case s.EndingBefore != "":
+		return column + " < " + placeholder, " ORDER BY " + column + " DESC", []interface{}{s.EndingBefore}
+	default:
+		return "", " ORDER BY " + column + " ASC", nil
+	}
+}
+
+// Paginate method will build the Stripe list for the given data, fetched
+// with the order of Keyset and one more item than the limit, so has_more can
+// be known. The url is the path of the list, like /v1/customers
+func (s StripeParams) Paginate(data []interface{}, url string) StripeList {
+	s.pageServed(len(data))
+	list := StripeList{
+		Object:  "list",
+		Data:    append([]interface{}{}, buildData(data, s.Params)...),
+		HasMore: uint(len(data)) > s.Limit,
+		URL:     url,
+	}
+	if s.EndingBefore != "" {
+		for i, j := 0, len(list.Data)-1; i < j; i, j = i+1, j-1 {
+			list.Data[i], list.Data[j] = list.Data[j], list.Data[i]
+		}
+	}
+	s.paginated(context.Background(), Response{Data: list.Data})
+	return list
+}
@@ -0,0 +1,123 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindStripeParams(t *testing.T) {
+	policy := pagination.Policy{DefaultLimit: 10, MaxLimit: 100}
+
+	tests := []struct {
+		name              string
+		url               string
+		wantLimit         uint
+		wantStartingAfter string
+		wantEndingBefore  string
+		wantErr           bool
+	}{
+		{
+			name:      "Only defaults",
+			url:       "app.quicka.co/api/sample",
+			wantLimit: 10,
+		},
+		{
+			name:              "Starting after",
+			url:               "app.quicka.co/api/sample?limit=3&starting_after=cus_123",
+			wantLimit:         3,
+			wantStartingAfter: "cus_123",
+		},
+		{
+			name:             "Ending before",
+			url:              "app.quicka.co/api/sample?ending_before=cus_123",
+			wantLimit:        10,
+			wantEndingBefore: "cus_123",
+		},
+		{
+			name:      "Limit clamped by the policy",
+			url:       "app.quicka.co/api/sample?limit=500",
+			wantLimit: 100,
+		},
+		{
+			name:    "Both cursors",
+			url:     "app.quicka.co/api/sample?starting_after=cus_1&ending_before=cus_2",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tt.url, nil)
+			assert.Nil(t, err)
+
+			stripe, err := pagination.FindStripeParams(req, policy)
+			if tt.wantErr {
+				assert.NotNil(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantLimit, stripe.Limit)
+			assert.Equal(t, uint(0), stripe.Offset)
+			assert.Equal(t, tt.wantStartingAfter, stripe.StartingAfter)
+			assert.Equal(t, tt.wantEndingBefore, stripe.EndingBefore)
+		})
+	}
+}
+
+func TestStripeKeyset(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      pagination.StripeParams
+		wantWhere   string
+		wantOrderBy string
+		wantArgs    []interface{}
+	}{
+		{
+			name:        "First page",
+			wantOrderBy: " ORDER BY id ASC",
+		},
+		{
+			name:        "Starting after on PostgreSQL",
+			params:      pagination.StripeParams{Params: pagination.Params{Dialect: pagination.DialectPostgres}, StartingAfter: "cus_1"},
+			wantWhere:   "id > $2",
+			wantOrderBy: " ORDER BY id ASC",
+			wantArgs:    []interface{}{"cus_1"},
+		},
+		{
+			name:        "Ending before",
+			params:      pagination.StripeParams{EndingBefore: "cus_9"},
+			wantWhere:   "id < ?",
+			wantOrderBy: " ORDER BY id DESC",
+			wantArgs:    []interface{}{"cus_9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, orderBy, args := tt.params.Keyset("id", 2)
+			assert.Equal(t, tt.wantWhere, where)
+			assert.Equal(t, tt.wantOrderBy, orderBy)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestStripePaginate(t *testing.T) {
+	stripe := pagination.StripeParams{Params: pagination.Params{Limit: 2}}
+	body, err := json.Marshal(stripe.Paginate([]interface{}{"a", "b", "c"}, "/v1/customers"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"object": "list", "data": ["a", "b"], "has_more": true, "url": "/v1/customers"}`, string(body))
+
+	stripe.EndingBefore = "d"
+	list := stripe.Paginate([]interface{}{"c", "b"}, "/v1/customers")
+	assert.Equal(t, []interface{}{"b", "c"}, list.Data)
+	assert.False(t, list.HasMore)
+
+	body, err = json.Marshal(stripe.Paginate(nil, "/v1/customers"))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"object": "list", "data": [], "has_more": false, "url": "/v1/customers"}`, string(body))
+}
@@ -0,0 +1,27 @@
+package pagination
+
+// PageQuery type documents the pagination query params for swaggo/swag, it is
+// not used for parsing the requests, use it on the annotations of the
+// endpoints instead of writing every param by hand
+//
+//	// @Param   pagination query    pagination.PageQuery false "Pagination"
+//	// @Success 200        {object} pagination.Response{data=[]User}
+type PageQuery struct {
+	// Maximum number of items of the page
+	Limit uint `form:"page[limit]" json:"page[limit]" example:"10" validate:"omitempty,min=0"`
+	// Number of items skipped before the page
+	Offset uint `form:"page[offset]" json:"page[offset]" example:"0" validate:"omitempty,min=0"`
+	// Page number, starting on 1, used instead of the offset
+	Number uint `form:"page[number]" json:"page[number]" example:"1" validate:"omitempty,min=1"`
+	// Page size used together with the page number
+	Size uint `form:"page[size]" json:"page[size]" example:"10" validate:"omitempty,min=0"`
+	// Comma separated sort fields
+	Sort string `form:"sort" json:"sort" example:"name.asc,created_at.desc"`
+}
+
+// SearchQuery type documents the free-text search param of the ListParams for
+// swaggo/swag, see PageQuery
+type SearchQuery struct {
+	// Free-text search term
+	Search string `form:"q" json:"q" example:"john"`
+}
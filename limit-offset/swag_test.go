@@ -0,0 +1,30 @@
+package pagination_test
+
+import (
+	"reflect"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageQueryParamNames(t *testing.T) {
+	names := func(v interface{}) []string {
+		var names []string
+		typ := reflect.TypeOf(v)
+		for i := 0; i < typ.NumField(); i++ {
+			names = append(names, typ.Field(i).Tag.Get("form"))
+		}
+		return names
+	}
+
+	// The documented params have to match the ones parsed
+	assert.Equal(t, []string{
+		pagination.ParamPageLimit,
+		pagination.ParamPageOffset,
+		pagination.ParamPageNumber,
+		pagination.ParamPageSize,
+		pagination.ParamSortBy,
+	}, names(pagination.PageQuery{}))
+	assert.Equal(t, []string{pagination.ParamSearch}, names(pagination.SearchQuery{}))
+}
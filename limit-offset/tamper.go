@@ -0,0 +1,72 @@
+package pagination
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"time"
+)
+
+// TamperReport type encapsulates the information about a page token refused
+// for having a wrong signature, meant for the security pipelines acting on
+// the clients repeating the tampering attempts
+type TamperReport struct {
+	// KeyID is the id of the key the signature was checked with, see
+	// TokenCodec.KeyID
+	KeyID string
+	// ClientIP is the IP of the client, see WithClientIP
+	ClientIP string
+	// Path is the path of the request
+	Path string
+	// CorrelationID is the correlation ID the token claims
+	CorrelationID string
+	// IssuedAt is when the token claims it was issued, zero when it couldn't
+	// be read
+	IssuedAt time.Time
+	// Age is the age of the token by its claimed issue time
+	Age time.Duration
+}
+
+// clientIPKey is the context key of the client IP
+type clientIPKey struct{}
+
+// WithClientIP will answer back a context carrying the given IP of the
+// client, usually the one found by the middleware trusting the proxies, like
+// the X-Forwarded-For one. It is reported on the TamperReport
+func WithClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// ClientIP will answer back the client IP of the given context, empty when
+// there is none
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// reportTampering method will call the OnTamperedToken callback for the
+// given refused token when it was tampered, the signature was checked with
+// the given codec. The client IP falls back to the remote address of the
+// request when the context doesn't carry it
+func (p Policy) reportTampering(req *http.Request, tokens *TokenCodec, tokenErr *TokenError) {
+	if p.OnTamperedToken == nil || tokens == nil || tokenErr.Failure != TokenTampered {
+		return
+	}
+	report := TamperReport{
+		KeyID:         tokens.KeyID,
+		ClientIP:      ClientIP(req.Context()),
+		Path:          req.URL.Path,
+		CorrelationID: tokenErr.CorrelationID,
+		IssuedAt:      tokenErr.IssuedAt,
+	}
+	if report.ClientIP == "" {
+		report.ClientIP = req.RemoteAddr
+		if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+			report.ClientIP = host
+		}
+	}
+	if !report.IssuedAt.IsZero() {
+		report.Age = tokens.now().Sub(report.IssuedAt)
+	}
+	p.OnTamperedToken(req.Context(), report)
+}
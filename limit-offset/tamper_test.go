@@ -0,0 +1,150 @@
+package pagination_test
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnTamperedToken(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	codec.KeyID = "2024-10"
+	codec.CorrelationIDs = true
+	var reports []pagination.TamperReport
+	policy := pagination.Policy{
+		Tokens: codec,
+		OnTamperedToken: func(ctx context.Context, report pagination.TamperReport) {
+			reports = append(reports, report)
+		},
+	}
+	forger := pagination.NewTokenCodec([]byte("another key of 32 bytes for test"))
+	forger.CorrelationIDs = true
+	forged := forger.Encode(pagination.PageToken{Query: "page[offset]=10", CorrelationID: "req-7", IssuedAt: time.Now().Add(-10 * time.Minute)})
+	// A forged token claiming another version is still a tampered one
+	raw, err := base64.RawURLEncoding.DecodeString(forged)
+	assert.NoError(t, err)
+	raw[0] = 2
+	forgedVersion := base64.RawURLEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		value        string
+		wantClientIP string
+		wantReported bool
+	}{
+		{
+			name:         "Tampered token with the IP on the context",
+			ctx:          pagination.WithClientIP(context.Background(), "203.0.113.9"),
+			value:        forged,
+			wantClientIP: "203.0.113.9",
+			wantReported: true,
+		},
+		{
+			name:         "Tampered token with the remote address",
+			ctx:          context.Background(),
+			value:        forged,
+			wantClientIP: "192.0.2.1",
+			wantReported: true,
+		},
+		{
+			name:         "Tampered token claiming another version",
+			ctx:          context.Background(),
+			value:        forgedVersion,
+			wantClientIP: "192.0.2.1",
+			wantReported: true,
+		},
+		{
+			name:  "Malformed token isn't reported",
+			ctx:   context.Background(),
+			value: "garbage",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports = nil
+			req := httptest.NewRequest("GET", "/users?page[token]="+tt.value, nil).WithContext(tt.ctx)
+			_, err := policy.FindParams(req)
+			assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+			if !tt.wantReported {
+				assert.Empty(t, reports)
+				return
+			}
+			if assert.Len(t, reports, 1) {
+				report := reports[0]
+				assert.Equal(t, "2024-10", report.KeyID)
+				assert.Equal(t, tt.wantClientIP, report.ClientIP)
+				assert.Equal(t, "/users", report.Path)
+				assert.Equal(t, "req-7", report.CorrelationID)
+				assert.InDelta(t, float64(10*time.Minute), float64(report.Age), float64(2*time.Second))
+			}
+		})
+	}
+}
+
+func TestOnTamperedOffset(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	codec.KeyID = "offsets"
+	var reports []pagination.TamperReport
+	policy := pagination.Policy{
+		OpaqueOffsets: true,
+		OffsetTokens:  codec,
+		OnTamperedToken: func(ctx context.Context, report pagination.TamperReport) {
+			reports = append(reports, report)
+		},
+	}
+	forger := pagination.NewTokenCodec([]byte("another key of 32 bytes for test"))
+	// A forged offset whose payload can't be read claims no issue time
+	raw, err := base64.RawURLEncoding.DecodeString(pagination.OffsetCursor(forger, 10))
+	assert.NoError(t, err)
+	raw[1] = 'x'
+	unreadable := base64.RawURLEncoding.EncodeToString(raw)
+
+	tests := []struct {
+		name         string
+		offset       string
+		wantReported bool
+		wantAged     bool
+	}{
+		{
+			name:         "Signed with another key",
+			offset:       pagination.OffsetCursor(forger, 10),
+			wantReported: true,
+			wantAged:     true,
+		},
+		{
+			name:         "Signed with another key without a readable payload",
+			offset:       unreadable,
+			wantReported: true,
+		},
+		{
+			name:   "Plain integer isn't reported",
+			offset: "10",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports = nil
+			_, err := policy.FindParams(httptest.NewRequest("GET", "/users?page[offset]="+url.QueryEscape(tt.offset), nil))
+			assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+			if !tt.wantReported {
+				assert.Empty(t, reports)
+				return
+			}
+			if assert.Len(t, reports, 1) {
+				assert.Equal(t, "offsets", reports[0].KeyID)
+				assert.Equal(t, "/users", reports[0].Path)
+				assert.Equal(t, tt.wantAged, !reports[0].IssuedAt.IsZero())
+				assert.Less(t, reports[0].Age, time.Minute)
+			}
+		})
+	}
+}
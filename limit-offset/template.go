@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// LinkTemplate type keeps precompiled the static parts of the links for a
+// route, so when building the links of each request only the offset has to be
+// written. It is safe for concurrent use once created
+type LinkTemplate struct {
+	baseURL    string
+	limit      uint
+	sortValue  string
+	sortFormat SortFormat
+	seed       string
+	filters    string
+	names      paramNames
+	// prefix holds everything until the offset value, for example
+	// /users?page[limit]=10&page[offset]=
+	prefix string
+	// suffix holds everything after the offset value, for example
+	// &sort=name.asc
+	suffix string
+}
+
+// NewLinkTemplate will compile a link template for the given base url using the
+// limit and sort of the given params, register it once per route and reuse it
+// for every request
+func NewLinkTemplate(baseURL string, params Params) *LinkTemplate {
+	t := &LinkTemplate{
+		baseURL:    baseURL,
+		limit:      params.Limit,
+		sortValue:  params.SortValue(),
+		sortFormat: params.SortFormat,
+		seed:       params.Seed,
+		filters:    params.filterQuery(),
+	}
+	names := params.paramNames()
+	t.names = names
+	t.prefix = baseURL + "?" + names.limit + "=" + strconv.FormatUint(uint64(params.Limit), 10) + "&" + names.offset + "="
+	if t.sortValue != "" {
+		t.suffix = "&" + params.SortURL()
+	}
+	if t.filters != "" {
+		t.suffix += "&" + t.filters
+	}
+	return t
+}
+
+// Matches will check if the given params can be served by this template, that
+// means they have the same limit, sort and filters the template was compiled
+// with. The params using page tokens, opaque offsets, signed links, the page
+// number profile, omitting the default params or without limit never match
+func (t *LinkTemplate) Matches(params Params) bool {
+	if params.tokens != nil || params.offsets.opaque || params.signer != nil || params.unlimited || params.Profile != PageProfileOffset || params.defaults.omit || params.paramNames() != t.names {
+		return false
+	}
+	if len(params.Filters) > 0 || t.filters != "" {
+		if params.filterQuery() != t.filters {
+			return false
+		}
+	}
+	return params.Limit == t.limit &&
+		params.SortFormat == t.sortFormat &&
+		(params.Seed == t.seed || len(params.Sort) == 0) &&
+		sortMatches(params.Sort, t.sortValue, t.sortFormat)
+}
+
+// Links will build the links for the given params, when the params don't match
+// the template we fallback into building the whole links
+func (t *LinkTemplate) Links(params Params, dataSize int) (links Links) {
+	if !t.Matches(params) {
+		return buildLinks(t.baseURL, params, dataSize)
+	}
+	links.First = t.link(0)
+	if uint(dataSize) > params.Limit {
+		links.Next = t.link(params.Offset + params.Limit)
+	}
+	if params.Offset > 0 {
+		links.Prev = t.link(prevOffset(params))
+	}
+	return links
+}
+
+// Paginate will build a new paginated response like the Paginate function
+// does but using the precompiled template for the links
+func (t *LinkTemplate) Paginate(data []interface{}, params Params) Response {
+	params.pageServed(len(data))
+	response := Response{
+		Data:   buildData(data, params),
+		Links:  t.Links(params, len(data)),
+		Meta:   params.meta(),
+		stable: params.StableJSON,
+	}
+	params.paginated(context.Background(), response)
+	return response
+}
+
+// link method will substitute the given offset on the template
+func (t *LinkTemplate) link(offset uint) string {
+	var digits [20]byte
+	number := strconv.AppendUint(digits[:0], uint64(offset), 10)
+	var b strings.Builder
+	b.Grow(len(t.prefix) + len(number) + len(t.suffix))
+	b.WriteString(t.prefix)
+	b.Write(number)
+	b.WriteString(t.suffix)
+	return b.String()
+}
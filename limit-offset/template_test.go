@@ -0,0 +1,140 @@
+package pagination_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkTemplate(t *testing.T) {
+	sort := []pagination.Sort{
+		{
+			Field: "first_name",
+			Order: "asc",
+		},
+	}
+	template := pagination.NewLinkTemplate("/sample", pagination.Params{Limit: 5, Sort: sort})
+
+	tests := []struct {
+		name     string
+		params   pagination.Params
+		dataSize int
+	}{
+		{
+			name:     "First page",
+			params:   pagination.Params{Limit: 5, Offset: 0, Sort: sort},
+			dataSize: 6,
+		},
+		{
+			name:     "Intermediate page",
+			params:   pagination.Params{Limit: 5, Offset: 10, Sort: sort},
+			dataSize: 6,
+		},
+		{
+			name:     "Last page",
+			params:   pagination.Params{Limit: 5, Offset: 10, Sort: sort},
+			dataSize: 2,
+		},
+		{
+			name:     "Different limit than the template",
+			params:   pagination.Params{Limit: 10, Offset: 10, Sort: sort},
+			dataSize: 11,
+		},
+		{
+			name:     "Different sort than the template",
+			params:   pagination.Params{Limit: 5, Offset: 10},
+			dataSize: 6,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make([]interface{}, tt.dataSize)
+			want := pagination.Paginate(data, "/sample", tt.params)
+			assert.Equal(t, want, template.Paginate(data, tt.params))
+		})
+	}
+}
+
+func TestLinkTemplateMatches(t *testing.T) {
+	template := pagination.NewLinkTemplate("/sample", pagination.Params{Limit: 5})
+
+	assert.True(t, template.Matches(pagination.Params{Limit: 5, Offset: 20}))
+	assert.False(t, template.Matches(pagination.Params{Limit: 6}))
+	assert.False(t, template.Matches(pagination.Params{
+		Limit: 5,
+		Sort:  []pagination.Sort{{Field: "name", Order: "asc"}},
+	}))
+	assert.False(t, template.Matches(pagination.Params{
+		Limit:   5,
+		Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}},
+	}))
+}
+
+func TestLinkTemplateFilters(t *testing.T) {
+	params := pagination.Params{
+		Limit:   5,
+		Offset:  5,
+		Filters: pagination.Filters{{Field: "status", Operator: pagination.OpEq, Value: "active"}},
+	}
+	template := pagination.NewLinkTemplate("/sample", params)
+	data := make([]interface{}, 6)
+
+	assert.Equal(t, pagination.Paginate(data, "/sample", params), template.Paginate(data, params))
+	assert.Equal(t, "/sample?page[limit]=5&page[offset]=10&filter[status]=active", template.Paginate(data, params).Links.Next)
+}
+
+func TestLinkTemplateFallsBack(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy pagination.Policy
+		target string
+	}{
+		{
+			name:   "Opaque offsets",
+			policy: pagination.Policy{DefaultLimit: 2, OpaqueOffsets: true, OffsetTokens: pagination.NewTokenCodec(tokenKey)},
+			target: "/sample?sort=name.asc",
+		},
+		{
+			name:   "Signed links",
+			policy: pagination.Policy{DefaultLimit: 2, LinkSigner: pagination.NewLinkSigner(tokenKey)},
+			target: "/sample",
+		},
+		{
+			name:   "Without limit",
+			policy: pagination.Policy{DefaultLimit: 2, AllowUnlimited: func(context.Context) bool { return true }},
+			target: "/sample?page[limit]=all",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := tt.policy.FindParams(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			assert.NoError(t, err)
+			template := pagination.NewLinkTemplate("/sample", params)
+			data := make([]interface{}, 3)
+
+			assert.False(t, template.Matches(params))
+			assert.Equal(t, pagination.Paginate(data, "/sample", params), template.Paginate(data, params))
+		})
+	}
+}
+
+func BenchmarkLinkTemplatePaginate(b *testing.B) {
+	data := make([]interface{}, 11)
+	params := pagination.Params{
+		Limit:  10,
+		Offset: 20,
+		Sort:   []pagination.Sort{{Field: "name", Order: "asc"}},
+	}
+	template := pagination.NewLinkTemplate("/sample", params)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		template.Paginate(data, params)
+	}
+}
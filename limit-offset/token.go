@@ -0,0 +1,203 @@
+package pagination
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+// ParamPageToken is the value for the opaque page token parameter on http
+// request, see TokenCodec
+const ParamPageToken = "page[token]"
+
+// tokenVersion is the version of the format of the tokens issued
+const tokenVersion = 1
+
+// tokenMACSize is the size of the truncated HMAC appended to the tokens
+const tokenMACSize = 16
+
+// TokenCodec type issues and decodes the opaque page tokens. When it is set on
+// the Policy the links only carry a page[token] param, which wraps the query
+// the link would have had (limit, offset, sort, filters...) signed with HMAC
+// so the clients can't build or modify them. It is safe for concurrent use
+type TokenCodec struct {
+	key []byte
+	// TTL is how long the tokens are accepted since they were issued, zero
+	// means they never expire
+	TTL time.Duration
+	// CorrelationIDs embeds on the tokens the correlation ID of the request
+	// that issued them, see WithCorrelationID, so a token refused days later
+	// can be tied back to the logs of that request. The ID is not a secret,
+	// don't use the whole trace context or user information
+	CorrelationIDs bool
+	// KeyID identifies the key of the codec on the tamper reports, like the
+	// name of the secret, never the key itself
+	KeyID string
+
+	now func() time.Time
+}
+
+// NewTokenCodec will build a new codec signing the tokens with the given key,
+// it should be a random secret of at least 32 bytes shared by all the
+// instances of the service
+func NewTokenCodec(key []byte) *TokenCodec {
+	return &TokenCodec{
+		key: key,
+		now: time.Now,
+	}
+}
+
+// PageToken type encapsulates the information kept inside a page token
+type PageToken struct {
+	// Query is the query of the page, like page[limit]=10&page[offset]=20
+	Query string
+	// CorrelationID is the ID of the request that issued the token
+	CorrelationID string
+	// Scope is the principal the token was issued to, like the tenant ID,
+	// see WithTokenScope
+	Scope string
+	// IssuedAt is when the token was issued
+	IssuedAt time.Time
+}
+
+// tokenPayload type is the JSON representation of the PageToken
+type tokenPayload struct {
+	Query         string `json:"q"`
+	CorrelationID string `json:"c,omitempty"`
+	Scope         string `json:"s,omitempty"`
+	IssuedAt      int64  `json:"t"`
+}
+
+// Encode will build the opaque token for the given page token, the issued time
+// is set to now when it is zero
+func (c *TokenCodec) Encode(token PageToken) string {
+	if token.IssuedAt.IsZero() {
+		token.IssuedAt = c.now()
+	}
+	if !c.CorrelationIDs {
+		token.CorrelationID = ""
+	}
+	payload, _ := json.Marshal(tokenPayload{
+		Query:         token.Query,
+		CorrelationID: token.CorrelationID,
+		Scope:         token.Scope,
+		IssuedAt:      token.IssuedAt.Unix(),
+	})
+	b := make([]byte, 0, 1+len(payload)+tokenMACSize)
+	b = append(b, tokenVersion)
+	b = append(b, payload...)
+	b = append(b, c.mac(b)...)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// Decode will validate the given opaque token and answer back its content, a
+// *TokenError is returned for the tokens that can't be accepted, carrying the
+// correlation ID when it can be read from the token. The signature covers the
+// whole token, version included, so it is verified before anything else and
+// the forged tokens are always reported as tampered
+func (c *TokenCodec) Decode(value string) (PageToken, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(b) < 1+tokenMACSize {
+		return PageToken{}, &TokenError{Failure: TokenMalformed, Reason: "the token is malformed"}
+	}
+	signed, mac := b[:len(b)-tokenMACSize], b[len(b)-tokenMACSize:]
+	var payload tokenPayload
+	if !hmac.Equal(mac, c.mac(signed)) {
+		// The payload of a forged token is only read for the report
+		return PageToken{}, claimedTokenError(TokenTampered, "the token signature is wrong", signed[1:])
+	}
+	if signed[0] != tokenVersion {
+		return PageToken{}, claimedTokenError(TokenVersionMismatch, "the token version is not supported", signed[1:])
+	}
+	if err := json.Unmarshal(signed[1:], &payload); err != nil {
+		return PageToken{}, &TokenError{Failure: TokenMalformed, Reason: "the token is malformed"}
+	}
+	token := PageToken{
+		Query:         payload.Query,
+		CorrelationID: payload.CorrelationID,
+		Scope:         payload.Scope,
+		IssuedAt:      time.Unix(payload.IssuedAt, 0),
+	}
+	if c.TTL > 0 && c.now().Sub(token.IssuedAt) > c.TTL {
+		return PageToken{}, &TokenError{Failure: TokenExpired, Reason: "the token expired", CorrelationID: payload.CorrelationID, IssuedAt: time.Unix(payload.IssuedAt, 0)}
+	}
+	return token, nil
+}
+
+// claimedTokenError function will answer back the *TokenError of the given
+// failure with the correlation ID and the issue time claimed by the given
+// payload, both are left empty when the payload can't be read
+func claimedTokenError(failure TokenFailure, reason string, payload []byte) *TokenError {
+	tokenErr := &TokenError{Failure: failure, Reason: reason}
+	var claimed tokenPayload
+	if err := json.Unmarshal(payload, &claimed); err == nil {
+		tokenErr.CorrelationID = claimed.CorrelationID
+		if claimed.IssuedAt != 0 {
+			tokenErr.IssuedAt = time.Unix(claimed.IssuedAt, 0)
+		}
+	}
+	return tokenErr
+}
+
+// DecodeScoped method works like Decode but the token must have been issued
+// to the given scope, otherwise a *TokenError matching ErrTokenScopeMismatch
+// is returned, so the tokens of a tenant can't be replayed by another one
+func (c *TokenCodec) DecodeScoped(value, scope string) (PageToken, error) {
+	token, err := c.Decode(value)
+	if err != nil {
+		return token, err
+	}
+	if token.Scope != scope {
+		return PageToken{}, &TokenError{Failure: TokenScopeMismatch, Reason: "the token was issued for another scope", CorrelationID: token.CorrelationID, IssuedAt: token.IssuedAt}
+	}
+	return token, nil
+}
+
+// mac method will compute the truncated HMAC of the given bytes
+func (c *TokenCodec) mac(b []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(b)
+	return h.Sum(nil)[:tokenMACSize]
+}
+
+// link method will build the link carrying the given query inside a token
+func (c *TokenCodec) link(baseURL, query, correlationID, scope string) string {
+	return baseURL + "?" + ParamPageToken + "=" + c.Encode(PageToken{Query: query, CorrelationID: correlationID, Scope: scope})
+}
+
+// correlationIDKey is the context key of the correlation ID
+type correlationIDKey struct{}
+
+// WithCorrelationID will answer back a context carrying the given correlation
+// ID, usually the request ID set by a middleware, which is embedded on the
+// tokens issued for that request
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID will answer back the correlation ID of the given context
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// tokenScopeKey is the context key of the token scope
+type tokenScopeKey struct{}
+
+// WithTokenScope will answer back a context carrying the principal the page
+// tokens are bound to, like the tenant or the user ID set by the
+// authentication middleware. The tokens issued for the request are bound to
+// it and the policies refuse them when they come with another scope
+func WithTokenScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, tokenScopeKey{}, scope)
+}
+
+// TokenScope will answer back the token scope of the given context, empty
+// when there is none
+func TokenScope(ctx context.Context) string {
+	scope, _ := ctx.Value(tokenScopeKey{}).(string)
+	return scope
+}
@@ -0,0 +1,259 @@
+package pagination_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+var tokenKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestTokenCodec(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	codec.TTL = time.Hour
+	codec.CorrelationIDs = true
+
+	value := codec.Encode(pagination.PageToken{Query: "page[limit]=10&page[offset]=20", CorrelationID: "req-42"})
+	token, err := codec.Decode(value)
+	assert.Nil(t, err)
+	assert.Equal(t, "page[limit]=10&page[offset]=20", token.Query)
+	assert.Equal(t, "req-42", token.CorrelationID)
+
+	tests := []struct {
+		name              string
+		value             string
+		wantCorrelationID string
+	}{
+		{
+			name:  "Garbage",
+			value: "not a token",
+		},
+		{
+			name:  "Too short",
+			value: "AQ",
+		},
+		{
+			name:              "Signed with another key",
+			value:             pagination.NewTokenCodec([]byte("another key")).Encode(pagination.PageToken{Query: "page[offset]=0"}),
+			wantCorrelationID: "",
+		},
+		{
+			name:              "Expired",
+			value:             codec.Encode(pagination.PageToken{Query: "page[offset]=0", CorrelationID: "req-1", IssuedAt: time.Now().Add(-2 * time.Hour)}),
+			wantCorrelationID: "req-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := codec.Decode(tt.value)
+			assert.True(t, errors.Is(err, pagination.ErrInvalidToken))
+			var tokenErr *pagination.TokenError
+			assert.True(t, errors.As(err, &tokenErr))
+			assert.Equal(t, tt.wantCorrelationID, tokenErr.CorrelationID)
+		})
+	}
+}
+
+func TestTokenCodecWithoutCorrelationIDs(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+
+	token, err := codec.Decode(codec.Encode(pagination.PageToken{Query: "page[offset]=0", CorrelationID: "req-42"}))
+	assert.Nil(t, err)
+	assert.Equal(t, "", token.CorrelationID)
+}
+
+func TestPolicyTokens(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	codec.CorrelationIDs = true
+	policy := pagination.Policy{DefaultLimit: 2, Tokens: codec}
+
+	req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?sort=name.asc&filter[status]=active", nil)
+	assert.Nil(t, err)
+	req = req.WithContext(pagination.WithCorrelationID(context.Background(), "req-42"))
+	params, err := policy.FindParams(req)
+	assert.Nil(t, err)
+
+	links := pagination.Paginate(make([]interface{}, 3), "/sample", params).Links
+	assert.True(t, strings.HasPrefix(links.Next, "/sample?page[token]="))
+	next, err := url.Parse(links.Next)
+	assert.Nil(t, err)
+	token, err := codec.Decode(next.Query().Get(pagination.ParamPageToken))
+	assert.Nil(t, err)
+	assert.Equal(t, "page[limit]=2&page[offset]=2&sort=name.asc&filter[status]=active", token.Query)
+	assert.Equal(t, "req-42", token.CorrelationID)
+
+	// Following the link gives the params of the next page
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co"+links.Next, nil)
+	assert.Nil(t, err)
+	params, err = policy.FindParams(req)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(2), params.Offset)
+	assert.Equal(t, []pagination.Sort{{Field: "name", Order: "asc"}}, params.Sort)
+	assert.Equal(t, "active", params.Filters[0].Value)
+
+	req, err = http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[token]=tampered", nil)
+	assert.Nil(t, err)
+	_, err = policy.FindParams(req)
+	assert.True(t, errors.Is(err, pagination.ErrInvalidToken))
+}
+
+func TestScopedTokens(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	hook := &tokenRecordingHook{}
+	policy := pagination.Policy{DefaultLimit: 2, Tokens: codec, Metrics: hook}
+
+	req := httptest.NewRequest(http.MethodGet, "/sample", nil)
+	req = req.WithContext(pagination.WithTokenScope(req.Context(), "tenant-a"))
+	params, err := policy.FindParams(req)
+	assert.NoError(t, err)
+	next := pagination.Paginate(make([]interface{}, 3), "/sample", params).Links.Next
+	value := strings.TrimPrefix(next, "/sample?page[token]=")
+	token, err := codec.Decode(value)
+	assert.NoError(t, err)
+	assert.Equal(t, "tenant-a", token.Scope)
+
+	tests := []struct {
+		name    string
+		scope   string
+		wantErr error
+	}{
+		{name: "Same scope", scope: "tenant-a"},
+		{name: "Another tenant", scope: "tenant-b", wantErr: pagination.ErrTokenScopeMismatch},
+		{name: "Without scope", wantErr: pagination.ErrTokenScopeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, next, nil)
+			if tt.scope != "" {
+				req = req.WithContext(pagination.WithTokenScope(req.Context(), tt.scope))
+			}
+			params, err := policy.FindParams(req)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, err, pagination.ErrInvalidToken)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, uint(2), params.Offset)
+		})
+	}
+	assert.Equal(t, []pagination.TokenFailure{pagination.TokenScopeMismatch, pagination.TokenScopeMismatch}, hook.failures)
+
+	_, err = codec.DecodeScoped(value, "tenant-b")
+	var tokenErr *pagination.TokenError
+	if assert.ErrorAs(t, err, &tokenErr) {
+		assert.Equal(t, pagination.TokenScopeMismatch, tokenErr.Failure)
+	}
+}
+
+type tokenRecordingHook struct {
+	pagination.NopMetricsHook
+	failures []pagination.TokenFailure
+}
+
+func (h *tokenRecordingHook) OnTokenRejected(failure pagination.TokenFailure) {
+	h.failures = append(h.failures, failure)
+}
+
+func TestTokenFailures(t *testing.T) {
+	codec := pagination.NewTokenCodec(tokenKey)
+	codec.TTL = time.Hour
+	hook := &tokenRecordingHook{}
+	policy := pagination.Policy{DefaultLimit: 10, Tokens: codec, Metrics: hook}
+
+	valid := codec.Encode(pagination.PageToken{Query: "page[offset]=10"})
+	raw, err := base64.RawURLEncoding.DecodeString(valid)
+	assert.Nil(t, err)
+	tampered := append([]byte{}, raw...)
+	// Change the last digit of the issued time, keeping the payload readable
+	tampered[len(tampered)-tokenMACSizeForTest-2] ^= 1
+	// A token of another version signed with the same key
+	oldVersion := append([]byte{2}, raw[1:len(raw)-tokenMACSizeForTest]...)
+	mac := hmac.New(sha256.New, tokenKey)
+	mac.Write(oldVersion)
+	oldVersion = mac.Sum(oldVersion)[:len(oldVersion)+tokenMACSizeForTest]
+	forgedVersion := append([]byte{2}, raw[1:]...)
+	// A forged token whose payload can't be read claims no issue time
+	unreadable := append([]byte{}, raw...)
+	unreadable[1] = 'x'
+
+	tests := []struct {
+		name        string
+		value       string
+		wantFailure pagination.TokenFailure
+		wantErr     error
+		wantIssued  bool
+	}{
+		{
+			name:        "Garbage",
+			value:       "garbage!",
+			wantFailure: pagination.TokenMalformed,
+			wantErr:     pagination.ErrTokenMalformed,
+		},
+		{
+			name:        "Tampered",
+			value:       base64.RawURLEncoding.EncodeToString(tampered),
+			wantFailure: pagination.TokenTampered,
+			wantErr:     pagination.ErrTokenTampered,
+			wantIssued:  true,
+		},
+		{
+			name:        "Version mismatch",
+			value:       base64.RawURLEncoding.EncodeToString(oldVersion),
+			wantFailure: pagination.TokenVersionMismatch,
+			wantErr:     pagination.ErrTokenVersion,
+			wantIssued:  true,
+		},
+		{
+			name:        "Forged version",
+			value:       base64.RawURLEncoding.EncodeToString(forgedVersion),
+			wantFailure: pagination.TokenTampered,
+			wantErr:     pagination.ErrTokenTampered,
+			wantIssued:  true,
+		},
+		{
+			name:        "Tampered without a readable payload",
+			value:       base64.RawURLEncoding.EncodeToString(unreadable),
+			wantFailure: pagination.TokenTampered,
+			wantErr:     pagination.ErrTokenTampered,
+		},
+		{
+			name:        "Expired",
+			value:       codec.Encode(pagination.PageToken{Query: "page[offset]=10", IssuedAt: time.Now().Add(-2 * time.Hour)}),
+			wantFailure: pagination.TokenExpired,
+			wantErr:     pagination.ErrTokenExpired,
+			wantIssued:  true,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "app.quicka.co/api/sample?page[token]="+tt.value, nil)
+			assert.Nil(t, err)
+			_, err = policy.FindParams(req)
+			assert.True(t, errors.Is(err, pagination.ErrInvalidToken))
+			assert.True(t, errors.Is(err, tt.wantErr))
+			var tokenErr *pagination.TokenError
+			assert.True(t, errors.As(err, &tokenErr))
+			assert.Equal(t, tt.wantFailure, tokenErr.Failure)
+			assert.Equal(t, tt.wantIssued, !tokenErr.IssuedAt.IsZero())
+			assert.Equal(t, tt.wantFailure, hook.failures[i])
+		})
+	}
+}
+
+// tokenMACSizeForTest is the size of the signature at the end of the tokens
+const tokenMACSizeForTest = 16
@@ -0,0 +1,34 @@
+package pagination
+
+import "net/http"
+
+// LimitAll is the value of the limit param requesting the full result set,
+// only accepted for the callers trusted by the Policy.AllowUnlimited
+const LimitAll = "all"
+
+// Unlimited method will answer back if the params fetch the full result set,
+// in that case the Query doesn't have the LIMIT and OFFSET and the responses
+// don't have links, see WithoutLimit
+func (p Params) Unlimited() bool {
+	return p.unlimited
+}
+
+// WithoutLimit method will answer back a copy of the params fetching the full
+// result set, for the internal callers building the params by hand, like the
+// batch jobs, instead of faking a huge limit
+func (p Params) WithoutLimit() Params {
+	p.Limit, p.Offset = 0, 0
+	p.unlimited = true
+	return p
+}
+
+// unlimitedQuery method will answer back if the given raw query requests the
+// full result set and the caller is trusted to do it. The limit and the offset
+// are placed as zero before the raw query so they are the ones found
+func (p Policy) unlimitedQuery(req *http.Request, rawQuery string) (string, bool) {
+	names := p.names.orDefault()
+	if lookupParam(rawQuery, names.limit) != LimitAll || !p.AllowUnlimited(req.Context()) {
+		return rawQuery, false
+	}
+	return names.limit + "=0&" + names.offset + "=0&" + rawQuery, true
+}
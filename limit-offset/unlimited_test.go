@@ -0,0 +1,49 @@
+package pagination_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+// internalKey is the context key of the internal callers of the tests
+type internalKey struct{}
+
+func TestPolicyAllowUnlimited(t *testing.T) {
+	policy := pagination.Policy{
+		DefaultLimit: 10,
+		MaxLimit:     50,
+		AllowUnlimited: func(ctx context.Context) bool {
+			return ctx.Value(internalKey{}) != nil
+		},
+	}
+	req, _ := http.NewRequest(http.MethodGet, "/users?page[limit]=all&page[offset]=20&sort=name.asc", nil)
+
+	_, err := policy.FindParams(req)
+	assert.Error(t, err)
+
+	req = req.WithContext(context.WithValue(req.Context(), internalKey{}, true))
+	params, err := policy.FindParams(req)
+	assert.NoError(t, err)
+	assert.True(t, params.Unlimited())
+	assert.Equal(t, uint(0), params.EffectiveLimit())
+	assert.Equal(t, " ORDER BY name asc", params.Query())
+	assert.Equal(t, "SELECT * FROM users ORDER BY name asc", params.Compose("SELECT * FROM users"))
+
+	data := make([]interface{}, 100)
+	response := pagination.Paginate(data, "/users", params)
+	assert.Len(t, response.Data, 100)
+	assert.Equal(t, pagination.Links{}, response.Links)
+}
+
+func TestParamsWithoutLimit(t *testing.T) {
+	params := pagination.Params{Limit: 10, Offset: 20}.WithoutLimit()
+	assert.True(t, params.Unlimited())
+	assert.Empty(t, params.LimitOffsetClause())
+	assert.Equal(t, "SELECT * FROM users", params.Compose("SELECT * FROM users"))
+	assert.False(t, pagination.Params{Limit: 10}.Unlimited())
+	assert.False(t, params.Equal(pagination.Params{}))
+}
@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"strconv"
+	"strings"
+)
+
+// sqlOperators maps the filter operators into the SQL ones
+var sqlOperators = map[Operator]string{
+	OpEq:   "=",
+	OpNe:   "<>",
+	OpGt:   ">",
+	OpGte:  ">=",
+	OpLt:   "<",
+	OpLte:  "<=",
+	OpLike: "LIKE",
+}
+
+// Placeholder method will render the placeholder for the argument on the
+// given position, starting on 1
+func (d Dialect) Placeholder(position int) string {
+	if d == DialectPostgres {
+		return "$" + strconv.Itoa(position)
+	}
+	return "?"
+}
+
+// Where will build the WHERE part of the SQL query for the filters, using
+// placeholders for the values that are answered back as the args of the
+// query. The columns work as an allowlist mapping the filter fields into the
+// SQL columns, the filters of fields that aren't mapped are ignored. The
+// result can be composed with the clauses of the params
+//
+//	where, args := filters.Where(columns, pagination.DialectPostgres, 1)
+//	db.Select(&data, params.Compose("SELECT * FROM users"+where), args...)
+//
+// The first placeholder is the position used for the first arg, useful when
+// the parent query already has args on PostgreSQL
+func (f Filters) Where(columns map[string]string, dialect Dialect, firstPlaceholder int) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	position := firstPlaceholder
+	for _, filter := range f {
+		column, ok := columns[filter.Field]
+		if !ok {
+			continue
+		}
+		if filter.Operator == OpIn {
+			placeholders := make([]string, 0, len(filter.Values))
+			for i, value := range filter.Values {
+				placeholders = append(placeholders, dialect.Placeholder(position))
+				args = append(args, filter.arg(i, value))
+				position++
+			}
+			conditions = append(conditions, column+" IN ("+strings.Join(placeholders, ", ")+")")
+			continue
+		}
+		op, ok := sqlOperators[filter.Operator]
+		if !ok {
+			continue
+		}
+		conditions = append(conditions, column+" "+op+" "+dialect.Placeholder(position))
+		args = append(args, filter.arg(0, filter.Value))
+		position++
+	}
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// arg method will return the arg used on the query for the value on the given
+// position, the parsed one when the filter was coerced
+func (f Filter) arg(i int, value string) interface{} {
+	if i < len(f.Parsed) {
+		return f.Parsed[i]
+	}
+	return value
+}
@@ -0,0 +1,100 @@
+package pagination_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagination "github.com/ramonmacias/go-pagination/limit-offset"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFiltersWhere(t *testing.T) {
+	columns := map[string]string{
+		"status":     "status",
+		"owner_id":   "owner_id",
+		"created_at": "users.created_at",
+		"name":       "lower(name)",
+	}
+
+	tests := []struct {
+		name             string
+		filters          pagination.Filters
+		dialect          pagination.Dialect
+		firstPlaceholder int
+		want             string
+		wantArgs         []interface{}
+	}{
+		{
+			name:    "Without filters",
+			dialect: pagination.DialectPostgres,
+			want:    "",
+		},
+		{
+			name: "Postgres placeholders",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpIn, Value: "active,pending", Values: []string{"active", "pending"}},
+				{Field: "owner_id", Operator: pagination.OpNe, Value: "42"},
+				{Field: "created_at", Operator: pagination.OpGte, Value: "2024-01-01"},
+				{Field: "created_at", Operator: pagination.OpLt, Value: "2024-02-01"},
+			},
+			dialect:          pagination.DialectPostgres,
+			firstPlaceholder: 1,
+			want:             " WHERE status IN ($1, $2) AND owner_id <> $3 AND users.created_at >= $4 AND users.created_at < $5",
+			wantArgs:         []interface{}{"active", "pending", "42", "2024-01-01", "2024-02-01"},
+		},
+		{
+			name: "Postgres placeholders after parent args",
+			filters: pagination.Filters{
+				{Field: "name", Operator: pagination.OpLike, Value: "john%"},
+			},
+			dialect:          pagination.DialectPostgres,
+			firstPlaceholder: 3,
+			want:             " WHERE lower(name) LIKE $3",
+			wantArgs:         []interface{}{"john%"},
+		},
+		{
+			name: "MySQL placeholders",
+			filters: pagination.Filters{
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+				{Field: "owner_id", Operator: pagination.OpGt, Value: "42"},
+				{Field: "owner_id", Operator: pagination.OpLte, Value: "50"},
+			},
+			dialect:  pagination.DialectMySQL,
+			want:     " WHERE status = ? AND owner_id > ? AND owner_id <= ?",
+			wantArgs: []interface{}{"active", "42", "50"},
+		},
+		{
+			name: "Not allowed fields",
+			filters: pagination.Filters{
+				{Field: "password", Operator: pagination.OpEq, Value: "secret"},
+				{Field: "status", Operator: pagination.OpEq, Value: "active"},
+			},
+			dialect:  pagination.DialectSQLite,
+			want:     " WHERE status = ?",
+			wantArgs: []interface{}{"active"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := tt.filters.Where(columns, tt.dialect, tt.firstPlaceholder)
+			assert.Equal(t, tt.want, where)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestFiltersWhereWithQuery(t *testing.T) {
+	req, err := http.NewRequest(
+		http.MethodGet,
+		"app.quicka.co/api/sample?filter[status]=active&page[limit]=10&sort=name.asc",
+		nil,
+	)
+	assert.Nil(t, err)
+	params, err := pagination.FindParams(req, 0, 5)
+	assert.Nil(t, err)
+
+	where, args := pagination.FindFilters(req).Where(map[string]string{"status": "status"}, pagination.DialectPostgres, 1)
+	assert.Equal(t, "SELECT * FROM users WHERE status = $1 LIMIT 11 OFFSET 0 ORDER BY name asc", "SELECT * FROM users"+where+params.Query())
+	assert.Equal(t, []interface{}{"active"}, args)
+}